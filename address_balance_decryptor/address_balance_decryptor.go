@@ -8,6 +8,7 @@ import (
 	"pandora-pay/cryptography/crypto"
 	"pandora-pay/cryptography/crypto/balance_decryptor"
 	"pandora-pay/helpers/generics"
+	"pandora-pay/metrics"
 )
 
 type AddressBalanceDecryptor struct {
@@ -43,10 +44,15 @@ func (decryptor *AddressBalanceDecryptor) DecryptBalance(decryptionName string,
 
 	foundWork, loaded := decryptor.all.LoadOrStore(string(publicKey)+"_"+string(encryptedBalance), &addressBalanceDecryptorWork{balancePoint, previousValue, make(chan struct{}), ADDRESS_BALANCE_DECRYPTED_INIT, 0, nil, ctx, statusCallback})
 	if !loaded {
+		metrics.BalanceDecryptorQueue.Add(1)
 		decryptor.newWorkCn <- foundWork
 	}
 
 	<-foundWork.wait
+	if !loaded {
+		metrics.BalanceDecryptorQueue.Add(-1)
+	}
+
 	if foundWork.result.err != nil {
 		return 0, foundWork.result.err
 	}
@@ -8,19 +8,17 @@ import (
 	"math/big"
 	"pandora-pay/blockchain/blockchain_sync"
 	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/blocks/block"
 	"pandora-pay/blockchain/blocks/block/difficulty"
 	"pandora-pay/blockchain/blocks/block_complete"
 	"pandora-pay/blockchain/data_storage"
 	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/blockchain/forging/forging_block_work"
 	"pandora-pay/blockchain/transactions/transaction"
-	"pandora-pay/blockchain/transactions/transaction/transaction_type"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_extra"
-	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_script"
 	"pandora-pay/config"
 	"pandora-pay/config/config_coins"
-	"pandora-pay/config/config_stake"
 	"pandora-pay/gui"
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/generics"
@@ -38,6 +36,7 @@ import (
 
 type Blockchain struct {
 	ChainData                               *generics.Value[*BlockchainData]
+	prunedHeight                            *generics.Value[uint64] //blocks below it have no body stored
 	Sync                                    *blockchain_sync.BlockchainSync
 	mempool                                 *mempool.Mempool
 	wallet                                  *wallet.Wallet
@@ -59,19 +58,16 @@ func (chain *Blockchain) validateBlocks(blocksComplete []*block_complete.BlockCo
 		return errors.New("Blocks length is ZERO")
 	}
 
+	txs := []*transaction.Transaction{}
 	for _, blkComplete := range blocksComplete {
-
 		if err = blkComplete.Verify(); err != nil {
 			return
 		}
-
-		if err = chain.txsValidator.ValidateTxs(blkComplete.Txs); err != nil {
-			return
-		}
-
+		txs = append(txs, blkComplete.Txs...)
 	}
 
-	return
+	//the proofs of all the blocks are verified in a single batch
+	return chain.txsValidator.ValidateTxs(txs)
 }
 
 func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplete, calledByForging bool, exceptSocketUUID advanced_connection_types.UUID) (kernelHash []byte, err error) {
@@ -91,7 +87,7 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 		return
 	}
 
-	gui.GUI.Info("Including blocks " + strconv.FormatUint(blocksComplete[0].Height, 10) + " ... " + strconv.FormatUint(blocksComplete[len(blocksComplete)-1].Height, 10))
+	gui.Consensus().Info("Including blocks " + strconv.FormatUint(blocksComplete[0].Height, 10) + " ... " + strconv.FormatUint(blocksComplete[len(blocksComplete)-1].Height, 10))
 
 	//chain.RLock() is not required because it is guaranteed that no other thread is writing now in the chain
 	var newChainData = &BlockchainData{
@@ -126,6 +122,8 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 
 	var dataStorage *data_storage.DataStorage
 
+	prunedHeight := chain.GetPrunedHeight()
+
 	err = func() (err error) {
 
 		chain.mempool.SuspendProcessingCn <- struct{}{}
@@ -167,6 +165,10 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 			firstBlockComplete := blocksComplete[0]
 			if firstBlockComplete.Block.Height < newChainData.Height {
 
+				if firstBlockComplete.Block.Height < prunedHeight {
+					return errors.New("Reorg is not possible because the blocks were pruned")
+				}
+
 				index := newChainData.Height - 1
 				for {
 
@@ -186,7 +188,7 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 				}
 
 				if firstBlockComplete.Block.Height == 0 {
-					gui.GUI.Info("chain.createGenesisBlockchainData called")
+					gui.Consensus().Info("chain.createGenesisBlockchainData called")
 					newChainData = chain.createGenesisBlockchainData()
 					removedBlocksTransactionsCount = 0
 				} else {
@@ -224,47 +226,13 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 						return errors.New("Block Height is not right!")
 					}
 
-					//check existance of a tx with payloads
-					var foundStakingRewardTx *transaction.Transaction
-					for index, tx := range blkComplete.Txs {
-						if tx.Version == transaction_type.TX_ZETHER {
-							txBase := tx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
-							if len(txBase.Payloads) == 2 && txBase.Payloads[0].PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING && txBase.Payloads[1].PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING_REWARD {
-								if foundStakingRewardTx != nil {
-									return errors.New("Multiple txs with staking & reward payloads")
-								}
-								foundStakingRewardTx = tx
-								if index != len(blkComplete.Txs)-1 {
-									return errors.New("Staking reward tx should be the last one")
-								}
-								continue
-							}
-							for _, payload := range txBase.Payloads {
-								if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING || payload.PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING_REWARD {
-									return errors.New("Block contains other staking/reward payloads")
-								}
-							}
-						}
-					}
-
-					// not staking and reward tx
-					if foundStakingRewardTx == nil {
-						return errors.New("Block is missing Staking and Reward Transaction")
-					}
-
-					//check blkComplete balance
-					foundStakingRewardTxBase := foundStakingRewardTx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
-					if foundStakingRewardTxBase.Payloads[0].BurnValue < config_stake.GetRequiredStake(blkComplete.Block.Height) {
-						return errors.New("Staked amount is not enough!")
-					}
-
-					//verify staking amount
-					if foundStakingRewardTxBase.Payloads[0].BurnValue != blkComplete.StakingAmount {
-						return errors.New("Staked amount is different that the burn value")
+					if blkComplete.Block.Version != block.GetBlockVersion(blkComplete.Block.Height) {
+						return errors.New("Block Version is not right!")
 					}
 
-					if !bytes.Equal(foundStakingRewardTxBase.Payloads[0].Proof.Nonce(), blkComplete.StakingNonce) {
-						return errors.New("Staked Proof Nonce is not matching with the one specified in the block")
+					var foundStakingRewardTxBase *transaction_zether.TransactionZether
+					if foundStakingRewardTxBase, err = VerifyBlockStaking(blkComplete); err != nil {
+						return
 					}
 
 					//verify forger reward
@@ -321,13 +289,13 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 					}
 
 					if err = dataStorage.ProcessConditionalPayments(blkComplete.Height); err != nil {
-						return errors.New("Error Processing Pending Future: " + err.Error())
+						return errors.New("Error Processing Conditional Payments: " + err.Error())
 					}
 
 					//to detect if the savedBlock was done correctly
 					savedBlock = false
 
-					if allTransactionsChanges, err = chain.saveBlockComplete(writer, blkComplete, newChainData.TransactionsCount, removedTxHashes, allTransactionsChanges, dataStorage); err != nil {
+					if allTransactionsChanges, err = chain.saveBlockComplete(writer, blkComplete, newChainData.TransactionsCount, removedTxHashes, allTransactionsChanges, dataStorage, calledByForging); err != nil {
 						return errors.New("Error saving block complete: " + err.Error())
 					}
 
@@ -408,6 +376,12 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 					}
 				}
 
+				if config.PRUNE_BLOCKS > 0 {
+					if prunedHeight, err = chain.pruneBlocksComplete(writer, newChainData.Height, dataStorage); err != nil {
+						panic(err)
+					}
+				}
+
 				//let's keep the order as well
 				var removedCount, insertedCount int
 				for _, change := range allTransactionsChanges {
@@ -468,6 +442,7 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 	if err == nil {
 		kernelHash = newChainData.KernelHash
 		chain.ChainData.Store(newChainData)
+		chain.prunedHeight.Store(prunedHeight)
 		chain.mempool.ContinueProcessingCn <- mempool.CONTINUE_PROCESSING_NO_ERROR
 	} else {
 		chain.mempool.ContinueProcessingCn <- mempool.CONTINUE_PROCESSING_ERROR
@@ -497,10 +472,11 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 
 func CreateBlockchain(mempool *mempool.Mempool, txsValidator *txs_validator.TxsValidator) (*Blockchain, error) {
 
-	gui.GUI.Log("Blockchain init...")
+	gui.Consensus().Log("Blockchain init...")
 
 	chain := &Blockchain{
 		&generics.Value[*BlockchainData]{},
+		&generics.Value[uint64]{},
 		blockchain_sync.CreateBlockchainSync(),
 		mempool,
 		nil,
@@ -538,6 +514,14 @@ func (chain *Blockchain) InitializeChain() (err error) {
 		}
 	}
 
+	if err = chain.rebuildStateTree(); err != nil {
+		return
+	}
+
+	if err = chain.rebuildAccountTxsInfo(); err != nil {
+		return
+	}
+
 	chainData := chain.GetChainData()
 	chainData.updateChainInfo()
 
@@ -28,6 +28,13 @@ type BlockchainData struct {
 }
 
 func (chainData *BlockchainData) computeNextTargetBig(reader store_db_interface.StoreDBTransactionInterface) (*big.Int, error) {
+	return chainData.computeNextTargetBigExtra(func(height uint64) (*big.Int, uint64, error) {
+		return chainData.LoadTotalDifficultyExtra(reader, height)
+	})
+}
+
+// computeNextTargetBigExtra computes the next target reading the total difficulty and the timestamp via loadTotalDifficulty
+func (chainData *BlockchainData) computeNextTargetBigExtra(loadTotalDifficulty func(height uint64) (*big.Int, uint64, error)) (*big.Int, error) {
 
 	if config.DIFFICULTY_BLOCK_WINDOW > chainData.Height {
 		return chainData.Target, nil
@@ -35,7 +42,7 @@ func (chainData *BlockchainData) computeNextTargetBig(reader store_db_interface.
 
 	first := chainData.Height - config.DIFFICULTY_BLOCK_WINDOW
 
-	firstDifficulty, firstTimestamp, err := chainData.LoadTotalDifficultyExtra(reader, first+1)
+	firstDifficulty, firstTimestamp, err := loadTotalDifficulty(first + 1)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +57,7 @@ func (chain *Blockchain) createGenesisBlockchainData() *BlockchainData {
 
 func (chain *Blockchain) initializeNewChain(chainData *BlockchainData, dataStorage *data_storage.DataStorage) (err error) {
 
-	gui.GUI.Info("Initializing New Chain")
+	gui.Consensus().Info("Initializing New Chain")
 
 	supply := uint64(0)
 
@@ -112,6 +112,8 @@ func (chain *Blockchain) initializeNewChain(chainData *BlockchainData, dataStora
 		byte(config_coins.DECIMAL_SEPARATOR),
 		config_coins.MAX_SUPPLY_COINS_UNITS,
 		supply,
+		false,
+		false,
 		config_coins.BURN_PUBLIC_KEY,
 		config_coins.BURN_PUBLIC_KEY,
 		config_coins.NATIVE_ASSET_NAME,
@@ -155,6 +157,9 @@ func (chain *Blockchain) init() (*BlockchainData, error) {
 			}
 		}
 
+		//the state tree is committed since the genesis
+		writer.Put("stateTreeVersion", []byte(STATE_TREE_VERSION))
+
 		return
 
 	}); err != nil {
@@ -193,13 +198,13 @@ func (chain *Blockchain) createNextBlockForForging(chainData *BlockchainData, ne
 		var err error
 		if chainData.Height == 0 {
 			if blk, err = genesis.CreateNewGenesisBlock(); err != nil {
-				gui.GUI.Error("Error creating next block", err)
+				gui.Consensus().Error("Error creating next block", err)
 				return
 			}
 		} else {
 			blk = &block.Block{
 				BlockHeader: &block.BlockHeader{
-					Version: 0,
+					Version: block.GetBlockVersion(chainData.Height),
 					Height:  chainData.Height,
 				},
 				MerkleHash:     cryptography.SHA3([]byte{}),
@@ -207,6 +212,10 @@ func (chain *Blockchain) createNextBlockForForging(chainData *BlockchainData, ne
 				PrevKernelHash: chainData.KernelHash,
 				Timestamp:      chainData.Timestamp,
 			}
+			//the state root is computed when the block is included
+			if blk.Version == block.BLOCK_VERSION_STATE_HASH {
+				blk.StateHash = make([]byte, cryptography.HashSize)
+			}
 		}
 
 		blk.StakingNonce = make([]byte, 32)
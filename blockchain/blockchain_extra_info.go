@@ -1,13 +1,20 @@
 package blockchain
 
 import (
+	"encoding/binary"
 	"errors"
 	"github.com/vmihailenco/msgpack/v5"
 	"pandora-pay/blockchain/blockchain_types"
 	"pandora-pay/blockchain/blocks/block_complete"
 	"pandora-pay/blockchain/data_storage/assets"
 	"pandora-pay/blockchain/info"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
 	"pandora-pay/helpers/generics"
+	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
 )
@@ -46,6 +53,9 @@ func removeBlockCompleteInfo(writer store_db_interface.StoreDBTransactionInterfa
 
 			count -= 1
 			writer.Delete("addrTx:" + string(key) + ":" + strconv.FormatUint(count, 10))
+			if err = removeAccountTxInfo(writer, string(key), count); err != nil {
+				return
+			}
 			if count == 0 {
 				writer.Delete("addrTxsCount:" + string(key))
 			} else {
@@ -54,6 +64,143 @@ func removeBlockCompleteInfo(writer store_db_interface.StoreDBTransactionInterfa
 		}
 
 		writer.Delete("txKeys:" + string(txHash))
+
+		if err = removeConditionalPaymentsInfo(writer, txHash); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// the asset index of an account stores the positions of the account transactions that used the asset
+func saveAccountTxInfo(writer store_db_interface.StoreDBTransactionInterface, keyStr string, index uint64, accTxInfo *info.AccountTxInfo) (err error) {
+
+	var data []byte
+	if data, err = msgpack.Marshal(accTxInfo); err != nil {
+		return
+	}
+
+	indexStr := strconv.FormatUint(index, 10)
+	writer.Put("addrTxInfo:"+keyStr+":"+indexStr, data)
+
+	for _, asset := range accTxInfo.Assets {
+
+		count := uint64(0)
+		if data = writer.Get("addrAssetTxsCount:" + keyStr + string(asset)); data != nil {
+			if count, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+				return
+			}
+		}
+
+		writer.Put("addrAssetTx:"+keyStr+string(asset)+":"+strconv.FormatUint(count, 10), []byte(indexStr))
+		writer.Put("addrAssetTxsCount:"+keyStr+string(asset), []byte(strconv.FormatUint(count+1, 10)))
+	}
+
+	return
+}
+
+func removeAccountTxInfo(writer store_db_interface.StoreDBTransactionInterface, keyStr string, index uint64) (err error) {
+
+	data := writer.Get("addrTxInfo:" + keyStr + ":" + strconv.FormatUint(index, 10))
+	if data == nil { //txs stored before the asset index was introduced have no addrTxInfo
+		return
+	}
+
+	accTxInfo := &info.AccountTxInfo{}
+	if err = msgpack.Unmarshal(data, accTxInfo); err != nil {
+		return
+	}
+
+	for _, asset := range accTxInfo.Assets {
+
+		if data = writer.Get("addrAssetTxsCount:" + keyStr + string(asset)); data == nil {
+			return errors.New("addrAssetTxsCount: was empty")
+		}
+
+		var count uint64
+		if count, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+			return
+		}
+
+		count -= 1
+		writer.Delete("addrAssetTx:" + keyStr + string(asset) + ":" + strconv.FormatUint(count, 10))
+		if count == 0 {
+			writer.Delete("addrAssetTxsCount:" + keyStr + string(asset))
+		} else {
+			writer.Put("addrAssetTxsCount:"+keyStr+string(asset), []byte(strconv.FormatUint(count, 10)))
+		}
+	}
+
+	writer.Delete("addrTxInfo:" + keyStr + ":" + strconv.FormatUint(index, 10))
+	return
+}
+
+func removeConditionalPaymentsInfo(writer store_db_interface.StoreDBTransactionInterface, txHash []byte) (err error) {
+
+	data := writer.Get("txCondPayments:" + string(txHash))
+	if data == nil {
+		return
+	}
+
+	list := make([]*info.ConditionalPaymentInfo, 0)
+	if err = msgpack.Unmarshal(data, &list); err != nil {
+		return
+	}
+
+	for _, condPaymentInfo := range list {
+		for _, key := range condPaymentInfo.MultisigPublicKeys {
+
+			data = writer.Get("condPaymentsMultisigCount:" + string(key))
+			if data == nil {
+				return errors.New("condPaymentsMultisigCount: was empty")
+			}
+
+			var count uint64
+			if count, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+				return
+			}
+
+			count -= 1
+			writer.Delete("condPaymentMultisig:" + string(key) + ":" + strconv.FormatUint(count, 10))
+			if count == 0 {
+				writer.Delete("condPaymentsMultisigCount:" + string(key))
+			} else {
+				writer.Put("condPaymentsMultisigCount:"+string(key), []byte(strconv.FormatUint(count, 10)))
+			}
+		}
+	}
+
+	writer.Delete("txCondPayments:" + string(txHash))
+	return
+}
+
+func saveConditionalPaymentsInfo(writer store_db_interface.StoreDBTransactionInterface, tx *transaction.Transaction) (err error) {
+
+	list := info.CreateConditionalPaymentsInfoFromTx(tx)
+	if len(list) == 0 {
+		return
+	}
+
+	var data []byte
+	if data, err = msgpack.Marshal(list); err != nil {
+		return
+	}
+	writer.Put("txCondPayments:"+tx.Bloom.HashStr, data)
+
+	for _, condPaymentInfo := range list {
+		for _, key := range condPaymentInfo.MultisigPublicKeys {
+
+			count := uint64(0)
+			if data = writer.Get("condPaymentsMultisigCount:" + string(key)); data != nil {
+				if count, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+					return
+				}
+			}
+
+			writer.Put("condPaymentMultisig:"+string(key)+":"+strconv.FormatUint(count, 10), append(helpers.CloneBytes(tx.Bloom.Hash), condPaymentInfo.PayloadIndex))
+			writer.Put("condPaymentsMultisigCount:"+string(key), []byte(strconv.FormatUint(count+1, 10)))
+		}
 	}
 
 	return
@@ -184,9 +331,135 @@ func saveBlockCompleteInfo(writer store_db_interface.StoreDBTransactionInterface
 
 			writer.Put("addrTx:"+keyStr+":"+strconv.FormatUint(count, 10), tx.Bloom.Hash)
 			writer.Put("addrTxsCount:"+keyStr, []byte(strconv.FormatUint(count+1, 10)))
+
+			if err = saveAccountTxInfo(writer, keyStr, count, info.CreateAccountTxInfoFromTx(tx, blkComplete.Height, key)); err != nil {
+				return
+			}
+		}
+
+		if err = saveConditionalPaymentsInfo(writer, tx); err != nil {
+			return
+		}
+
+	}
+
+	return
+}
+
+const ACCOUNT_TXS_INFO_VERSION = "0"
+
+// loadAccountTxInfoFromTx creates the info of an account transaction from the stored tx
+func loadAccountTxInfoFromTx(reader store_db_interface.StoreDBTransactionInterface, key []byte, txHash []byte) (*info.AccountTxInfo, error) {
+
+	data := reader.Get("tx:" + string(txHash))
+	if data == nil {
+		return nil, errors.New("Tx of the account was not found")
+	}
+
+	tx := &transaction.Transaction{}
+	if err := tx.Deserialize(advanced_buffers.NewBufferReader(data)); err != nil {
+		return nil, err
+	}
+	if err := tx.BloomAll(); err != nil {
+		return nil, err
+	}
+
+	if data = reader.Get("txBlock:" + string(txHash)); data == nil {
+		return nil, errors.New("Block of the account tx was not found")
+	}
+	blkHeight, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errors.New("Block of the account tx is invalid")
+	}
+
+	return info.CreateAccountTxInfoFromTx(tx, blkHeight, key), nil
+}
+
+// rebuildAccountTxInfo creates the missing addrTxInfo of the account and rebuilds its asset index.
+// The asset index is saved again in the order of the account txs as the history is searched by height
+func rebuildAccountTxInfo(writer store_db_interface.StoreDBTransactionInterface, keyStr string, count uint64) (err error) {
+
+	infos := make([]*info.AccountTxInfo, count)
+	missing := false
+
+	for i := uint64(0); i < count; i++ {
+		data := writer.Get("addrTxInfo:" + keyStr + ":" + strconv.FormatUint(i, 10))
+		if data == nil {
+			missing = true
+			continue
+		}
+		infos[i] = &info.AccountTxInfo{}
+		if err = msgpack.Unmarshal(data, infos[i]); err != nil {
+			return
 		}
+	}
+
+	if !missing {
+		return
+	}
 
+	for i := count; i > 0; i-- {
+		if err = removeAccountTxInfo(writer, keyStr, i-1); err != nil {
+			return
+		}
+	}
+
+	for i := uint64(0); i < count; i++ {
+		if infos[i] == nil {
+			txHash := writer.Get("addrTx:" + keyStr + ":" + strconv.FormatUint(i, 10))
+			if txHash == nil {
+				return errors.New("addrTx: was empty")
+			}
+			if infos[i], err = loadAccountTxInfoFromTx(writer, []byte(keyStr), txHash); err != nil {
+				return
+			}
+		}
+		if err = saveAccountTxInfo(writer, keyStr, i, infos[i]); err != nil {
+			return
+		}
 	}
 
 	return
 }
+
+// rebuildAccountTxsInfo creates the addrTxInfo of the account txs stored before the asset index was introduced
+func (chain *Blockchain) rebuildAccountTxsInfo() error {
+
+	if !config.SEED_WALLET_NODES_INFO {
+		return nil
+	}
+
+	return store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if writer.Exists("accountTxsInfoVersion") {
+			return
+		}
+
+		iterable, ok := writer.(store_db_interface.StoreDBTransactionIterableInterface)
+		if !ok {
+			return errors.New("Chain store doesn't support the account txs info rebuild")
+		}
+
+		gui.Consensus().Info("Building the account txs info")
+
+		counts := make(map[string]uint64)
+		if err = iterable.IterateByPrefix("addrTxsCount:", func(key string, value []byte) (err error) {
+			counts[key[len("addrTxsCount:"):]], err = strconv.ParseUint(string(value), 10, 64)
+			return
+		}); err != nil {
+			return
+		}
+
+		//the store is changed only after the iteration as it can't be changed during it
+		for keyStr, count := range counts {
+			if err = rebuildAccountTxInfo(writer, keyStr, count); err != nil {
+				return
+			}
+		}
+
+		writer.Put("accountTxsInfoVersion", []byte(ACCOUNT_TXS_INFO_VERSION))
+
+		gui.Consensus().Info("Account txs info built")
+		return
+	})
+}
@@ -0,0 +1,152 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account/asset_fee_liquidity"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"strconv"
+	"testing"
+)
+
+func TestRemoveAccountTxInfo(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	keyStr := string(cryptography.RandomHash())
+	asset := config_coins.NATIVE_ASSET_FULL
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		//index 0 was stored before the asset index was introduced
+		assert.NoError(t, saveAccountTxInfo(writer, keyStr, 1, &info.AccountTxInfo{TxHash: cryptography.RandomHash(), Assets: [][]byte{asset}}))
+		assert.NoError(t, saveAccountTxInfo(writer, keyStr, 2, &info.AccountTxInfo{TxHash: cryptography.RandomHash(), Assets: [][]byte{asset}}))
+		assert.Equal(t, []byte("2"), writer.Get("addrAssetTxsCount:"+keyStr+string(asset)))
+
+		assert.NoError(t, removeAccountTxInfo(writer, keyStr, 2))
+		assert.Nil(t, writer.Get("addrTxInfo:"+keyStr+":2"))
+		assert.Nil(t, writer.Get("addrAssetTx:"+keyStr+string(asset)+":1"))
+		assert.Equal(t, []byte("1"), writer.Get("addrAssetTxsCount:"+keyStr+string(asset)))
+
+		assert.NoError(t, removeAccountTxInfo(writer, keyStr, 1))
+		assert.Nil(t, writer.Get("addrAssetTxsCount:"+keyStr+string(asset)))
+
+		assert.NoError(t, removeAccountTxInfo(writer, keyStr, 0), "txs stored before the upgrade must be removable in a reorg")
+
+		return
+	}))
+
+}
+
+func TestRebuildAccountTxsInfo(t *testing.T) {
+
+	initTestSnapshotStore(t)
+	config.SEED_WALLET_NODES_INFO = true
+	defer func() { config.SEED_WALLET_NODES_INFO = false }()
+
+	privateKey := addresses.GenerateNewPrivateKey()
+	publicKey := privateKey.GeneratePublicKey()
+	keyStr := string(publicKey)
+	asset := config_coins.NATIVE_ASSET_FULL
+
+	tx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+		&wizard.WizardTxSimpleExtraClaim{nil, 10, addresses.GenerateNewPrivateKey().GeneratePublicKey()},
+		&wizard.WizardTransactionData{nil, false},
+		&wizard.WizardTransactionFee{10, 0, 0, false},
+		0,
+		privateKey.Key,
+	}, false, func(string) {})
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		//index 0 was stored before the asset index was introduced
+		writer.Put("tx:"+tx.Bloom.HashStr, tx.Bloom.Serialized)
+		writer.Put("txBlock:"+tx.Bloom.HashStr, binary.AppendUvarint(nil, 5))
+		writer.Put("addrTx:"+keyStr+":0", tx.Bloom.Hash)
+
+		for i := uint64(1); i < 3; i++ {
+			txHash := cryptography.RandomHash()
+			writer.Put("addrTx:"+keyStr+":"+strconv.FormatUint(i, 10), txHash)
+			assert.NoError(t, saveAccountTxInfo(writer, keyStr, i, &info.AccountTxInfo{TxHash: txHash, BlkHeight: 5 + i, Assets: [][]byte{asset}}))
+		}
+		writer.Put("addrTxsCount:"+keyStr, []byte("3"))
+
+		return
+	}))
+
+	chain := &Blockchain{}
+	assert.NoError(t, chain.rebuildAccountTxsInfo())
+
+	assert.NoError(t, store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+
+		accTxInfo := &info.AccountTxInfo{}
+		assert.NoError(t, msgpack.Unmarshal(reader.Get("addrTxInfo:"+keyStr+":0"), accTxInfo))
+		assert.Equal(t, tx.Bloom.Hash, accTxInfo.TxHash)
+		assert.Equal(t, uint64(5), accTxInfo.BlkHeight)
+		assert.Equal(t, info.ACCOUNT_TX_DIRECTION_OUT, accTxInfo.Direction)
+
+		assert.Equal(t, [][]byte{asset}, accTxInfo.Assets)
+
+		assert.Equal(t, []byte("3"), reader.Get("addrAssetTxsCount:"+keyStr+string(asset)))
+		assert.Equal(t, []byte("0"), reader.Get("addrAssetTx:"+keyStr+string(asset)+":0"))
+		assert.Equal(t, []byte("1"), reader.Get("addrAssetTx:"+keyStr+string(asset)+":1"))
+		assert.Equal(t, []byte("2"), reader.Get("addrAssetTx:"+keyStr+string(asset)+":2"))
+
+		assert.True(t, reader.Exists("accountTxsInfoVersion"))
+		return nil
+	}))
+
+}
+
+func TestCreateAccountTxInfoFromTx(t *testing.T) {
+
+	privateKey := addresses.GenerateNewPrivateKey()
+	senderPublicKey, receiverPublicKey := privateKey.GeneratePublicKey(), addresses.GenerateNewPrivateKey().GeneratePublicKey()
+	liquidityAsset := cryptography.RandomHash()[:config_coins.ASSET_LENGTH]
+
+	claimTx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+		&wizard.WizardTxSimpleExtraClaim{nil, 10, receiverPublicKey},
+		&wizard.WizardTransactionData{nil, false},
+		&wizard.WizardTransactionFee{10, 0, 0, false},
+		0,
+		privateKey.Key,
+	}, false, func(string) {})
+	assert.NoError(t, err)
+
+	liquidityTx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+		&wizard.WizardTxSimpleExtraUpdateAssetFeeLiquidity{nil, []*asset_fee_liquidity.AssetFeeLiquidity{{liquidityAsset, 1, 1}}, false, nil},
+		&wizard.WizardTransactionData{nil, false},
+		&wizard.WizardTransactionFee{10, 0, 0, false},
+		0,
+		privateKey.Key,
+	}, false, func(string) {})
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		name      string
+		tx        *transaction.Transaction
+		publicKey []byte
+		direction info.AccountTxDirection
+		assets    [][]byte
+	}{
+		{"claim sender", claimTx, senderPublicKey, info.ACCOUNT_TX_DIRECTION_OUT, [][]byte{config_coins.NATIVE_ASSET_FULL}},
+		{"claim receiver", claimTx, receiverPublicKey, info.ACCOUNT_TX_DIRECTION_IN, [][]byte{config_coins.NATIVE_ASSET_FULL}},
+		{"update asset fee liquidity", liquidityTx, senderPublicKey, info.ACCOUNT_TX_DIRECTION_OUT, [][]byte{config_coins.NATIVE_ASSET_FULL, liquidityAsset}},
+	} {
+		accTxInfo := info.CreateAccountTxInfoFromTx(test.tx, 5, test.publicKey)
+		assert.Equal(t, test.direction, accTxInfo.Direction, test.name)
+		assert.Equal(t, test.assets, accTxInfo.Assets, test.name)
+	}
+}
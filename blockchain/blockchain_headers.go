@@ -0,0 +1,157 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block/difficulty"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_script"
+	"pandora-pay/config"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"time"
+)
+
+type blockHeaderDifficulty struct {
+	totalDifficulty *big.Int
+	timestamp       uint64
+}
+
+// ValidateBlockHeaders verifies that the headers are linked to the chain and that the kernel hashes meet the difficulty computed along the headers. It returns the total difficulty after the last header.
+// The staking amounts claimed by the headers are verified by VerifyBlockStaking once the blocks arrive
+func (chain *Blockchain) ValidateBlockHeaders(headers []*block.Block) (*big.Int, error) {
+
+	if len(headers) == 0 {
+		return nil, errors.New("Headers length is ZERO")
+	}
+
+	var bigTotalDifficulty *big.Int
+
+	if err := store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		var chainData *BlockchainData
+		if headers[0].Height == 0 {
+			chainData = chain.createGenesisBlockchainData()
+		} else {
+			chainData = &BlockchainData{}
+			if err = chainData.loadBlockchainInfo(reader, headers[0].Height); err != nil {
+				return
+			}
+		}
+
+		//the difficulties of the headers are not stored yet
+		difficulties := make(map[uint64]*blockHeaderDifficulty)
+		loadTotalDifficulty := func(height uint64) (*big.Int, uint64, error) {
+			if data := difficulties[height]; data != nil {
+				return data.totalDifficulty, data.timestamp, nil
+			}
+			return chainData.LoadTotalDifficultyExtra(reader, height)
+		}
+
+		for _, header := range headers {
+
+			if err = header.BloomNow(); err != nil {
+				return
+			}
+
+			if header.Height != chainData.Height {
+				return errors.New("Header Height is not right!")
+			}
+			if header.Version != block.GetBlockVersion(header.Height) {
+				return errors.New("Header Version is not right!")
+			}
+			if !bytes.Equal(header.PrevHash, chainData.Hash) {
+				return errors.New("Header PrevHash is not matching")
+			}
+			if !bytes.Equal(header.PrevKernelHash, chainData.KernelHash) {
+				return errors.New("Header PrevKernelHash is not matching")
+			}
+			if header.Timestamp < chainData.Timestamp {
+				return errors.New("Timestamp has to be greater than the last timestmap")
+			}
+			if header.Timestamp > uint64(time.Now().UTC().Unix())+config.NETWORK_TIMESTAMP_DRIFT_MAX {
+				return errors.New("Timestamp is too much into the future")
+			}
+			if !difficulty.CheckKernelHashBig(header.Bloom.KernelHashStaked, chainData.Target) {
+				return errors.New("KernelHash Difficulty is not met")
+			}
+
+			chainData.PrevHash = chainData.Hash
+			chainData.Hash = header.Bloom.Hash
+			chainData.PrevKernelHash = chainData.KernelHash
+			chainData.KernelHash = header.Bloom.KernelHash
+			chainData.Timestamp = header.Timestamp
+			chainData.BigTotalDifficulty = new(big.Int).Add(chainData.BigTotalDifficulty, difficulty.ConvertTargetToDifficulty(chainData.Target))
+
+			if chainData.Target, err = chainData.computeNextTargetBigExtra(loadTotalDifficulty); err != nil {
+				return
+			}
+
+			chainData.Height += 1
+			difficulties[chainData.Height] = &blockHeaderDifficulty{chainData.BigTotalDifficulty, chainData.Timestamp}
+		}
+
+		bigTotalDifficulty = chainData.BigTotalDifficulty
+		return
+	}); err != nil {
+		return nil, err
+	}
+
+	return bigTotalDifficulty, nil
+}
+
+// VerifyBlockStaking verifies that the block has a single staking and reward transaction and that it proves the staking amount and the staking nonce claimed by the header.
+// The headers downloaded first are validated against these claims, so it is verified as soon as the block arrives. It returns the staking and reward transaction
+func VerifyBlockStaking(blkComplete *block_complete.BlockComplete) (*transaction_zether.TransactionZether, error) {
+
+	//check existance of a tx with payloads
+	var foundStakingRewardTx *transaction.Transaction
+	for index, tx := range blkComplete.Txs {
+		if tx.Version == transaction_type.TX_ZETHER {
+			txBase := tx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
+			if len(txBase.Payloads) == 2 && txBase.Payloads[0].PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING && txBase.Payloads[1].PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING_REWARD {
+				if foundStakingRewardTx != nil {
+					return nil, errors.New("Multiple txs with staking & reward payloads")
+				}
+				foundStakingRewardTx = tx
+				if index != len(blkComplete.Txs)-1 {
+					return nil, errors.New("Staking reward tx should be the last one")
+				}
+				continue
+			}
+			for _, payload := range txBase.Payloads {
+				if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING || payload.PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING_REWARD {
+					return nil, errors.New("Block contains other staking/reward payloads")
+				}
+			}
+		}
+	}
+
+	// not staking and reward tx
+	if foundStakingRewardTx == nil {
+		return nil, errors.New("Block is missing Staking and Reward Transaction")
+	}
+
+	//check blkComplete balance
+	foundStakingRewardTxBase := foundStakingRewardTx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
+	if foundStakingRewardTxBase.Payloads[0].BurnValue < config_stake.GetRequiredStake(blkComplete.Block.Height) {
+		return nil, errors.New("Staked amount is not enough!")
+	}
+
+	//verify staking amount
+	if foundStakingRewardTxBase.Payloads[0].BurnValue != blkComplete.StakingAmount {
+		return nil, errors.New("Staked amount is different that the burn value")
+	}
+
+	if !bytes.Equal(foundStakingRewardTxBase.Payloads[0].Proof.Nonce(), blkComplete.StakingNonce) {
+		return nil, errors.New("Staked Proof Nonce is not matching with the one specified in the block")
+	}
+
+	return foundStakingRewardTxBase, nil
+}
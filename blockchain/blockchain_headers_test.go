@@ -0,0 +1,201 @@
+package blockchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block/difficulty"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_script"
+	"pandora-pay/blockchain/genesis"
+	"pandora-pay/config"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+	"time"
+)
+
+func initTestHeaders(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{Name: "blockchain", Opened: true, DB: db}
+
+	genesis.GenesisData = &genesis.GenesisDataType{
+		Hash:       cryptography.SHA3([]byte("genesis")),
+		KernelHash: cryptography.SHA3([]byte("genesis kernel")),
+		Timestamp:  uint64(time.Now().Unix()) - 1000,
+		Target:     helpers.DecodeHex("00FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"),
+	}
+}
+
+// createTestHeaders returns headers linked to the genesis. The staking amount is large enough to meet the difficulty
+func createTestHeaders(t *testing.T, count int) []*block.Block {
+
+	headers := make([]*block.Block, count)
+	prevHash, prevKernelHash, timestamp := genesis.GenesisData.Hash, genesis.GenesisData.KernelHash, genesis.GenesisData.Timestamp
+
+	for i := range headers {
+		blk := &block.Block{
+			BlockHeader:    &block.BlockHeader{Version: block.GetBlockVersion(uint64(i)), Height: uint64(i)},
+			MerkleHash:     cryptography.SHA3([]byte{}),
+			PrevHash:       prevHash,
+			PrevKernelHash: prevKernelHash,
+			Timestamp:      timestamp + config.BLOCK_TIME,
+			StakingAmount:  1 << 60,
+			StakingNonce:   helpers.RandomBytes(32),
+		}
+		if blk.Version == block.BLOCK_VERSION_STATE_HASH {
+			blk.StateHash = make([]byte, cryptography.HashSize)
+		}
+		assert.NoError(t, blk.BloomNow())
+
+		headers[i] = blk
+		prevHash, prevKernelHash, timestamp = blk.Bloom.Hash, blk.Bloom.KernelHash, blk.Timestamp
+	}
+
+	return headers
+}
+
+func TestValidateBlockHeaders(t *testing.T) {
+
+	initTestHeaders(t)
+	chain := &Blockchain{}
+
+	headers := createTestHeaders(t, 5)
+	totalDifficulty, err := chain.ValidateBlockHeaders(headers)
+	assert.NoError(t, err)
+
+	target := new(big.Int).SetBytes(genesis.GenesisData.Target)
+	assert.Equal(t, new(big.Int).Mul(difficulty.ConvertTargetToDifficulty(target), big.NewInt(5)), totalDifficulty)
+
+	_, err = chain.ValidateBlockHeaders(nil)
+	assert.Error(t, err)
+}
+
+func TestValidateBlockHeaders_BadLink(t *testing.T) {
+
+	initTestHeaders(t)
+	chain := &Blockchain{}
+
+	headers := createTestHeaders(t, 5)
+	headers[2].PrevHash = cryptography.RandomHash()
+	headers[2].Bloom = nil
+	_, err := chain.ValidateBlockHeaders(headers)
+	assert.EqualError(t, err, "Header PrevHash is not matching")
+
+	headers = createTestHeaders(t, 5)
+	headers[3].PrevKernelHash = cryptography.RandomHash()
+	headers[3].Bloom = nil
+	_, err = chain.ValidateBlockHeaders(headers)
+	assert.EqualError(t, err, "Header PrevKernelHash is not matching")
+
+	headers = createTestHeaders(t, 5)
+	headers[1], headers[2] = headers[2], headers[1]
+	_, err = chain.ValidateBlockHeaders(headers)
+	assert.EqualError(t, err, "Header Height is not right!")
+}
+
+func TestValidateBlockHeaders_BadDifficulty(t *testing.T) {
+
+	initTestHeaders(t)
+	chain := &Blockchain{}
+
+	headers := createTestHeaders(t, 5)
+
+	//the last header is staked with too little to meet the target
+	target := new(big.Int).SetBytes(genesis.GenesisData.Target)
+	last := headers[4]
+	last.StakingAmount = 1
+	for {
+		last.Bloom = nil
+		assert.NoError(t, last.BloomNow())
+		if !difficulty.CheckKernelHashBig(last.Bloom.KernelHashStaked, target) {
+			break
+		}
+		last.StakingNonce = helpers.RandomBytes(32)
+	}
+
+	_, err := chain.ValidateBlockHeaders(headers)
+	assert.EqualError(t, err, "KernelHash Difficulty is not met")
+
+	_, err = chain.ValidateBlockHeaders(headers[:4])
+	assert.NoError(t, err)
+}
+
+func TestValidateBlockHeaders_BadTimestamp(t *testing.T) {
+
+	initTestHeaders(t)
+	chain := &Blockchain{}
+
+	headers := createTestHeaders(t, 5)
+	headers[2].Timestamp = headers[1].Timestamp - 1
+	headers[2].Bloom = nil
+	_, err := chain.ValidateBlockHeaders(headers[:3])
+	assert.EqualError(t, err, "Timestamp has to be greater than the last timestmap")
+
+	headers = createTestHeaders(t, 5)
+	headers[4].Timestamp = uint64(time.Now().Unix()) + config.NETWORK_TIMESTAMP_DRIFT_MAX + 100
+	headers[4].Bloom = nil
+	_, err = chain.ValidateBlockHeaders(headers)
+	assert.EqualError(t, err, "Timestamp is too much into the future")
+}
+
+func TestVerifyBlockStaking(t *testing.T) {
+
+	requiredStake := config_stake.GetRequiredStake(0)
+
+	createTestTx := func(scripts ...transaction_zether_payload_script.PayloadScriptType) *transaction.Transaction {
+		payloads := make([]*transaction_zether_payload.TransactionZetherPayload, len(scripts))
+		for i, script := range scripts {
+			payloads[i] = &transaction_zether_payload.TransactionZetherPayload{PayloadScript: script, BurnValue: requiredStake}
+		}
+		return &transaction.Transaction{
+			TransactionBaseInterface: &transaction_zether.TransactionZether{Payloads: payloads},
+			Version:                  transaction_type.TX_ZETHER,
+		}
+	}
+	stakingTx := func() *transaction.Transaction {
+		return createTestTx(transaction_zether_payload_script.SCRIPT_STAKING, transaction_zether_payload_script.SCRIPT_STAKING_REWARD)
+	}
+	transferTx := func() *transaction.Transaction {
+		return createTestTx(transaction_zether_payload_script.SCRIPT_TRANSFER)
+	}
+
+	for _, test := range []struct {
+		name          string
+		txs           []*transaction.Transaction
+		stakingAmount uint64
+		err           string
+	}{
+		{"missing staking tx", []*transaction.Transaction{transferTx()}, requiredStake, "Block is missing Staking and Reward Transaction"},
+		{"staking tx not last", []*transaction.Transaction{stakingTx(), transferTx()}, requiredStake, "Staking reward tx should be the last one"},
+		{"multiple staking txs", []*transaction.Transaction{stakingTx(), stakingTx()}, requiredStake, "Staking reward tx should be the last one"},
+		{"other staking payloads", []*transaction.Transaction{createTestTx(transaction_zether_payload_script.SCRIPT_STAKING), stakingTx()}, requiredStake, "Block contains other staking/reward payloads"},
+		{"header claims more than staked", []*transaction.Transaction{transferTx(), stakingTx()}, requiredStake + 1, "Staked amount is different that the burn value"},
+		{"header claims less than staked", []*transaction.Transaction{stakingTx()}, requiredStake - 1, "Staked amount is different that the burn value"},
+	} {
+		blkComplete := &block_complete.BlockComplete{
+			Block: &block.Block{BlockHeader: &block.BlockHeader{}, StakingAmount: test.stakingAmount},
+			Txs:   test.txs,
+		}
+		_, err := VerifyBlockStaking(blkComplete)
+		assert.EqualError(t, err, test.err, test.name)
+	}
+
+	//the staked amount must be enough even if the header claims it
+	tx := stakingTx()
+	tx.TransactionBaseInterface.(*transaction_zether.TransactionZether).Payloads[0].BurnValue = requiredStake - 1
+	_, err := VerifyBlockStaking(&block_complete.BlockComplete{
+		Block: &block.Block{BlockHeader: &block.BlockHeader{}, StakingAmount: requiredStake - 1},
+		Txs:   []*transaction.Transaction{tx},
+	})
+	assert.EqualError(t, err, "Staked amount is not enough!")
+}
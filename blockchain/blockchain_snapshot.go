@@ -0,0 +1,340 @@
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/vmihailenco/msgpack/v5"
+	"golang.org/x/crypto/sha3"
+	"hash"
+	"io"
+	"os"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+	"strings"
+)
+
+const SNAPSHOT_VERSION = uint64(0)
+
+// committed hashmaps of the DataStorage. Transitional changes are not exported as reorgs below the snapshot are not possible
+var snapshotStatePrefixes = []string{"registrations:", "plainAccs:", "assets:", "accounts:", "accounts_", "pendingStakes:", "conditionalPayments:", "conditionalPayments_", "stateTree:"}
+
+// chain keys required to continue syncing after the snapshot height
+var snapshotChainKeys = []string{"blockchainInfo", "chainHeight", "chainHash", "chainPrevHash", "chainKernelHash", "chainPrevKernelHash", "stateTreeVersion"}
+
+func snapshotPrefixes(reader store_db_interface.StoreDBTransactionInterface) ([]string, error) {
+
+	prefixes := append([]string{}, snapshotStatePrefixes...)
+
+	//assets fee liquidity max heaps are named by the asset
+	dataStorage := data_storage.NewDataStorage(reader)
+	for i := uint64(0); i < dataStorage.Asts.Count; i++ {
+		assetId, err := dataStorage.Asts.GetKeyByIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, string(assetId)+":", string(assetId)+"_dict:")
+	}
+
+	return prefixes, nil
+}
+
+// snapshotWriter streams the snapshot into the file computing its checksum
+type snapshotWriter struct {
+	w      *bufio.Writer
+	hasher hash.Hash
+	temp   []byte
+	err    error
+}
+
+func (writer *snapshotWriter) write(data []byte) {
+	if writer.err != nil {
+		return
+	}
+	writer.hasher.Write(data)
+	_, writer.err = writer.w.Write(data)
+}
+
+func (writer *snapshotWriter) writeUvarint(value uint64) {
+	n := binary.PutUvarint(writer.temp, value)
+	writer.write(writer.temp[:n])
+}
+
+func (writer *snapshotWriter) writeVariableBytes(data []byte) {
+	writer.writeUvarint(uint64(len(data)))
+	writer.write(data)
+}
+
+// ExportSnapshot streams the committed state and the BlockchainData of the current height into a versioned, checksummed file.
+// The entries are followed by an empty key as their count is not known in advance
+func (chain *Blockchain) ExportSnapshot(path string) (err error) {
+
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	chainData := chain.GetChainData()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+
+	w := &snapshotWriter{w: bufio.NewWriter(file), hasher: sha3.New256(), temp: make([]byte, binary.MaxVarintLen64)}
+	w.writeUvarint(SNAPSHOT_VERSION)
+	w.writeUvarint(config.NETWORK_SELECTED)
+	w.writeUvarint(chainData.Height)
+	w.writeVariableBytes(chainData.Hash)
+
+	write := func(key string, value []byte) {
+		w.writeVariableBytes([]byte(key))
+		w.writeVariableBytes(value)
+	}
+
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		iterable, ok := reader.(store_db_interface.StoreDBTransactionIterableInterface)
+		if !ok {
+			return errors.New("Chain store doesn't support snapshots")
+		}
+
+		var prefixes []string
+		if prefixes, err = snapshotPrefixes(reader); err != nil {
+			return
+		}
+
+		for _, prefix := range prefixes {
+			if err = iterable.IterateByPrefix(prefix, func(key string, value []byte) error {
+				if !strings.Contains(key, ":transitions:") {
+					write(key, value)
+				}
+				return w.err
+			}); err != nil {
+				return
+			}
+		}
+
+		for _, key := range snapshotChainKeys {
+			if data := reader.Get(key); data != nil {
+				write(key, data)
+			}
+		}
+
+		heightStr := strconv.FormatUint(chainData.Height, 10)
+		if data := reader.Get("blockchainInfo_" + heightStr); data != nil {
+			write("blockchainInfo_"+heightStr, data)
+		}
+
+		//difficulty window and the last headers
+		start := uint64(0)
+		if chainData.Height > config.DIFFICULTY_BLOCK_WINDOW+1 {
+			start = chainData.Height - config.DIFFICULTY_BLOCK_WINDOW - 1
+		}
+		for height := start; height <= chainData.Height; height++ {
+
+			heightStr = strconv.FormatUint(height, 10)
+			if data := reader.Get("totalDifficulty" + heightStr); data != nil {
+				write("totalDifficulty"+heightStr, data)
+			}
+
+			hash := reader.Get("blockHash_ByHeight" + heightStr)
+			if hash == nil {
+				continue
+			}
+
+			write("blockHash_ByHeight"+heightStr, hash)
+			write("blockKernelHash_ByHeight"+heightStr, reader.Get("blockKernelHash_ByHeight"+heightStr))
+			write("blockHeight_ByHash"+string(hash), []byte(heightStr))
+			write("block_ByHash"+string(hash), reader.Get("block_ByHash"+string(hash)))
+		}
+
+		return
+	}); err != nil {
+		return
+	}
+
+	w.writeVariableBytes(nil) //end of the entries
+	if w.err != nil {
+		return w.err
+	}
+	if _, err = w.w.Write(w.hasher.Sum(nil)); err != nil {
+		return
+	}
+	if err = w.w.Flush(); err != nil {
+		return
+	}
+
+	gui.Consensus().Info("Snapshot exported at height " + strconv.FormatUint(chainData.Height, 10))
+	return
+}
+
+// ImportSnapshot initializes an empty chain store from a snapshot. Blocks below the snapshot height are considered pruned
+// The checksum only detects a corrupted file. The state is trusted by recomputing the state root committed in the last block, which must have the trusted hash
+func (chain *Blockchain) ImportSnapshot(path string, trustedHash []byte) error {
+
+	if len(trustedHash) != cryptography.HashSize {
+		return errors.New("Snapshot trusted block hash is invalid")
+	}
+
+	if config.SEED_WALLET_NODES_INFO {
+		return errors.New("Snapshots don't contain the history required by --seed-wallet-nodes-info")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if stat.Size() < cryptography.HashSize {
+		return errors.New("Snapshot is invalid")
+	}
+	size := stat.Size() - cryptography.HashSize
+
+	//the checksum is verified in a first pass to not read a corrupted file
+	hasher := sha3.New256()
+	if _, err = io.CopyN(hasher, file, size); err != nil {
+		return err
+	}
+	checksum := make([]byte, cryptography.HashSize)
+	if _, err = io.ReadFull(file, checksum); err != nil {
+		return err
+	}
+	if !bytes.Equal(hasher.Sum(nil), checksum) {
+		return errors.New("Snapshot checksum is invalid")
+	}
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	r := bufio.NewReader(io.LimitReader(file, size))
+
+	readVariableBytes := func(limit uint64) ([]byte, error) {
+		length, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		if length > limit {
+			return nil, errors.New("Variable bytes exceeding maximum length")
+		}
+		data := make([]byte, length)
+		if _, err = io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	var version, network, height uint64
+	if version, err = binary.ReadUvarint(r); err != nil {
+		return err
+	}
+	if version != SNAPSHOT_VERSION {
+		return errors.New("Snapshot version is not supported")
+	}
+	if network, err = binary.ReadUvarint(r); err != nil {
+		return err
+	}
+	if network != config.NETWORK_SELECTED {
+		return errors.New("Snapshot is for a different network")
+	}
+	if height, err = binary.ReadUvarint(r); err != nil {
+		return err
+	}
+
+	var hash []byte
+	if hash, err = readVariableBytes(cryptography.HashSize); err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, trustedHash) {
+		return errors.New("Snapshot block hash is not the trusted hash")
+	}
+
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	if err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if writer.Exists("blockchainInfo") {
+			return errors.New("Snapshot can be imported only in an empty chain store")
+		}
+
+		var key, value []byte
+		for {
+			if key, err = readVariableBytes(config.BLOCK_MAX_SIZE); err != nil {
+				return
+			}
+			if len(key) == 0 { //end of the entries
+				break
+			}
+			if value, err = readVariableBytes(config.BLOCK_MAX_SIZE); err != nil {
+				return
+			}
+			//the state tree is computed again from the imported hashmaps
+			if !strings.HasPrefix(string(key), "stateTree:") {
+				writer.Put(string(key), value)
+			}
+		}
+		if _, readErr := r.ReadByte(); readErr != io.EOF {
+			return errors.New("Snapshot is invalid")
+		}
+
+		chainData := &BlockchainData{}
+		if err = msgpack.Unmarshal(writer.Get("blockchainInfo"), chainData); err != nil {
+			return
+		}
+		if chainData.Height != height || !bytes.Equal(chainData.Hash, hash) {
+			return errors.New("Snapshot chain data is not matching")
+		}
+
+		blk := block.CreateEmptyBlock()
+		if err = blk.Deserialize(advanced_buffers.NewBufferReader(writer.Get("block_ByHash" + string(hash)))); err != nil {
+			return
+		}
+		if err = blk.BloomNow(); err != nil {
+			return
+		}
+		if !bytes.Equal(blk.Bloom.Hash, hash) || blk.Height+1 != height {
+			return errors.New("Snapshot block is not matching")
+		}
+		if blk.Version != block.BLOCK_VERSION_STATE_HASH {
+			return errors.New("Snapshot block doesn't commit the state root")
+		}
+
+		if err = updateStateTree(writer); err != nil {
+			return
+		}
+		if !bytes.Equal(state_tree.GetRoot(writer), blk.StateHash) {
+			return errors.New("Snapshot state is not matching the state root of the block")
+		}
+		writer.Put("stateTreeVersion", []byte(STATE_TREE_VERSION))
+
+		writer.Put("blockchainPrunedHeight", []byte(strconv.FormatUint(height, 10)))
+
+		return
+	}); err != nil {
+		return err
+	}
+
+	gui.Consensus().Info("Snapshot imported at height " + strconv.FormatUint(height, 10))
+	return nil
+}
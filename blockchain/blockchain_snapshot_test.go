@@ -0,0 +1,169 @@
+package blockchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func initTestSnapshotStore(t *testing.T) {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(false)
+	assert.NoError(t, err)
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{Name: "blockchain", Opened: true, DB: db}
+}
+
+// createTestSnapshotChain stores a forged block committing a registration. The tamper callback can change the store before the export
+func createTestSnapshotChain(t *testing.T, publicKey []byte, tamper func(writer store_db_interface.StoreDBTransactionInterface)) *Blockchain {
+
+	initTestSnapshotStore(t)
+
+	chain := &Blockchain{
+		ChainData: &generics.Value[*BlockchainData]{},
+		mutex:     &sync.Mutex{},
+	}
+
+	assert.NoError(t, store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(writer)
+		_, err = dataStorage.CreateRegistration(publicKey, false, nil)
+		assert.NoError(t, err)
+
+		blkComplete := createTestBlockComplete(t, 1, make([]byte, cryptography.HashSize))
+		_, err = chain.saveBlockComplete(writer, blkComplete, 0, map[string][]byte{}, nil, dataStorage, true)
+		assert.NoError(t, err)
+
+		chainData := &BlockchainData{
+			Hash:           blkComplete.Block.Bloom.Hash,
+			PrevHash:       blkComplete.Block.PrevHash,
+			KernelHash:     blkComplete.Block.Bloom.KernelHash,
+			PrevKernelHash: blkComplete.Block.PrevKernelHash,
+			Height:         2,
+		}
+		chainData.saveBlockchainHeight(writer)
+		assert.NoError(t, chainData.saveBlockchain(writer))
+		chain.ChainData.Store(chainData)
+
+		if tamper != nil {
+			tamper(writer)
+		}
+
+		return
+	}))
+
+	return chain
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+
+	config.SEED_WALLET_NODES_INFO = false
+
+	publicKey := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+	chain := createTestSnapshotChain(t, publicKey, nil)
+
+	var stateRoot []byte
+	assert.NoError(t, store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		stateRoot = state_tree.GetRoot(reader)
+		return nil
+	}))
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+	assert.NoError(t, chain.ExportSnapshot(path))
+
+	hash := chain.GetChainData().Hash
+
+	initTestSnapshotStore(t)
+	assert.EqualError(t, chain.ImportSnapshot(path, cryptography.RandomHash()), "Snapshot block hash is not the trusted hash")
+	assert.NoError(t, chain.ImportSnapshot(path, hash))
+	assert.EqualError(t, chain.ImportSnapshot(path, hash), "Snapshot can be imported only in an empty chain store")
+
+	assert.NoError(t, store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.Equal(t, stateRoot, state_tree.GetRoot(reader))
+		assert.Equal(t, hash, reader.Get("chainHash"))
+		assert.Equal(t, []byte("2"), reader.Get("blockchainPrunedHeight"))
+
+		reg, err := data_storage.NewDataStorage(reader).Regs.Get(string(publicKey))
+		assert.NoError(t, err)
+		assert.NotNil(t, reg)
+		return nil
+	}))
+}
+
+func TestSnapshotCorrupted(t *testing.T) {
+
+	config.SEED_WALLET_NODES_INFO = false
+
+	chain := createTestSnapshotChain(t, addresses.GenerateNewPrivateKey().GeneratePublicKey(), nil)
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+	assert.NoError(t, chain.ExportSnapshot(path))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	data[len(data)/2] ^= 0xFF
+	assert.NoError(t, os.WriteFile(path, data, 0644))
+
+	initTestSnapshotStore(t)
+	assert.EqualError(t, chain.ImportSnapshot(path, chain.GetChainData().Hash), "Snapshot checksum is invalid")
+}
+
+func TestSnapshotTrailingData(t *testing.T) {
+
+	config.SEED_WALLET_NODES_INFO = false
+
+	chain := createTestSnapshotChain(t, addresses.GenerateNewPrivateKey().GeneratePublicKey(), nil)
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+	assert.NoError(t, chain.ExportSnapshot(path))
+
+	//data after the end of the entries with a valid checksum
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	data = append(data[:len(data)-cryptography.HashSize], 1)
+	data = append(data, cryptography.SHA3(data)...)
+	assert.NoError(t, os.WriteFile(path, data, 0644))
+
+	initTestSnapshotStore(t)
+	assert.EqualError(t, chain.ImportSnapshot(path, chain.GetChainData().Hash), "Snapshot is invalid")
+}
+
+func TestSnapshotTampered(t *testing.T) {
+
+	config.SEED_WALLET_NODES_INFO = false
+
+	publicKey, otherPublicKey := addresses.GenerateNewPrivateKey().GeneratePublicKey(), addresses.GenerateNewPrivateKey().GeneratePublicKey()
+
+	//a registration missing from the state tree of the block is exported with a valid checksum
+	chain := createTestSnapshotChain(t, publicKey, func(writer store_db_interface.StoreDBTransactionInterface) {
+		writer.Put("registrations:map:"+string(otherPublicKey), writer.Get("registrations:map:"+string(publicKey)))
+		writer.Put("registrations:exists:"+string(otherPublicKey), []byte{1})
+	})
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+	assert.NoError(t, chain.ExportSnapshot(path))
+
+	initTestSnapshotStore(t)
+	assert.EqualError(t, chain.ImportSnapshot(path, chain.GetChainData().Hash), "Snapshot state is not matching the state root of the block")
+
+	assert.NoError(t, store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.False(t, reader.Exists("blockchainInfo"), "nothing is imported")
+		return nil
+	}))
+}
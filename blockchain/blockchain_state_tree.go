@@ -0,0 +1,136 @@
+package blockchain
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/gui"
+	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strings"
+)
+
+const STATE_TREE_VERSION = "1" //version 1 commits the assets fee liquidity max heaps
+
+// hashmaps committed into the state tree. The length of the name is required because the keys can contain ':'
+var stateTreeHashMaps = []struct {
+	prefix  string
+	nameLen func(key string) int
+}{
+	{"registrations:", func(key string) int { return len("registrations") }},
+	{"plainAccs:", func(key string) int { return len("plainAccs") }},
+	{"assets:", func(key string) int { return len("assets") }},
+	{"accounts_", func(key string) int { return len("accounts_") + config_coins.ASSET_LENGTH }},
+	{"pendingStakes:", func(key string) int { return len("pendingStakes") }},
+	{"conditionalPayments_", func(key string) int { return strings.Index(key, ":") }},
+}
+
+// updateStateTree commits all the stored elements of the hashmaps into the state tree
+func updateStateTree(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+	iterable, ok := writer.(store_db_interface.StoreDBTransactionIterableInterface)
+	if !ok {
+		return errors.New("Chain store doesn't support the state tree")
+	}
+
+	type element struct {
+		name       string
+		key        string
+		serialized []byte
+	}
+
+	var elements []*element
+	for _, it := range stateTreeHashMaps {
+		hashMap := it
+		if err = iterable.IterateByPrefix(hashMap.prefix, func(key string, value []byte) error {
+			nameLen := hashMap.nameLen(key)
+			if nameLen < 0 || len(key) < nameLen || !strings.HasPrefix(key[nameLen:], ":map:") {
+				return nil
+			}
+			elements = append(elements, &element{key[:nameLen], key[nameLen+len(":map:"):], value})
+			return nil
+		}); err != nil {
+			return
+		}
+	}
+
+	//assets fee liquidity max heaps are named by the asset
+	dataStorage := data_storage.NewDataStorage(writer)
+	for i := uint64(0); i < dataStorage.Asts.Count; i++ {
+		var assetId []byte
+		if assetId, err = dataStorage.Asts.GetKeyByIndex(i); err != nil {
+			return
+		}
+		for _, name := range []string{string(assetId), string(assetId) + "_dict"} {
+			prefix := name + ":map:"
+			if err = iterable.IterateByPrefix(prefix, func(key string, value []byte) error {
+				elements = append(elements, &element{name, key[len(prefix):], value})
+				return nil
+			}); err != nil {
+				return
+			}
+		}
+	}
+
+	//the tree is updated only after the iteration as the store can't be changed during it
+	for _, it := range elements {
+		state_tree.Update(writer, it.name, []byte(it.key), it.serialized)
+	}
+
+	return
+}
+
+// clearStateTree removes all the nodes of the state tree
+func clearStateTree(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+	iterable, ok := writer.(store_db_interface.StoreDBTransactionIterableInterface)
+	if !ok {
+		return errors.New("Chain store doesn't support the state tree")
+	}
+
+	var keys []string
+	if err = iterable.IterateByPrefix("stateTree:", func(key string, value []byte) error {
+		keys = append(keys, key)
+		return nil
+	}); err != nil {
+		return
+	}
+
+	for _, key := range keys {
+		writer.Delete(key)
+	}
+	return
+}
+
+// rebuildStateTree computes the state tree of the chains stored before the state tree was introduced or by an older STATE_TREE_VERSION
+func (chain *Blockchain) rebuildStateTree() error {
+
+	return store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if string(writer.Get("stateTreeVersion")) == STATE_TREE_VERSION {
+			return
+		}
+
+		//only the full nodes store the DataStorage
+		if config.CONSENSUS != config.CONSENSUS_TYPE_FULL {
+			writer.Put("stateTreeVersion", []byte(STATE_TREE_VERSION))
+			return
+		}
+
+		gui.Consensus().Info("Building the state tree")
+
+		if err = clearStateTree(writer); err != nil {
+			return
+		}
+		if err = updateStateTree(writer); err != nil {
+			return
+		}
+
+		writer.Put("stateTreeVersion", []byte(STATE_TREE_VERSION))
+
+		gui.Consensus().Info("State tree built")
+		return
+	})
+}
@@ -0,0 +1,67 @@
+package blockchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account/asset_fee_liquidity"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestUpdateStateTree(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+
+	publicKey := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+	assetId := cryptography.RandomHash()[:config_coins.ASSET_LENGTH]
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(writer)
+		_, err = dataStorage.CreateRegistration(publicKey, false, nil)
+		assert.NoError(t, err)
+
+		ast := asset.NewAsset(nil, 0)
+		ast.Name = "Test Asset"
+		ast.Ticker = "TEST"
+		ast.Description = "Test asset"
+		ast.MaxSupply = 1000
+		ast.UpdatePublicKey = addresses.GenerateNewPrivateKey().GeneratePublicKey()
+		ast.SupplyPublicKey = addresses.GenerateNewPrivateKey().GeneratePublicKey()
+		ast.SetKey(assetId)
+		assert.NoError(t, dataStorage.Asts.CreateAsset(assetId, ast))
+
+		assert.NoError(t, dataStorage.AstsFeeLiquidityCollection.UpdateLiquidity(publicKey, 10, 0, assetId, asset_fee_liquidity.UPDATE_LIQUIDITY_INSERTED))
+		assert.NoError(t, dataStorage.CommitChanges())
+
+		root := state_tree.GetRoot(writer)
+
+		//the assets fee liquidity max heaps are committed
+		for _, it := range []struct {
+			name string
+			key  []byte
+		}{
+			{string(assetId), []byte("0")},
+			{string(assetId) + "_dict", publicKey},
+		} {
+			serialized := writer.Get(it.name + ":map:" + string(it.key))
+			assert.NotNil(t, serialized, it.name)
+			assert.True(t, state_tree.GetProof(writer, it.name, it.key).Verify(root, it.name, it.key, serialized), it.name)
+		}
+
+		//the state tree computed from the stored hashmaps is the same
+		assert.NoError(t, clearStateTree(writer))
+		assert.NotEqual(t, root, state_tree.GetRoot(writer))
+		assert.NoError(t, updateStateTree(writer))
+		assert.Equal(t, root, state_tree.GetRoot(writer))
+
+		return
+	}))
+}
@@ -1,15 +1,19 @@
 package blockchain
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"github.com/vmihailenco/msgpack/v5"
 	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/blocks/block"
 	"pandora-pay/blockchain/blocks/block_complete"
 	"pandora-pay/blockchain/data_storage"
 	"pandora-pay/config"
 	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
 	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
 )
@@ -58,6 +62,67 @@ func (chain *Blockchain) LoadBlockHash(reader store_db_interface.StoreDBTransact
 	return hash, nil
 }
 
+func (chain *Blockchain) GetPrunedHeight() uint64 {
+	return chain.prunedHeight.Load()
+}
+
+func (chain *Blockchain) loadPrunedHeight(reader store_db_interface.StoreDBTransactionInterface) (uint64, error) {
+	data := reader.Get("blockchainPrunedHeight")
+	if data == nil {
+		return 0, nil
+	}
+	return strconv.ParseUint(string(data), 10, 64)
+}
+
+// pruneBlocksComplete deletes the block bodies, the raw txs and the transitional changes of the blocks outside the prune window.
+// Headers, kernel hashes and the current DataStorage hashmaps are kept
+func (chain *Blockchain) pruneBlocksComplete(writer store_db_interface.StoreDBTransactionInterface, chainHeight uint64, dataStorage *data_storage.DataStorage) (prunedHeight uint64, err error) {
+
+	if prunedHeight, err = chain.loadPrunedHeight(writer); err != nil {
+		return
+	}
+
+	if chainHeight <= config.PRUNE_BLOCKS {
+		return
+	}
+
+	end := generics.Min(chainHeight-config.PRUNE_BLOCKS, prunedHeight+config.PRUNE_MAX_BLOCKS_BATCH)
+	if prunedHeight >= end {
+		return
+	}
+
+	for ; prunedHeight < end; prunedHeight++ {
+
+		blockHeightStr := strconv.FormatUint(prunedHeight, 10)
+
+		data := writer.Get("blockTxs" + blockHeightStr)
+		if data == nil {
+			return prunedHeight, errors.New("blockTxs was not found")
+		}
+
+		txHashes := [][]byte{}
+		if err = msgpack.Unmarshal(data, &txHashes); err != nil {
+			return
+		}
+
+		for _, txHash := range txHashes {
+			writer.Delete("tx:" + string(txHash))
+			writer.Delete("txInfo_ByHash" + string(txHash))
+			writer.Delete("txPreview_ByHash" + string(txHash))
+		}
+
+		writer.Delete("blockTxs" + blockHeightStr)
+
+		if err = dataStorage.DeleteTransitionalChangesFromStore(blockHeightStr); err != nil {
+			return
+		}
+	}
+
+	writer.Put("blockchainPrunedHeight", []byte(strconv.FormatUint(prunedHeight, 10)))
+
+	return
+}
+
 func (chain *Blockchain) deleteUnusedBlocksComplete(writer store_db_interface.StoreDBTransactionInterface, blockHeight uint64, dataStorage *data_storage.DataStorage) error {
 
 	blockHeightStr := strconv.FormatUint(blockHeight, 10)
@@ -96,6 +161,10 @@ func (chain *Blockchain) removeBlockComplete(writer store_db_interface.StoreDBTr
 	writer.Delete("blockKernelHash_ByHeight" + string(blockHeightStr))
 
 	data := writer.Get("blockTxs" + blockHeightStr)
+	if data == nil {
+		return allTransactionsChanges, errors.New("Block was pruned")
+	}
+
 	txHashes := [][]byte{} //32 byte
 
 	if err := msgpack.Unmarshal(data, &txHashes); err != nil {
@@ -106,9 +175,10 @@ func (chain *Blockchain) removeBlockComplete(writer store_db_interface.StoreDBTr
 	for i, txHash := range txHashes {
 
 		txChange := &blockchain_types.BlockchainTransactionUpdate{
-			TxHash:    txHash,
-			TxHashStr: string(txHash),
-			Inserted:  false,
+			TxHash:      txHash,
+			TxHashStr:   string(txHash),
+			Inserted:    false,
+			BlockHeight: blockHeight, //required to notify the conditional payments of the removed txs
 		}
 
 		allTransactionsChangesFinal = append(allTransactionsChangesFinal, txChange)
@@ -126,7 +196,7 @@ func (chain *Blockchain) removeBlockComplete(writer store_db_interface.StoreDBTr
 	return allTransactionsChangesFinal, nil
 }
 
-func (chain *Blockchain) saveBlockComplete(writer store_db_interface.StoreDBTransactionInterface, blkComplete *block_complete.BlockComplete, transactionsCount uint64, removedTxHashes map[string][]byte, allTransactionsChanges []*blockchain_types.BlockchainTransactionUpdate, dataStorage *data_storage.DataStorage) ([]*blockchain_types.BlockchainTransactionUpdate, error) {
+func (chain *Blockchain) saveBlockComplete(writer store_db_interface.StoreDBTransactionInterface, blkComplete *block_complete.BlockComplete, transactionsCount uint64, removedTxHashes map[string][]byte, allTransactionsChanges []*blockchain_types.BlockchainTransactionUpdate, dataStorage *data_storage.DataStorage, calledByForging bool) ([]*blockchain_types.BlockchainTransactionUpdate, error) {
 
 	allTransactionsChanges2 := allTransactionsChanges
 
@@ -139,6 +209,21 @@ func (chain *Blockchain) saveBlockComplete(writer store_db_interface.StoreDBTran
 		return allTransactionsChanges, err
 	}
 
+	if blkComplete.Block.Version == block.BLOCK_VERSION_STATE_HASH {
+		stateHash := state_tree.GetRoot(writer)
+		if calledByForging {
+			//the forged block gets the state root and its hash changes
+			blkComplete.Block.StateHash = stateHash
+			blkComplete.Block.Bloom = nil
+			blkComplete.BloomBlkComplete = nil
+			if err := blkComplete.BloomAll(); err != nil {
+				return allTransactionsChanges, err
+			}
+		} else if !bytes.Equal(blkComplete.Block.StateHash, stateHash) {
+			return allTransactionsChanges, errors.New("State hash is not matching")
+		}
+	}
+
 	writer.Put("block_ByHash"+string(blkComplete.Block.Bloom.Hash), helpers.SerializeToBytes(blkComplete.Block))
 	writer.Put("blockHash_ByHeight"+blockHeightStr, blkComplete.Block.Bloom.Hash)
 	writer.Put("blockKernelHash_ByHeight"+blockHeightStr, blkComplete.Block.Bloom.KernelHash)
@@ -229,6 +314,12 @@ func (chain *Blockchain) loadBlockchain() error {
 		}
 		chain.ChainData.Store(chainData)
 
+		var prunedHeight uint64
+		if prunedHeight, err = chain.loadPrunedHeight(reader); err != nil {
+			return
+		}
+		chain.prunedHeight.Store(prunedHeight)
+
 		return
 	})
 
@@ -0,0 +1,275 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"math/big"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/accounts/account"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/mempool"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func createTestBlockComplete(t *testing.T, height uint64, stateHash []byte) *block_complete.BlockComplete {
+
+	blkComplete := &block_complete.BlockComplete{
+		Block: &block.Block{
+			BlockHeader:    &block.BlockHeader{Version: block.BLOCK_VERSION_STATE_HASH, Height: height},
+			MerkleHash:     cryptography.SHA3([]byte{}),
+			PrevHash:       cryptography.RandomHash(),
+			PrevKernelHash: cryptography.RandomHash(),
+			StakingAmount:  1,
+			StakingNonce:   cryptography.RandomHash(),
+			StateHash:      stateHash,
+		},
+		Txs: []*transaction.Transaction{},
+	}
+	assert.NoError(t, blkComplete.BloomAll())
+	return blkComplete
+}
+
+func TestSaveBlockCompleteStateHash(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+
+	chain := &Blockchain{}
+
+	var stateHash []byte
+
+	//the forger assigns the state root
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(writer)
+		_, err = dataStorage.CreateRegistration(addresses.GenerateNewPrivateKey().GeneratePublicKey(), false, nil)
+		assert.NoError(t, err)
+
+		blkComplete := createTestBlockComplete(t, 1, make([]byte, cryptography.HashSize))
+		_, err = chain.saveBlockComplete(writer, blkComplete, 0, map[string][]byte{}, nil, dataStorage, true)
+		assert.NoError(t, err)
+
+		stateHash = state_tree.GetRoot(writer)
+		assert.Equal(t, stateHash, blkComplete.Block.StateHash)
+
+		return
+	}))
+
+	publicKey := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+
+	include := func(blkComplete *block_complete.BlockComplete) error {
+		return db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+			dataStorage := data_storage.NewDataStorage(writer)
+			if _, err = dataStorage.CreateRegistration(publicKey, false, nil); err != nil {
+				return
+			}
+			_, err = chain.saveBlockComplete(writer, blkComplete, 0, map[string][]byte{}, nil, dataStorage, false)
+			return
+		})
+	}
+
+	assert.EqualError(t, include(createTestBlockComplete(t, 2, stateHash)), "State hash is not matching", "the root of the previous block doesn't commit the new registration")
+	assert.EqualError(t, include(createTestBlockComplete(t, 2, cryptography.RandomHash())), "State hash is not matching")
+
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.Equal(t, stateHash, state_tree.GetRoot(reader), "the rejected blocks are not stored")
+		assert.Nil(t, reader.Get("blockHash_ByHeight2"))
+		return nil
+	}))
+
+}
+
+// createTestPrunableChain stores the blocks 0...count-1 with a tx in every block and returns the tx hashes
+func createTestPrunableChain(t *testing.T, count uint64) (*Blockchain, [][]byte) {
+
+	initTestSnapshotStore(t)
+
+	chain := &Blockchain{
+		ChainData:    &generics.Value[*BlockchainData]{},
+		prunedHeight: &generics.Value[uint64]{},
+		mutex:        &sync.Mutex{},
+	}
+
+	txHashes := make([][]byte, count)
+
+	assert.NoError(t, store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		for height := uint64(0); height < count; height++ {
+
+			dataStorage := data_storage.NewDataStorage(writer)
+			_, err = dataStorage.CreateRegistration(addresses.GenerateNewPrivateKey().GeneratePublicKey(), false, nil)
+			assert.NoError(t, err)
+
+			_, err = chain.saveBlockComplete(writer, createTestBlockComplete(t, height, nil), 0, map[string][]byte{}, nil, dataStorage, true)
+			assert.NoError(t, err)
+
+			txHashes[height] = cryptography.RandomHash()
+			txHashStr := string(txHashes[height])
+
+			var data []byte
+			if data, err = msgpack.Marshal([][]byte{txHashes[height]}); err != nil {
+				return
+			}
+			writer.Put("blockTxs"+strconv.FormatUint(height, 10), data)
+			writer.Put("tx:"+txHashStr, []byte{1})
+			writer.Put("txInfo_ByHash"+txHashStr, []byte{1})
+			writer.Put("txPreview_ByHash"+txHashStr, []byte{1})
+			writer.Put("txHash:"+txHashStr, []byte{1})
+			writer.Put("txBlock:"+txHashStr, binary.AppendUvarint(nil, height))
+		}
+
+		chain.ChainData.Store(&BlockchainData{Height: count, Target: new(big.Int), BigTotalDifficulty: new(big.Int)})
+		return
+	}))
+
+	return chain, txHashes
+}
+
+func TestPruneBlocksComplete(t *testing.T) {
+
+	config.SEED_WALLET_NODES_INFO = false
+
+	defer func() { config.PRUNE_BLOCKS = 0 }()
+	config.PRUNE_BLOCKS = 5
+
+	chain, txHashes := createTestPrunableChain(t, 10)
+
+	for _, expected := range []uint64{5, 5} {
+		assert.NoError(t, store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+			prunedHeight, err := chain.pruneBlocksComplete(writer, 10, data_storage.NewDataStorage(writer))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, prunedHeight, "the blocks are pruned up to the prune window")
+			return
+		}))
+	}
+
+	assert.NoError(t, store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+
+		assert.Equal(t, []byte("5"), reader.Get("blockchainPrunedHeight"))
+
+		for height, txHash := range txHashes {
+
+			txHashStr := string(txHash)
+			pruned := height < 5
+
+			assert.Equal(t, !pruned, reader.Exists("tx:"+txHashStr))
+			assert.Equal(t, !pruned, reader.Exists("txInfo_ByHash"+txHashStr))
+			assert.Equal(t, !pruned, reader.Exists("txPreview_ByHash"+txHashStr))
+			assert.Equal(t, !pruned, reader.Exists("blockTxs"+strconv.Itoa(height)))
+			assert.Equal(t, !pruned, reader.Exists("dataStorage:transitionsCollectionsKeys:"+strconv.Itoa(height)))
+
+			assert.True(t, reader.Exists("txHash:"+txHashStr), "the pruned txs are still known")
+			assert.True(t, reader.Exists("txBlock:"+txHashStr))
+			assert.True(t, reader.Exists("blockHash_ByHeight"+strconv.Itoa(height)), "the headers are kept")
+		}
+		return nil
+	}))
+}
+
+func TestAddBlocksReorgPruned(t *testing.T) {
+
+	config.SEED_WALLET_NODES_INFO = false
+
+	chain, _ := createTestPrunableChain(t, 10)
+	chain.prunedHeight.Store(5)
+	chain.mempool = &mempool.Mempool{
+		SuspendProcessingCn:  make(chan struct{}, 1),
+		ContinueProcessingCn: make(chan mempool.ContinueProcessingType, 1),
+	}
+	chain.updatesQueue = &BlockchainUpdatesQueue{updatesCn: make(chan *BlockchainUpdate, 1)}
+
+	_, err := chain.AddBlocks([]*block_complete.BlockComplete{createTestBlockComplete(t, 4, nil)}, false, advanced_connection_types.UUID_ALL)
+	assert.EqualError(t, err, "Reorg is not possible because the blocks were pruned")
+	assert.Equal(t, mempool.CONTINUE_PROCESSING_ERROR, <-chain.mempool.ContinueProcessingCn)
+
+	assert.NoError(t, store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.True(t, reader.Exists("blockHash_ByHeight9"), "no block was removed")
+		return nil
+	}))
+}
+
+func TestRemoveBlockCompleteConditionalPaymentExpired(t *testing.T) {
+
+	config.SEED_WALLET_NODES_INFO = false
+
+	initTestSnapshotStore(t)
+	chain := &Blockchain{}
+
+	receiverPrivateKey := addresses.GenerateNewPrivateKey()
+	sender, receiver := addresses.GenerateNewPrivateKey().GeneratePublicKey(), receiverPrivateKey.GeneratePublicKey()
+	txId := cryptography.RandomHash()
+	amount := crypto.CommitElGamal(receiverPrivateKey.GeneratePublicKeyPoint(), big.NewInt(10))
+
+	saveBlock := func(height uint64, process func(dataStorage *data_storage.DataStorage)) {
+		assert.NoError(t, store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+			dataStorage := data_storage.NewDataStorage(writer)
+			process(dataStorage)
+			_, err = chain.saveBlockComplete(writer, createTestBlockComplete(t, height, nil), 0, map[string][]byte{}, nil, dataStorage, true)
+			return
+		}))
+	}
+
+	//the payment is created in the block 99 and expires in the block 100
+	saveBlock(99, func(dataStorage *data_storage.DataStorage) {
+		for _, publicKey := range [][]byte{sender, receiver} {
+			_, err := dataStorage.Regs.CreateNewRegistration(publicKey, false, nil)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, dataStorage.AddConditionalPayment(100, txId, 0, config_coins.NATIVE_ASSET_FULL, true, true, [][]byte{sender, receiver}, []*crypto.ElGamal{crypto.CommitElGamal(receiverPrivateKey.GeneratePublicKeyPoint(), big.NewInt(0)), amount}, 1, [][]byte{addresses.GenerateNewPrivateKey().GeneratePublicKey()}))
+	})
+	saveBlock(100, func(dataStorage *data_storage.DataStorage) {
+		assert.NoError(t, dataStorage.ProcessConditionalPayments(100))
+	})
+
+	getState := func() (condPayment *conditional_payment.ConditionalPayment, acc *account.Account) {
+		assert.NoError(t, store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+			dataStorage := data_storage.NewDataStorage(reader)
+			condPayment, err = dataStorage.GetConditionalPayment(txId, 0)
+			assert.NoError(t, err)
+
+			accs, err := dataStorage.AccsCollection.GetMap(config_coins.NATIVE_ASSET_FULL)
+			assert.NoError(t, err)
+			acc, err = accs.Get(string(receiver))
+			assert.NoError(t, err)
+			return
+		}))
+		return
+	}
+
+	condPayment, acc := getState()
+	assert.Nil(t, condPayment, "the expired payment was settled")
+	assert.NotNil(t, acc, "the default resolution paid the receiver")
+
+	//a reorg removes the block which settled the payment
+	assert.NoError(t, store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		dataStorage := data_storage.NewDataStorage(writer)
+		if _, err = chain.removeBlockComplete(writer, 100, map[string][]byte{}, nil, dataStorage); err != nil {
+			return
+		}
+		return dataStorage.CommitChanges()
+	}))
+
+	condPayment, acc = getState()
+	assert.NotNil(t, condPayment, "the payment is pending again")
+	assert.False(t, condPayment.Processed)
+	assert.Equal(t, uint64(100), condPayment.BlockHeight)
+	assert.Equal(t, [][]byte{receiver}, condPayment.ReceiverPublicKeys, "only the receivers of the parity are stored")
+	assert.Nil(t, acc, "the payout is undone")
+}
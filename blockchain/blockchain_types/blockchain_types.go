@@ -32,6 +32,7 @@ type MempoolTransactionUpdate struct {
 	Tx                               *transaction.Transaction
 	IncludedInBlockchainNotification bool
 	Keys                             map[string]bool
+	ReplacedBy                       []byte //hash of the transaction that replaced it
 }
 
 type BlockchainUpdates struct {
@@ -73,10 +73,10 @@ func (queue *BlockchainUpdatesQueue) lastSuccess(updates []*BlockchainUpdate) *B
 
 func (queue *BlockchainUpdatesQueue) executeUpdate(update *BlockchainUpdate) (err error) {
 
-	gui.GUI.Warning("-------------------------------------------")
-	gui.GUI.Warning(fmt.Sprintf("Included blocks %v - %d | TXs: %d | Hash %s", update.calledByForging, len(update.insertedBlocks), len(update.insertedTxs), base64.StdEncoding.EncodeToString(update.newChainData.Hash)))
-	gui.GUI.Warning(update.newChainData.Height, base64.StdEncoding.EncodeToString(update.newChainData.Hash), update.newChainData.Target.Text(10), update.newChainData.BigTotalDifficulty.Text(10))
-	gui.GUI.Warning("-------------------------------------------")
+	gui.Consensus().Warning("-------------------------------------------")
+	gui.Consensus().Warning(fmt.Sprintf("Included blocks %v - %d | TXs: %d | Hash %s", update.calledByForging, len(update.insertedBlocks), len(update.insertedTxs), base64.StdEncoding.EncodeToString(update.newChainData.Hash)))
+	gui.Consensus().Warning(update.newChainData.Height, base64.StdEncoding.EncodeToString(update.newChainData.Hash), update.newChainData.Target.Text(10), update.newChainData.BigTotalDifficulty.Text(10))
+	gui.Consensus().Warning("-------------------------------------------")
 	update.newChainData.updateChainInfo()
 
 	queue.chain.UpdateNewChainUpdate.Broadcast(&blockchain_types.BlockchainUpdates{
@@ -93,7 +93,7 @@ func (queue *BlockchainUpdatesQueue) executeUpdate(update *BlockchainUpdate) (er
 	queue.updatesMempool.Broadcast(update)
 	queue.updatesNotifications.Broadcast(update)
 
-	gui.GUI.Log("queue.chain.UpdateNewChain fired")
+	gui.Consensus().Log("queue.chain.UpdateNewChain fired")
 	queue.chain.UpdateNewChain.Broadcast(update.newChainData.Height)
 
 	queue.chain.UpdateNewChainDataUpdate.Broadcast(&BlockchainDataUpdate{
@@ -128,7 +128,7 @@ func (queue *BlockchainUpdatesQueue) processBlockchainUpdatesQueue() {
 			for _, update = range works {
 				if update.err == nil {
 					if err := queue.executeUpdate(update); err != nil {
-						gui.GUI.Error("Error processUpdate", err)
+						gui.Consensus().Error("Error processUpdate", err)
 					}
 				}
 			}
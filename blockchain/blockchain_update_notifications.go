@@ -35,6 +35,10 @@ func (queue *BlockchainUpdatesQueue) processBlockchainUpdateMempool() {
 
 			update := <-updatesMempoolCn
 
+			for _, blkComplete := range update.insertedBlocks {
+				queue.chain.mempool.FeeEstimator.AddBlock(blkComplete.Height, blkComplete.Txs)
+			}
+
 			//let's remove the transactions from the mempool
 			if len(update.insertedTxsList) > 0 {
 				hashes := make([]string, len(update.insertedTxsList))
@@ -8,9 +8,10 @@ import (
 
 type Block struct {
 	*BlockHeader
-	MerkleHash     []byte      `json:"merkleHash" msgpack:"merkleHash"`          //32 byte
-	PrevHash       []byte      `json:"prevHash"  msgpack:"prevHash"`             //32 byte
-	PrevKernelHash []byte      `json:"prevKernelHash"  msgpack:"prevKernelHash"` //32 byte
+	MerkleHash     []byte      `json:"merkleHash" msgpack:"merkleHash"`                   //32 byte
+	StateHash      []byte      `json:"stateHash,omitempty" msgpack:"stateHash,omitempty"` //32 byte, only for BLOCK_VERSION_STATE_HASH
+	PrevHash       []byte      `json:"prevHash"  msgpack:"prevHash"`                      //32 byte
+	PrevKernelHash []byte      `json:"prevKernelHash"  msgpack:"prevKernelHash"`          //32 byte
 	Timestamp      uint64      `json:"timestamp" msgpack:"timestamp"`
 	StakingAmount  uint64      `json:"stakingAmount" msgpack:"stakingAmount"`
 	StakingNonce   []byte      `json:"stakingNonce" msgpack:"stakingNonce"` // 33 byte public key can also be found into the accounts tree
@@ -57,6 +58,9 @@ func (blk *Block) AdvancedSerialization(w *advanced_buffers.BufferWriter, kernel
 
 	if !kernelHash {
 		w.Write(blk.MerkleHash)
+		if blk.Version == BLOCK_VERSION_STATE_HASH {
+			w.Write(blk.StateHash)
+		}
 		w.Write(blk.PrevHash)
 	}
 
@@ -96,6 +100,11 @@ func (blk *Block) Deserialize(r *advanced_buffers.BufferReader) (err error) {
 	if blk.MerkleHash, err = r.ReadHash(); err != nil {
 		return
 	}
+	if blk.Version == BLOCK_VERSION_STATE_HASH {
+		if blk.StateHash, err = r.ReadHash(); err != nil {
+			return
+		}
+	}
 	if blk.PrevHash, err = r.ReadHash(); err != nil {
 		return
 	}
@@ -2,16 +2,30 @@ package block
 
 import (
 	"errors"
+	"pandora-pay/config"
 	"pandora-pay/helpers/advanced_buffers"
 )
 
+const (
+	BLOCK_VERSION            = uint64(0)
+	BLOCK_VERSION_STATE_HASH = uint64(1) //the block commits the state root and its merkle hash uses domain separation
+)
+
+// GetBlockVersion returns the version required for a block of the height
+func GetBlockVersion(height uint64) uint64 {
+	if height >= config.NETWORK_SELECTED_STATE_ROOT_HEIGHT {
+		return BLOCK_VERSION_STATE_HASH
+	}
+	return BLOCK_VERSION
+}
+
 type BlockHeader struct {
 	Version uint64 `json:"version" msgpack:"version"`
 	Height  uint64 `json:"height" msgpack:"height"`
 }
 
 func (blockHeader *BlockHeader) Validate() error {
-	if blockHeader.Version != 0 {
+	if blockHeader.Version != BLOCK_VERSION && blockHeader.Version != BLOCK_VERSION_STATE_HASH {
 		return errors.New("Invalid Block")
 	}
 	return nil
@@ -45,7 +45,7 @@ func (blkComplete *BlockComplete) MerkleHash() []byte {
 		for i, tx := range blkComplete.Txs {
 			hashes[i] = tx.Bloom.Hash
 		}
-		return merkle_tree.MerkleRoot(hashes)
+		return merkle_tree.MerkleRoot(hashes, blkComplete.Version == block.BLOCK_VERSION_STATE_HASH)
 	} else {
 		return cryptography.SHA3([]byte{})
 	}
@@ -74,6 +74,8 @@ func NewAccounts(tx store_db_interface.StoreDBTransactionInterface, AssetId []by
 		return account.NewAccountClear(key, index, accounts.Asset), nil
 	}
 
+	accounts.HashMap.StateTree = true
+
 	accounts.HashMap.StoredEvent = func(key []byte, committed *hash_map.CommittedMapElement[*account.Account], index uint64) (err error) {
 
 		if !tx.IsWritable() {
@@ -19,6 +19,11 @@ var regexAssetName = regexp.MustCompile("^([a-zA-Z0-9]+ )+[a-zA-Z0-9]+$|^[a-zA-Z
 var regexAssetTicker = regexp.MustCompile("^[A-Z0-9]+$") // only lowercase ascii is allowed. No space allowed
 var regexAssetDescription = regexp.MustCompile("[\\w|\\W]+")
 
+const (
+	ASSET_VERSION_SIMPLE uint64 = 0
+	ASSET_VERSION_STATUS uint64 = 1 //the Paused and Frozen status are serialized after the existing fields
+)
+
 type Asset struct {
 	PublicKeyHash            []byte `json:"-" msgpack:"-"` //hashmap key
 	Index                    uint64 `json:"-" msgpack:"-"` //hashMap index
@@ -33,6 +38,8 @@ type Asset struct {
 	DecimalSeparator         byte   `json:"decimalSeparator,omitempty" msgpack:"decimalSeparator,omitempty"`
 	MaxSupply                uint64 `json:"maxSupply,omitempty" msgpack:"maxSupply,omitempty"`
 	Supply                   uint64 `json:"supply,omitempty" msgpack:"supply,omitempty"`
+	Paused                   bool   `json:"paused,omitempty" msgpack:"paused,omitempty"`                   //transfers are suspended
+	Frozen                   bool   `json:"frozen,omitempty" msgpack:"frozen,omitempty"`                   //supply can not be changed anymore
 	UpdatePublicKey          []byte `json:"updatePublicKey,omitempty" msgpack:"updatePublicKey,omitempty"` //33 byte
 	SupplyPublicKey          []byte `json:"supplyPublicKey,omitempty" msgpack:"supplyPublicKey,omitempty"` //33 byte
 	Name                     string `json:"name" msgpack:"name"`
@@ -62,6 +69,16 @@ func (asset *Asset) GetIndex() uint64 {
 }
 
 func (asset *Asset) Validate() error {
+	switch asset.Version {
+	case ASSET_VERSION_SIMPLE:
+		if asset.Paused || asset.Frozen {
+			return errors.New("asset status requires a newer version")
+		}
+	case ASSET_VERSION_STATUS:
+	default:
+		return errors.New("asset version is invalid")
+	}
+
 	if asset.DecimalSeparator > config_assets.ASSETS_DECIMAL_SEPARATOR_MAX_BYTE {
 		return errors.New("asset decimal separator is invalid")
 	}
@@ -153,6 +170,10 @@ func (asset *Asset) AddSupply(sign bool, amount uint64) error {
 		return errors.New("BURN PUBLIC KEY")
 	}
 
+	if asset.Frozen {
+		return errors.New("Asset supply is frozen")
+	}
+
 	if sign {
 		if !asset.CanMint {
 			return errors.New("Can't mint")
@@ -195,6 +216,18 @@ func (asset *Asset) Serialize(w *advanced_buffers.BufferWriter) {
 	w.WriteString(asset.Ticker)
 	w.WriteString(asset.Description)
 	w.WriteVariableBytes(asset.Data)
+
+	if asset.Version >= ASSET_VERSION_STATUS {
+		w.WriteBool(asset.Paused)
+		w.WriteBool(asset.Frozen)
+	}
+}
+
+// SetStatusVersion upgrades the asset to the version which serializes the Paused and Frozen status
+func (asset *Asset) SetStatusVersion() {
+	if asset.Version < ASSET_VERSION_STATUS {
+		asset.Version = ASSET_VERSION_STATUS
+	}
 }
 
 func (asset *Asset) setIdentification() {
@@ -259,6 +292,19 @@ func (asset *Asset) Deserialize(r *advanced_buffers.BufferReader) (err error) {
 		return
 	}
 
+	switch asset.Version {
+	case ASSET_VERSION_SIMPLE:
+	case ASSET_VERSION_STATUS:
+		if asset.Paused, err = r.ReadBool(); err != nil {
+			return
+		}
+		if asset.Frozen, err = r.ReadBool(); err != nil {
+			return
+		}
+	default:
+		return errors.New("Invalid Asset Version")
+	}
+
 	asset.setIdentification()
 
 	return
@@ -0,0 +1,97 @@
+package asset
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"testing"
+)
+
+// serializeLegacy writes the layout used before the Paused and Frozen status existed
+func serializeLegacy(asset *Asset) []byte {
+	w := advanced_buffers.NewBufferWriter()
+	w.WriteUvarint(0)
+	w.WriteBool(asset.CanUpgrade)
+	w.WriteBool(asset.CanMint)
+	w.WriteBool(asset.CanBurn)
+	w.WriteBool(asset.CanChangeUpdatePublicKey)
+	w.WriteBool(asset.CanChangeSupplyPublicKey)
+	w.WriteBool(asset.CanPause)
+	w.WriteBool(asset.CanFreeze)
+	w.WriteByte(asset.DecimalSeparator)
+	w.WriteUvarint(asset.MaxSupply)
+	w.WriteUvarint(asset.Supply)
+	w.Write(asset.UpdatePublicKey)
+	w.Write(asset.SupplyPublicKey)
+	w.WriteString(asset.Name)
+	w.WriteString(asset.Ticker)
+	w.WriteString(asset.Description)
+	w.WriteVariableBytes(asset.Data)
+	return w.Bytes()
+}
+
+func newTestAsset() *Asset {
+	ast := NewAsset(helpers.RandomBytes(cryptography.PublicKeyHashSize), 0)
+	ast.CanMint = true
+	ast.CanPause = true
+	ast.CanFreeze = true
+	ast.DecimalSeparator = 5
+	ast.MaxSupply = 21000000
+	ast.Supply = 1000
+	ast.UpdatePublicKey = helpers.RandomBytes(cryptography.PublicKeySize)
+	ast.SupplyPublicKey = helpers.RandomBytes(cryptography.PublicKeySize)
+	ast.Name = "My Asset"
+	ast.Ticker = "AST"
+	ast.Description = "My simple Asset"
+	ast.Data = []byte{1, 2, 3}
+	ast.setIdentification()
+	return ast
+}
+
+func TestAsset_DeserializeLegacy(t *testing.T) {
+
+	ast := newTestAsset()
+	legacy := serializeLegacy(ast)
+
+	decoded := NewAsset(ast.PublicKeyHash, 0)
+	assert.NoError(t, decoded.Deserialize(advanced_buffers.NewBufferReader(legacy)))
+	assert.Equal(t, ASSET_VERSION_SIMPLE, decoded.Version)
+	assert.Equal(t, ast.Supply, decoded.Supply)
+	assert.Equal(t, ast.UpdatePublicKey, decoded.UpdatePublicKey)
+	assert.Equal(t, ast.Name, decoded.Name)
+	assert.Equal(t, ast.Data, decoded.Data)
+	assert.False(t, decoded.Paused)
+	assert.False(t, decoded.Frozen)
+	assert.NoError(t, decoded.Validate())
+
+	w := advanced_buffers.NewBufferWriter()
+	decoded.Serialize(w)
+	assert.Equal(t, legacy, w.Bytes(), "legacy assets must keep the same bytes and hash")
+}
+
+func TestAsset_SerializeStatus(t *testing.T) {
+
+	ast := newTestAsset()
+	ast.Paused = true
+	assert.Error(t, ast.Validate(), "the status requires the status version")
+
+	ast.SetStatusVersion()
+	assert.NoError(t, ast.Validate())
+
+	w := advanced_buffers.NewBufferWriter()
+	ast.Serialize(w)
+	assert.Equal(t, len(serializeLegacy(ast))+2, w.Length())
+
+	decoded := NewAsset(ast.PublicKeyHash, 0)
+	assert.NoError(t, decoded.Deserialize(advanced_buffers.NewBufferReader(w.Bytes())))
+	assert.Equal(t, ASSET_VERSION_STATUS, decoded.Version)
+	assert.True(t, decoded.Paused)
+	assert.False(t, decoded.Frozen)
+
+	ast.Version = 2
+	assert.Error(t, ast.Validate())
+	w = advanced_buffers.NewBufferWriter()
+	ast.Serialize(w)
+	assert.Error(t, decoded.Deserialize(advanced_buffers.NewBufferReader(w.Bytes())))
+}
@@ -35,6 +35,8 @@ func NewAssets(tx store_db_interface.StoreDBTransactionInterface) (this *Assets)
 		return asset.NewAsset(key, index), nil
 	}
 
+	this.HashMap.StateTree = true
+
 	this.HashMap.StoredEvent = func(key []byte, committed *hash_map.CommittedMapElement[*asset.Asset], index uint64) (err error) {
 		if !this.Tx.IsWritable() {
 			return
@@ -41,6 +41,8 @@ func (collection *AssetsFeeLiquidityCollection) GetMaxHeap(assetId []byte) (*min
 	}
 
 	maxheap := min_max_heap.NewMaxHeapStoreHashMap(collection.tx, string(assetId))
+	maxheap.HashMap.StateTree = true
+	maxheap.DictMap.StateTree = true
 	collection.listMaps = append(collection.listMaps, maxheap.HashMap, maxheap.DictMap)
 
 	collection.liquidityMaxHeaps[string(assetId)] = maxheap
@@ -6,6 +6,8 @@ import (
 	"pandora-pay/helpers/advanced_buffers"
 )
 
+const MULTISIG_PUBLIC_KEYS_MAX = 5 //maximum number of multisig public keys resolving a conditional payment
+
 type ConditionalPayment struct {
 	Key                []byte   `json:"-" msgpack:"-"` //hashmap key
 	BlockHeight        uint64   `json:"-" msgpack:"-"` //collection height
@@ -50,6 +52,9 @@ func (this *ConditionalPayment) Validate() error {
 	default:
 		return errors.New("Invalid Version")
 	}
+	if this.Processed { //processed payments are stored without the escrow data
+		return nil
+	}
 	for _, p := range this.ReceiverPublicKeys {
 		if len(p) != cryptography.PublicKeySize {
 			return errors.New("PendingStake PublicKey size is invalid")
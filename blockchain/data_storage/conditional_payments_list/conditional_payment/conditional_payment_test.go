@@ -0,0 +1,44 @@
+package conditional_payment
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"testing"
+)
+
+func TestConditionalPayment_ValidateProcessed(t *testing.T) {
+
+	condPayment := NewConditionalPayment(nil, 0, 100)
+	condPayment.TxId = cryptography.RandomHash()
+	condPayment.Asset = config_coins.NATIVE_ASSET_FULL
+	condPayment.ReceiverPublicKeys = [][]byte{helpers.RandomBytes(cryptography.PublicKeySize)}
+	condPayment.ReceiverAmounts = [][]byte{helpers.RandomBytes(66)}
+	condPayment.SenderPublicKeys = [][]byte{helpers.RandomBytes(cryptography.PublicKeySize)}
+	condPayment.SenderAmounts = [][]byte{helpers.RandomBytes(66)}
+	condPayment.MultisigThreshold = 1
+	condPayment.MultisigPublicKeys = [][]byte{helpers.RandomBytes(cryptography.PublicKeySize)}
+	assert.NoError(t, condPayment.Validate())
+
+	condPayment.Processed = true
+	w := advanced_buffers.NewBufferWriter()
+	condPayment.Serialize(w)
+
+	//processed payments are stored without the escrow data
+	decoded := NewConditionalPayment(nil, 0, 100)
+	assert.NoError(t, decoded.Deserialize(advanced_buffers.NewBufferReader(w.Bytes())))
+	assert.True(t, decoded.Processed)
+	assert.Equal(t, condPayment.TxId, decoded.TxId)
+	assert.Equal(t, byte(0), decoded.MultisigThreshold)
+	assert.Empty(t, decoded.MultisigPublicKeys)
+	assert.NoError(t, decoded.Validate(), "processed payments must be valid without the escrow data")
+
+	decoded.Processed = false
+	assert.Error(t, decoded.Validate(), "pending payments require the multisig threshold")
+
+	decoded.Version = 1
+	decoded.Processed = true
+	assert.Error(t, decoded.Validate())
+}
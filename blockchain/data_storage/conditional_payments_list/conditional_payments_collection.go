@@ -3,6 +3,7 @@ package conditional_payments_list
 import (
 	"pandora-pay/store/hash_map"
 	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
 )
 
 type ConditionalPaymentsCollection struct {
@@ -25,11 +26,13 @@ func (this *ConditionalPaymentsCollection) GetAllHashmaps() []hash_map.HashMapIn
 
 func (this *ConditionalPaymentsCollection) GetMap(blockHeight uint64) (*ConditionalPaymentsHashMap, error) {
 
-	it := this.maps[string(blockHeight)]
+	key := strconv.FormatUint(blockHeight, 10)
+
+	it := this.maps[key]
 	if it == nil {
 		it = NewConditionalPaymentsHashMap(this.tx, blockHeight)
 		this.list = append(this.list, it.HashMap)
-		this.maps[string(blockHeight)] = it
+		this.maps[key] = it
 	}
 
 	return it, nil
@@ -10,6 +10,7 @@ import (
 type ConditionalPaymentsHashMap struct {
 	*hash_map.HashMap[*conditional_payment.ConditionalPayment]
 	BlockHeight uint64
+	Expired     map[string]bool //payments settled with their default resolution by ProcessConditionalPayments
 }
 
 func NewConditionalPaymentsHashMap(tx store_db_interface.StoreDBTransactionInterface, blockHeight uint64) (this *ConditionalPaymentsHashMap) {
@@ -17,12 +18,15 @@ func NewConditionalPaymentsHashMap(tx store_db_interface.StoreDBTransactionInter
 	this = &ConditionalPaymentsHashMap{
 		hash_map.CreateNewHashMap[*conditional_payment.ConditionalPayment](tx, "conditionalPayments_"+strconv.FormatUint(blockHeight, 10), 0, true),
 		blockHeight,
+		make(map[string]bool),
 	}
 
 	this.HashMap.CreateObject = func(key []byte, index uint64) (*conditional_payment.ConditionalPayment, error) {
 		return conditional_payment.NewConditionalPayment(key, index, blockHeight), nil
 	}
 
+	this.HashMap.StateTree = true
+
 	this.HashMap.StoredEvent = func(key []byte, committed *hash_map.CommittedMapElement[*conditional_payment.ConditionalPayment], index uint64) (err error) {
 		if !this.Tx.IsWritable() {
 			return
@@ -273,6 +273,28 @@ func (dataStorage *DataStorage) AddConditionalPayment(blockHeight uint64, txId [
 	return conditionalPaymentsMap.Update(key, condPayment)
 }
 
+func (dataStorage *DataStorage) GetConditionalPayment(txId []byte, payloadIndex byte) (*conditional_payment.ConditionalPayment, error) {
+
+	key := string(txId) + "_" + strconv.Itoa(int(payloadIndex))
+
+	val := dataStorage.DBTx.Get("conditionalPayments:all:" + key)
+	if val == nil {
+		return nil, nil
+	}
+
+	blockHeight, err := strconv.ParseUint(string(val), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	conditionalPaymentsMap, err := dataStorage.ConditionalPaymentsCollection.GetMap(blockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return conditionalPaymentsMap.Get(key)
+}
+
 func (dataStorage *DataStorage) ProceedConditionalPayment(resolution bool, condPayment *conditional_payment.ConditionalPayment) (err error) {
 
 	if condPayment.Processed {
@@ -347,6 +369,7 @@ func (dataStorage *DataStorage) ProcessConditionalPayments(blockHeight uint64) e
 			if err = dataStorage.ProceedConditionalPayment(condPayment.DefaultResolution, condPayment); err != nil {
 				return err
 			}
+			conditionalPaymentsMap.Expired[deleteKeys[i]] = true
 		}
 
 	}
@@ -0,0 +1,130 @@
+package data_storage
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage/accounts/account/account_balance_homomorphic"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestProcessConditionalPayments(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	senderPrivateKey, receiverPrivateKey := addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey()
+	sender, receiver := senderPrivateKey.GeneratePublicKey(), receiverPrivateKey.GeneratePublicKey()
+	multisig := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+
+	expiringTxId, resolvedTxId := cryptography.RandomHash(), cryptography.RandomHash()
+	expiringAmount := crypto.CommitElGamal(receiverPrivateKey.GeneratePublicKeyPoint(), big.NewInt(10))
+	resolvedAmount := crypto.CommitElGamal(receiverPrivateKey.GeneratePublicKeyPoint(), big.NewInt(20))
+	senderAmount := crypto.CommitElGamal(senderPrivateKey.GeneratePublicKeyPoint(), big.NewInt(0))
+
+	assert.NoError(t, db.Update(func(tx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := NewDataStorage(tx)
+		for _, publicKey := range [][]byte{sender, receiver} {
+			_, err = dataStorage.Regs.CreateNewRegistration(publicKey, false, nil)
+			assert.NoError(t, err)
+		}
+
+		assert.NoError(t, dataStorage.AddConditionalPayment(100, expiringTxId, 0, config_coins.NATIVE_ASSET_FULL, true, true, [][]byte{sender, receiver}, []*crypto.ElGamal{senderAmount, expiringAmount}, 1, [][]byte{multisig}))
+		assert.NoError(t, dataStorage.AddConditionalPayment(100, resolvedTxId, 0, config_coins.NATIVE_ASSET_FULL, true, true, [][]byte{sender, receiver}, []*crypto.ElGamal{senderAmount, resolvedAmount}, 1, [][]byte{multisig}))
+
+		return dataStorage.CommitChanges()
+	}))
+
+	//the second payment is resolved by the multisig before expiring
+	assert.NoError(t, db.Update(func(tx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := NewDataStorage(tx)
+		condPayment, err := dataStorage.GetConditionalPayment(resolvedTxId, 0)
+		assert.NoError(t, err)
+		assert.NotNil(t, condPayment)
+
+		assert.NoError(t, dataStorage.ProceedConditionalPayment(true, condPayment))
+		assert.Error(t, dataStorage.ProceedConditionalPayment(true, condPayment), "a payment can be processed only once")
+
+		conditionalPaymentsMap, err := dataStorage.ConditionalPaymentsCollection.GetMap(100)
+		assert.NoError(t, err)
+		assert.NoError(t, conditionalPaymentsMap.Update(string(condPayment.Key), condPayment))
+
+		return dataStorage.CommitChanges()
+	}))
+
+	assert.NoError(t, db.Update(func(tx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := NewDataStorage(tx)
+
+		assert.NoError(t, dataStorage.ProcessConditionalPayments(99))
+		condPayment, err := dataStorage.GetConditionalPayment(expiringTxId, 0)
+		assert.NoError(t, err)
+		assert.False(t, condPayment.Processed, "payments expire only at their block height")
+
+		assert.NoError(t, dataStorage.ProcessConditionalPayments(100))
+
+		conditionalPaymentsMap, err := dataStorage.ConditionalPaymentsCollection.GetMap(100)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{string(expiringTxId) + "_0": true}, conditionalPaymentsMap.Expired, "only the payment settled with the default resolution expired")
+
+		assert.NoError(t, dataStorage.CommitChanges())
+
+		for _, txId := range [][]byte{expiringTxId, resolvedTxId} {
+			condPayment, err = dataStorage.GetConditionalPayment(txId, 0)
+			assert.NoError(t, err)
+			assert.Nil(t, condPayment, "expired payments are removed")
+		}
+
+		accs, err := dataStorage.AccsCollection.GetMap(config_coins.NATIVE_ASSET_FULL)
+		assert.NoError(t, err)
+
+		acc, err := accs.Get(string(receiver))
+		assert.NoError(t, err)
+
+		expected, err := account_balance_homomorphic.NewBalanceHomomorphicEmptyBalance(receiver)
+		assert.NoError(t, err)
+		expected.AddEchanges(resolvedAmount)
+		expected.AddEchanges(expiringAmount)
+		assert.Equal(t, expected.Amount.Serialize(), acc.Balance.Amount.Serialize(), "the resolved payment must not be paid twice")
+
+		acc, err = accs.Get(string(sender))
+		assert.NoError(t, err)
+		assert.Nil(t, acc, "the default resolution pays the receiver")
+
+		return
+	}))
+
+}
+
+func TestConditionalPaymentsCollectionGetMap(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.Update(func(tx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := NewDataStorage(tx)
+
+		//heights above the unicode range used to be converted to the same key
+		for _, height := range []uint64{100, 0x110000, 0x110001} {
+			conditionalPaymentsMap, err := dataStorage.ConditionalPaymentsCollection.GetMap(height)
+			assert.NoError(t, err)
+			assert.Equal(t, height, conditionalPaymentsMap.BlockHeight)
+
+			again, err := dataStorage.ConditionalPaymentsCollection.GetMap(height)
+			assert.NoError(t, err)
+			assert.Same(t, conditionalPaymentsMap, again)
+		}
+		assert.Equal(t, 3, len(dataStorage.ConditionalPaymentsCollection.GetAllMaps()))
+		assert.Equal(t, 3, len(dataStorage.ConditionalPaymentsCollection.GetAllHashmaps()))
+
+		return
+	}))
+}
@@ -37,5 +37,7 @@ func NewPendingStakesList(tx store_db_interface.StoreDBTransactionInterface) (th
 		return pending_stakes.NewPendingStakes(key, index), nil
 	}
 
+	this.HashMap.StateTree = true
+
 	return
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"pandora-pay/config/config_assets"
 	"pandora-pay/config/config_coins"
+	"pandora-pay/helpers"
 	"pandora-pay/helpers/advanced_buffers"
 )
 
@@ -29,6 +30,20 @@ func (self *AssetFeeLiquidity) Validate() error {
 	return nil
 }
 
+// ConvertNativeFee returns the minimum fee in the asset that pays at least the native fee. It is the inverse of fee * Rate / 10^LeadingZeros
+func (self *AssetFeeLiquidity) ConvertNativeFee(fee uint64) (uint64, error) {
+	if self.Rate == 0 {
+		return 0, errors.New("Asset Fee Liquidity rate is zero")
+	}
+	if err := helpers.SafeUint64Mul(&fee, helpers.Pow10(self.LeadingZeros)); err != nil {
+		return 0, err
+	}
+	if fee%self.Rate != 0 {
+		return fee/self.Rate + 1, nil
+	}
+	return fee / self.Rate, nil
+}
+
 func (self *AssetFeeLiquidity) Serialize(w *advanced_buffers.BufferWriter) {
 	w.Write(self.Asset)
 	w.WriteUvarint(self.Rate)
@@ -30,5 +30,7 @@ func NewPlainAccounts(tx store_db_interface.StoreDBTransactionInterface) (this *
 		return plain_account.NewPlainAccount(key, index), nil
 	}
 
+	this.HashMap.StateTree = true
+
 	return
 }
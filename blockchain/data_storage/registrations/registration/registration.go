@@ -6,12 +6,18 @@ import (
 	"pandora-pay/helpers/advanced_buffers"
 )
 
+const (
+	REGISTRATION_VERSION_SIMPLE   uint64 = 0
+	REGISTRATION_VERSION_COOLDOWN uint64 = 1 //the StakeCooldown is serialized after the existing fields
+)
+
 type Registration struct {
 	PublicKey      []byte `json:"-" msgpack:"-"` //hashMap key
 	Index          uint64 `json:"-" msgpack:"-"` //hashMap index
 	Version        uint64 `json:"version" msgpack:"version"`
 	Staked         bool   `json:"staked" msgpack:"staked"`
 	SpendPublicKey []byte `json:"spendPublicKey" msgpack:"spendPublicKey"`
+	StakeCooldown  uint64 `json:"stakeCooldown" msgpack:"stakeCooldown"` //block height after the Staked status can be changed again
 }
 
 func (registration *Registration) IsDeletable() bool {
@@ -31,7 +37,13 @@ func (registration *Registration) GetIndex() uint64 {
 }
 
 func (registration *Registration) Validate() error {
-	if registration.Version != 0 {
+	switch registration.Version {
+	case REGISTRATION_VERSION_SIMPLE:
+		if registration.StakeCooldown != 0 {
+			return errors.New("Registration StakeCooldown requires a newer version")
+		}
+	case REGISTRATION_VERSION_COOLDOWN:
+	default:
 		return errors.New("Registration Version is invalid")
 	}
 	if len(registration.SpendPublicKey) != cryptography.PublicKeySize && len(registration.SpendPublicKey) != 0 {
@@ -45,6 +57,17 @@ func (registration *Registration) Serialize(w *advanced_buffers.BufferWriter) {
 	w.WriteBool(registration.Staked)
 	w.WriteBool(len(registration.SpendPublicKey) > 0)
 	w.Write(registration.SpendPublicKey)
+	if registration.Version >= REGISTRATION_VERSION_COOLDOWN {
+		w.WriteUvarint(registration.StakeCooldown)
+	}
+}
+
+// SetStakeCooldown upgrades the registration to the version which serializes the StakeCooldown
+func (registration *Registration) SetStakeCooldown(value uint64) {
+	if registration.Version < REGISTRATION_VERSION_COOLDOWN {
+		registration.Version = REGISTRATION_VERSION_COOLDOWN
+	}
+	registration.StakeCooldown = value
 }
 
 func (registration *Registration) Deserialize(r *advanced_buffers.BufferReader) (err error) {
@@ -64,6 +87,16 @@ func (registration *Registration) Deserialize(r *advanced_buffers.BufferReader)
 			return
 		}
 	}
+
+	switch registration.Version {
+	case REGISTRATION_VERSION_SIMPLE:
+	case REGISTRATION_VERSION_COOLDOWN:
+		if registration.StakeCooldown, err = r.ReadUvarint(); err != nil {
+			return
+		}
+	default:
+		return errors.New("Registration Version is invalid")
+	}
 	return
 }
 
@@ -71,8 +104,9 @@ func NewRegistration(publicKey []byte, index uint64) *Registration {
 	return &Registration{
 		PublicKey:      publicKey,
 		Index:          index,
-		Version:        0,
+		Version:        REGISTRATION_VERSION_SIMPLE,
 		Staked:         false,
 		SpendPublicKey: nil,
+		StakeCooldown:  0,
 	}
 }
@@ -0,0 +1,67 @@
+package registration
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"testing"
+)
+
+// serializeLegacy writes the layout used before the StakeCooldown existed
+func serializeLegacy(registration *Registration) []byte {
+	w := advanced_buffers.NewBufferWriter()
+	w.WriteUvarint(0)
+	w.WriteBool(registration.Staked)
+	w.WriteBool(len(registration.SpendPublicKey) > 0)
+	w.Write(registration.SpendPublicKey)
+	return w.Bytes()
+}
+
+func TestRegistration_DeserializeLegacy(t *testing.T) {
+
+	for _, spendPublicKey := range [][]byte{nil, helpers.RandomBytes(cryptography.PublicKeySize)} {
+
+		reg := NewRegistration(helpers.RandomBytes(cryptography.PublicKeySize), 0)
+		reg.Staked = true
+		reg.SpendPublicKey = spendPublicKey
+		legacy := serializeLegacy(reg)
+
+		decoded := NewRegistration(reg.PublicKey, 0)
+		assert.NoError(t, decoded.Deserialize(advanced_buffers.NewBufferReader(legacy)))
+		assert.Equal(t, REGISTRATION_VERSION_SIMPLE, decoded.Version)
+		assert.True(t, decoded.Staked)
+		assert.Equal(t, spendPublicKey, decoded.SpendPublicKey)
+		assert.Equal(t, uint64(0), decoded.StakeCooldown)
+		assert.NoError(t, decoded.Validate())
+
+		w := advanced_buffers.NewBufferWriter()
+		decoded.Serialize(w)
+		assert.Equal(t, legacy, w.Bytes(), "legacy registrations must keep the same bytes")
+	}
+}
+
+func TestRegistration_SerializeStakeCooldown(t *testing.T) {
+
+	reg := NewRegistration(helpers.RandomBytes(cryptography.PublicKeySize), 0)
+	reg.StakeCooldown = 100
+	assert.Error(t, reg.Validate(), "the cooldown requires the cooldown version")
+
+	reg.SetStakeCooldown(100)
+	assert.NoError(t, reg.Validate())
+
+	w := advanced_buffers.NewBufferWriter()
+	reg.Serialize(w)
+	assert.Equal(t, len(serializeLegacy(reg))+1, w.Length())
+
+	decoded := NewRegistration(reg.PublicKey, 0)
+	assert.NoError(t, decoded.Deserialize(advanced_buffers.NewBufferReader(w.Bytes())))
+	assert.Equal(t, REGISTRATION_VERSION_COOLDOWN, decoded.Version)
+	assert.Equal(t, uint64(100), decoded.StakeCooldown)
+
+	reg.Version = 2
+	assert.Error(t, reg.Validate())
+	w = advanced_buffers.NewBufferWriter()
+	reg.Serialize(w)
+	assert.Error(t, decoded.Deserialize(advanced_buffers.NewBufferReader(w.Bytes())))
+}
@@ -56,5 +56,7 @@ func NewRegistrations(tx store_db_interface.StoreDBTransactionInterface) (this *
 		return registration.NewRegistration(key, index), nil
 	}
 
+	this.HashMap.StateTree = true
+
 	return
 }
@@ -23,6 +23,7 @@ type Forging struct {
 	forgingThread           *ForgingThread
 	nextBlockCreatedCn      <-chan *forging_block_work.ForgingWork
 	forgingSolutionCn       chan<- *blockchain_types.BlockchainSolution
+	signer                  ForgingSigner //nil unless the staking keys are kept by a remote signer
 }
 
 func CreateForging(mempool *mempool.Mempool, addressBalanceDecryptor *address_balance_decryptor.AddressBalanceDecryptor) (*Forging, error) {
@@ -44,7 +45,7 @@ func CreateForging(mempool *mempool.Mempool, addressBalanceDecryptor *address_ba
 			abool.New(),
 		},
 		abool.New(),
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	}
 	forging.Wallet.forging = forging
 
@@ -57,20 +58,23 @@ func (forging *Forging) InitializeForging(createForgingTransactions func(*block_
 	forging.Wallet.updateNewChainUpdate = updateNewChainUpdate
 	forging.forgingSolutionCn = forgingSolutionCn
 
-	forging.forgingThread = createForgingThread(config.CPU_THREADS, createForgingTransactions, forging.mempool, forging.addressBalanceDecryptor, forging.forgingSolutionCn, forging.nextBlockCreatedCn)
+	forging.forgingThread = createForgingThread(config.CPU_THREADS, createForgingTransactions, forging.mempool, forging.addressBalanceDecryptor, forging.forgingSolutionCn, forging.nextBlockCreatedCn, forging.signer)
 	forging.Wallet.workersCreatedCn = forging.forgingThread.workersCreatedCn
 	forging.Wallet.workersDestroyedCn = forging.forgingThread.workersDestroyedCn
 
 	forging.Wallet.initialized.Set()
 	recovery.SafeGo(forging.Wallet.runProcessUpdates)
 	recovery.SafeGo(forging.Wallet.runDecryptBalanceAndNotifyWorkers)
+	if forging.signer != nil {
+		recovery.SafeGo(forging.Wallet.runSignerAddresses)
+	}
 
 }
 
 func (forging *Forging) StartForging() bool {
 
 	if config.CONSENSUS != config.CONSENSUS_TYPE_FULL {
-		gui.GUI.Warning(`Staking was not started as "--consensus=full" is missing`)
+		gui.Forging().Warning(`Staking was not started as "--consensus=full" is missing`)
 		return false
 	}
 
@@ -83,6 +87,10 @@ func (forging *Forging) StartForging() bool {
 	return true
 }
 
+func (forging *Forging) IsForging() bool {
+	return forging.started.IsSet()
+}
+
 func (forging *Forging) StopForging() bool {
 	if forging.started.SetToIf(true, false) {
 		return true
@@ -0,0 +1,69 @@
+package forging
+
+import (
+	"math/big"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/bn256"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/gui"
+	"strconv"
+	"time"
+)
+
+// ForgingSigner keeps the private keys of the staked addresses in a separate process.
+// The node finds the kernel hash solutions using only the public keys and the staking nonces supplied by the signer
+type ForgingSigner interface {
+	GetPublicKeys() ([][]byte, error)
+	GetStakingNonce(publicKey, prevKernelHash []byte) ([]byte, error)
+	DecryptStakingBalance(publicKey, encryptedBalance []byte) (uint64, error)
+}
+
+// ComputeStakingNonce is deterministic for a private key and the kernel hash of the previous block
+func ComputeStakingNonce(prevKernelHash []byte, privateKeyPoint *big.Int) []byte {
+	uinput := append([]byte(crypto.PROTOCOL_CRYPTOPGRAPHY_CONSTANT), prevKernelHash[:]...)
+	uinput = append(uinput, config_coins.NATIVE_ASSET_FULL...)
+	uinput = append(uinput, strconv.Itoa(0)...)
+	u := new(bn256.G1).ScalarMult(crypto.HashToPoint(crypto.HashtoNumber(uinput)), privateKeyPoint)
+	return cryptography.SHA3(u.EncodeCompressed())
+}
+
+// SetSigner must be called before InitializeForging
+func (forging *Forging) SetSigner(signer ForgingSigner) {
+	forging.signer = signer
+}
+
+// runSignerAddresses forges with the staked addresses of the signer and keeps them in sync
+func (w *ForgingWallet) runSignerAddresses() {
+
+	loaded := make(map[string]bool)
+
+	for {
+
+		publicKeys, err := w.forging.signer.GetPublicKeys()
+		if err != nil {
+			gui.Forging().Error("Error reading the addresses of the forging signer", err)
+		} else {
+
+			found := make(map[string]bool)
+			for _, publicKey := range publicKeys {
+				found[string(publicKey)] = true
+				if err = w.AddSignerWallet(publicKey); err != nil {
+					gui.Forging().Error("Error adding the address of the forging signer", err)
+					continue
+				}
+				loaded[string(publicKey)] = true
+			}
+
+			for key := range loaded {
+				if !found[key] {
+					w.RemoveWallet([]byte(key), false, nil, nil, 0)
+					delete(loaded, key)
+				}
+			}
+		}
+
+		time.Sleep(time.Minute)
+	}
+
+}
@@ -0,0 +1,148 @@
+package forging_signer
+
+import (
+	"bytes"
+	"errors"
+	"github.com/vmihailenco/msgpack/v5"
+	"io"
+	"net/http"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config/config_auth"
+	"pandora-pay/cryptography/bn256"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/txs_builder/wizard"
+	"strings"
+	"time"
+)
+
+// ForgingSignerClient requests the staking nonces, the staking balances and the staking transactions from a remote signer
+type ForgingSignerClient struct {
+	url    string
+	auth   *config_auth.ConfigAuth
+	client *http.Client
+}
+
+func request[T any, B any](client *ForgingSignerClient, method string, args *T) (*B, error) {
+
+	data, err := msgpack.Marshal(&api_types.APIAuthenticated[T]{client.auth.Username, client.auth.Password, args})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.client.Post(client.url+"/"+method, "application/msgpack", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New(strings.TrimSpace(string(body)))
+	}
+
+	reply := new(B)
+	if err = msgpack.Unmarshal(body, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (client *ForgingSignerClient) GetPublicKeys() ([][]byte, error) {
+	reply, err := request[struct{}, ForgingSignerPublicKeysReply](client, "public-keys", &struct{}{})
+	if err != nil {
+		return nil, err
+	}
+	return reply.PublicKeys, nil
+}
+
+func (client *ForgingSignerClient) GetStakingNonce(publicKey, prevKernelHash []byte) ([]byte, error) {
+	reply, err := request[ForgingSignerStakingNonceRequest, ForgingSignerStakingNonceReply](client, "staking-nonce", &ForgingSignerStakingNonceRequest{publicKey, prevKernelHash})
+	if err != nil {
+		return nil, err
+	}
+	return reply.StakingNonce, nil
+}
+
+func (client *ForgingSignerClient) DecryptStakingBalance(publicKey, encryptedBalance []byte) (uint64, error) {
+	reply, err := request[ForgingSignerDecryptBalanceRequest, ForgingSignerDecryptBalanceReply](client, "decrypt-balance", &ForgingSignerDecryptBalanceRequest{publicKey, encryptedBalance})
+	if err != nil {
+		return 0, err
+	}
+	return reply.DecryptedBalance, nil
+}
+
+// SignForgingTransaction asks the signer to sign the staking transfer and to create the staking reward transaction. The forging transactions pay no fees
+func (client *ForgingSignerClient) SignForgingTransaction(publicKey []byte, transfers []*wizard.WizardZetherTransfer, reward uint64, emap map[string]map[string][]byte, hasRollovers map[string]bool, ringsSenderMembers, ringsRecipientMembers [][]*bn256.G1, chainHeight uint64, chainKernelHash []byte, publicKeyIndexes map[string]*wizard.WizardZetherPublicKeyIndex) (*transaction.Transaction, error) {
+
+	req := &ForgingSignerTxRequest{
+		publicKey,
+		make([]*wizard.WizardZetherTransfer, len(transfers)),
+		reward,
+		emap,
+		hasRollovers,
+		EncodeRings(ringsSenderMembers),
+		EncodeRings(ringsRecipientMembers),
+		chainHeight,
+		chainKernelHash,
+		publicKeyIndexes,
+	}
+
+	for t, transfer := range transfers {
+		copied := *transfer
+		copied.PayloadExtra = nil
+		req.Transfers[t] = &copied
+	}
+
+	reply, err := request[ForgingSignerTxRequest, ForgingSignerTxReply](client, "sign-forging-tx", req)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &transaction.Transaction{}
+	if err = tx.Deserialize(advanced_buffers.NewBufferReader(reply.Tx)); err != nil {
+		return nil, err
+	}
+	if err = tx.BloomAll(); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func EncodeRings(rings [][]*bn256.G1) [][][]byte {
+	out := make([][][]byte, len(rings))
+	for i, ring := range rings {
+		out[i] = make([][]byte, len(ring))
+		for j, point := range ring {
+			out[i][j] = point.EncodeCompressed()
+		}
+	}
+	return out
+}
+
+func DecodeRings(rings [][][]byte) ([][]*bn256.G1, error) {
+	out := make([][]*bn256.G1, len(rings))
+	for i, ring := range rings {
+		out[i] = make([]*bn256.G1, len(ring))
+		for j, data := range ring {
+			out[i][j] = new(bn256.G1)
+			if err := out[i][j].DecodeCompressed(data); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return out, nil
+}
+
+func NewForgingSignerClient(url string, auth *config_auth.ConfigAuth) *ForgingSignerClient {
+	return &ForgingSignerClient{
+		strings.TrimSuffix(url, "/"),
+		auth,
+		&http.Client{Timeout: 30 * time.Second},
+	}
+}
@@ -0,0 +1,33 @@
+package forging_signer
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/cryptography/bn256"
+	"testing"
+)
+
+func TestEncodeRings(t *testing.T) {
+
+	rings := make([][]*bn256.G1, 2)
+	for i := range rings {
+		rings[i] = make([]*bn256.G1, 4)
+		for j := range rings[i] {
+			rings[i][j] = new(bn256.G1).ScalarBaseMult(big.NewInt(int64(i*10 + j + 1)))
+		}
+	}
+
+	decoded, err := DecodeRings(EncodeRings(rings))
+	assert.NoError(t, err)
+	assert.Equal(t, len(rings), len(decoded))
+
+	for i := range rings {
+		assert.Equal(t, len(rings[i]), len(decoded[i]))
+		for j := range rings[i] {
+			assert.Equal(t, rings[i][j].String(), decoded[i][j].String())
+		}
+	}
+
+	_, err = DecodeRings([][][]byte{{[]byte{1, 2, 3}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,192 @@
+package forging_signer_server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/forging"
+	"pandora-pay/blockchain/forging/forging_signer"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_interface"
+	"pandora-pay/recovery"
+	"pandora-pay/txs_builder/wizard"
+	"pandora-pay/wallet"
+	"pandora-pay/wallet/wallet_address"
+)
+
+// ForgingSignerServer keeps the staking private keys of the wallet and serves the forging nodes.
+// It only signs staking transfers which can't move any funds
+type ForgingSignerServer struct {
+	wallet          *wallet.Wallet
+	rewardPublicKey []byte //recipient of the rewards allowed besides the staked address
+}
+
+func (server *ForgingSignerServer) getStakedAddress(publicKey []byte) (*wallet_address.WalletAddress, error) {
+	addr := server.wallet.GetWalletAddressByPublicKey(publicKey, true)
+	if addr == nil || addr.SharedStaked == nil || addr.SharedStaked.PrivateKey == nil {
+		return nil, errors.New("Staked address was not found")
+	}
+	return addr, nil
+}
+
+func (server *ForgingSignerServer) getPublicKeys(args *struct{}, reply *forging_signer.ForgingSignerPublicKeysReply) error {
+	reply.PublicKeys = server.wallet.GetForgingPublicKeys()
+	return nil
+}
+
+func (server *ForgingSignerServer) getStakingNonce(args *forging_signer.ForgingSignerStakingNonceRequest, reply *forging_signer.ForgingSignerStakingNonceReply) error {
+
+	if len(args.PrevKernelHash) != cryptography.HashSize {
+		return errors.New("Invalid Prev Kernel Hash")
+	}
+
+	addr, err := server.getStakedAddress(args.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	reply.StakingNonce = forging.ComputeStakingNonce(args.PrevKernelHash, new(crypto.BNRed).SetBytes(addr.SharedStaked.PrivateKey.Key).BigInt())
+	return nil
+}
+
+func (server *ForgingSignerServer) decryptBalance(args *forging_signer.ForgingSignerDecryptBalanceRequest, reply *forging_signer.ForgingSignerDecryptBalanceReply) (err error) {
+
+	addr, err := server.getStakedAddress(args.PublicKey)
+	if err != nil {
+		return
+	}
+
+	reply.DecryptedBalance, err = server.wallet.DecryptBalance(addr, args.EncryptedBalance, config_coins.NATIVE_ASSET_FULL, false, 0, true, context.Background(), func(string) {})
+	return
+}
+
+func (server *ForgingSignerServer) signForgingTx(args *forging_signer.ForgingSignerTxRequest, reply *forging_signer.ForgingSignerTxReply) error {
+
+	addr, err := server.getStakedAddress(args.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if len(args.Transfers) != 2 {
+		return errors.New("Forging transaction must have a staking and a reward transfer")
+	}
+
+	staking, reward := args.Transfers[0], args.Transfers[1]
+	if staking.Amount != 0 || staking.Burn != 0 {
+		return errors.New("Staking transfer can not transfer any funds")
+	}
+	if reward.Amount != args.Reward || reward.Burn != 0 {
+		return errors.New("Reward transfer must transfer only the reward")
+	}
+
+	recipient, err := addresses.DecodeAddr(reward.Recipient)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(recipient.PublicKey, args.PublicKey) && !bytes.Equal(recipient.PublicKey, server.rewardPublicKey) {
+		return errors.New("Reward recipient is not the staked address or the reward address of the signer")
+	}
+
+	//only the ring members are taken from the forging node. The asset, the fees and the data are not trusted
+	transfers := []*wizard.WizardZetherTransfer{
+		{
+			Asset:                  config_coins.NATIVE_ASSET_FULL,
+			SenderPrivateKey:       addr.SharedStaked.PrivateKey.Key,
+			SenderDecryptedBalance: staking.SenderDecryptedBalance,
+			Recipient:              staking.Recipient,
+			PayloadExtra:           &wizard.WizardZetherPayloadExtraStaking{},
+			WitnessIndexes:         staking.WitnessIndexes,
+		},
+		{
+			Asset:                  config_coins.NATIVE_ASSET_FULL,
+			SenderPrivateKey:       reward.SenderPrivateKey,
+			SenderDecryptedBalance: reward.SenderDecryptedBalance,
+			Recipient:              reward.Recipient,
+			Amount:                 args.Reward,
+			PayloadExtra:           &wizard.WizardZetherPayloadExtraStakingReward{nil, args.Reward},
+			WitnessIndexes:         reward.WitnessIndexes,
+		},
+	}
+	fees := []*wizard.WizardTransactionFee{{0, 0, 0, false}, {0, 0, 0, false}}
+
+	ringsSenderMembers, err := forging_signer.DecodeRings(args.RingsSenderMembers)
+	if err != nil {
+		return err
+	}
+	ringsRecipientMembers, err := forging_signer.DecodeRings(args.RingsRecipientMembers)
+	if err != nil {
+		return err
+	}
+
+	tx, err := wizard.CreateZetherTx(transfers, args.Emap, args.HasRollovers, ringsSenderMembers, ringsRecipientMembers, args.ChainHeight, args.ChainKernelHash, args.PublicKeyIndexes, fees, context.Background(), func(string) {})
+	if err != nil {
+		return err
+	}
+
+	reply.Tx = tx.Bloom.Serialized
+	return nil
+}
+
+// checkListenAddress refuses the addresses reachable from other machines. The signer serves the staking keys over plain HTTP
+// and the Basic Auth credentials would travel unencrypted, so remote forgers should reach it through a tunnel
+func checkListenAddress(address string) error {
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+
+	return errors.New("Forging signer server must listen on a loopback address like 127.0.0.1")
+}
+
+// CreateForgingSignerServer serves the staked addresses of the wallet. The rewards can be sent only to the staked address
+// itself or to the optional rewardAddress
+func CreateForgingSignerServer(wallet *wallet.Wallet, address, rewardAddress string) error {
+
+	if err := checkListenAddress(address); err != nil {
+		return err
+	}
+
+	server := &ForgingSignerServer{wallet, nil}
+
+	if rewardAddress != "" {
+		addr, err := addresses.DecodeAddr(rewardAddress)
+		if err != nil {
+			return err
+		}
+		server.rewardPublicKey = addr.PublicKey
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/public-keys", serverMethod[struct{}, forging_signer.ForgingSignerPublicKeysReply](server.getPublicKeys))
+	mux.HandleFunc("/staking-nonce", serverMethod[forging_signer.ForgingSignerStakingNonceRequest, forging_signer.ForgingSignerStakingNonceReply](server.getStakingNonce))
+	mux.HandleFunc("/decrypt-balance", serverMethod[forging_signer.ForgingSignerDecryptBalanceRequest, forging_signer.ForgingSignerDecryptBalanceReply](server.decryptBalance))
+	mux.HandleFunc("/sign-forging-tx", serverMethod[forging_signer.ForgingSignerTxRequest, forging_signer.ForgingSignerTxReply](server.signForgingTx))
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	recovery.SafeGo(func() {
+		if err := http.Serve(listener, mux); err != nil {
+			gui.Forging().Error("Forging signer stopped", err)
+		}
+	})
+
+	gui.Forging().Info("Forging signer started", gui_interface.Field("address", address))
+	return nil
+}
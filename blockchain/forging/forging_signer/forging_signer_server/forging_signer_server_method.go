@@ -0,0 +1,52 @@
+package forging_signer_server
+
+import (
+	"github.com/vmihailenco/msgpack/v5"
+	"io"
+	"net/http"
+	"pandora-pay/network/api/api_common/api_types"
+)
+
+func serverMethod[T any, B any](method func(args *T, reply *B) error) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		authenticated := new(api_types.APIAuthenticated[T])
+		if err = msgpack.Unmarshal(body, authenticated); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !authenticated.CheckAuthenticated() {
+			http.Error(w, "Invalid User or Password", http.StatusUnauthorized)
+			return
+		}
+		if authenticated.Data == nil {
+			authenticated.Data = new(T)
+		}
+
+		reply := new(B)
+		if err = method(authenticated.Data, reply); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		final, err := msgpack.Marshal(reply)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/msgpack")
+		w.Write(final)
+	}
+}
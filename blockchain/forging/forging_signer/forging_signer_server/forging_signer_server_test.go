@@ -0,0 +1,144 @@
+package forging_signer_server
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/forging"
+	"pandora-pay/blockchain/forging/forging_signer"
+	"pandora-pay/config/config_auth"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"pandora-pay/wallet"
+	"pandora-pay/wallet/wallet_address"
+	"pandora-pay/wallet/wallet_address/shared_staked"
+	"testing"
+)
+
+func createTestSignerServer(t *testing.T, rewardAddress string) (*wallet.Wallet, string) {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(false)
+	assert.NoError(t, err)
+
+	db, err := store_db_memory.CreateStoreDBMemory("wallet")
+	assert.NoError(t, err)
+	store.StoreWallet = &store.Store{Name: "wallet", Opened: true, DB: db}
+
+	forging, err := forging.CreateForging(nil, nil)
+	assert.NoError(t, err)
+
+	wallet, err := wallet.CreateWallet(forging, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config_auth.CONFIG_AUTH_USERS_MAP = map[string]*config_auth.ConfigAuth{"forger": {"forger", "secret"}}
+
+	//a free port for the server
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+
+	assert.NoError(t, CreateForgingSignerServer(wallet, address, rewardAddress))
+	return wallet, "http://" + address
+}
+
+func TestForgingSignerServer_RoundTrip(t *testing.T) {
+
+	rewardAddr, err := addresses.CreateAddr(addresses.GenerateNewPrivateKey().GeneratePublicKey(), false, nil, nil, nil, 0, nil)
+	assert.NoError(t, err)
+
+	wallet, url := createTestSignerServer(t, rewardAddr.EncodeAddr())
+
+	privateKey := addresses.GenerateNewPrivateKey()
+	publicKey := privateKey.GeneratePublicKey()
+	assert.NoError(t, wallet.AddSharedStakedAddress(&wallet_address.WalletAddress{
+		Version:        wallet_address.VERSION_NORMAL,
+		Name:           "Delegated Stake",
+		PrivateKey:     privateKey,
+		PublicKey:      publicKey,
+		IsSharedStaked: true,
+		SharedStaked:   &shared_staked.WalletAddressSharedStaked{PrivateKey: privateKey, PublicKey: publicKey},
+	}, true))
+
+	client := forging_signer.NewForgingSignerClient(url+"/", &config_auth.ConfigAuth{"forger", "secret"})
+
+	publicKeys, err := client.GetPublicKeys()
+	assert.NoError(t, err)
+	assert.Contains(t, publicKeys, publicKey)
+
+	prevKernelHash := cryptography.RandomHash()
+	stakingNonce, err := client.GetStakingNonce(publicKey, prevKernelHash)
+	assert.NoError(t, err)
+	assert.Equal(t, forging.ComputeStakingNonce(prevKernelHash, new(crypto.BNRed).SetBytes(privateKey.Key).BigInt()), stakingNonce, "the signer computes the same staking nonce as the node")
+
+	_, err = client.GetStakingNonce(publicKey, []byte{1, 2, 3})
+	assert.EqualError(t, err, "Invalid Prev Kernel Hash")
+
+	_, err = client.GetStakingNonce(addresses.GenerateNewPrivateKey().GeneratePublicKey(), prevKernelHash)
+	assert.EqualError(t, err, "Staked address was not found")
+
+	stakedAddr, err := addresses.CreateAddr(publicKey, false, nil, nil, nil, 0, nil)
+	assert.NoError(t, err)
+	otherAddr, err := addresses.CreateAddr(addresses.GenerateNewPrivateKey().GeneratePublicKey(), false, nil, nil, nil, 0, nil)
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		name      string
+		transfers []*wizard.WizardZetherTransfer
+		err       string
+	}{
+		{"staking transfer of funds", []*wizard.WizardZetherTransfer{{Amount: 1}, {Amount: 10, Recipient: stakedAddr.EncodeAddr()}}, "Staking transfer can not transfer any funds"},
+		{"staking transfer burning funds", []*wizard.WizardZetherTransfer{{Burn: 1}, {Amount: 10, Recipient: stakedAddr.EncodeAddr()}}, "Staking transfer can not transfer any funds"},
+		{"missing reward transfer", []*wizard.WizardZetherTransfer{{}}, "Forging transaction must have a staking and a reward transfer"},
+		{"reward transfer of more than the reward", []*wizard.WizardZetherTransfer{{}, {Amount: 11, Recipient: stakedAddr.EncodeAddr()}}, "Reward transfer must transfer only the reward"},
+		{"reward transfer burning funds", []*wizard.WizardZetherTransfer{{}, {Amount: 10, Burn: 1, Recipient: stakedAddr.EncodeAddr()}}, "Reward transfer must transfer only the reward"},
+		{"reward sent to another address", []*wizard.WizardZetherTransfer{{}, {Amount: 10, Recipient: otherAddr.EncodeAddr()}}, "Reward recipient is not the staked address or the reward address of the signer"},
+		{"reward sent to the staked address", []*wizard.WizardZetherTransfer{{}, {Amount: 10, Recipient: stakedAddr.EncodeAddr()}}, ""},
+		{"reward sent to the reward address", []*wizard.WizardZetherTransfer{{}, {Amount: 10, Recipient: rewardAddr.EncodeAddr()}}, ""},
+	} {
+		_, err = client.SignForgingTransaction(publicKey, test.transfers, 10, nil, nil, nil, nil, 0, nil, nil)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.name)
+		} else {
+			//the transfers were accepted, the signing fails only as the ring of the staking transfer is missing
+			assert.EqualError(t, err, "Invalid Address length", test.name)
+		}
+	}
+
+	unauthorized := forging_signer.NewForgingSignerClient(url, &config_auth.ConfigAuth{"forger", "wrong"})
+	_, err = unauthorized.GetPublicKeys()
+	assert.EqualError(t, err, "Invalid User or Password")
+}
+
+func TestForgingSignerServer_ListenAddress(t *testing.T) {
+
+	for _, test := range []struct {
+		address string
+		err     string
+	}{
+		{"127.0.0.1:16500", ""},
+		{"localhost:16500", ""},
+		{"[::1]:16500", ""},
+		{"0.0.0.0:16500", "Forging signer server must listen on a loopback address like 127.0.0.1"},
+		{":16500", "Forging signer server must listen on a loopback address like 127.0.0.1"},
+		{"8.8.8.8:16500", "Forging signer server must listen on a loopback address like 127.0.0.1"},
+		{"example.com:16500", "Forging signer server must listen on a loopback address like 127.0.0.1"},
+		{"127.0.0.1", "address 127.0.0.1: missing port in address"},
+	} {
+		if test.err == "" {
+			assert.NoError(t, checkListenAddress(test.address), test.address)
+		} else {
+			assert.EqualError(t, checkListenAddress(test.address), test.err, test.address)
+		}
+	}
+
+	assert.EqualError(t, CreateForgingSignerServer(nil, "0.0.0.0:0", ""), "Forging signer server must listen on a loopback address like 127.0.0.1", "nothing is served")
+}
@@ -0,0 +1,46 @@
+package forging_signer
+
+import (
+	"pandora-pay/txs_builder/wizard"
+)
+
+type ForgingSignerPublicKeysReply struct {
+	PublicKeys [][]byte `json:"publicKeys" msgpack:"publicKeys"`
+}
+
+type ForgingSignerStakingNonceRequest struct {
+	PublicKey      []byte `json:"publicKey" msgpack:"publicKey"`
+	PrevKernelHash []byte `json:"prevKernelHash" msgpack:"prevKernelHash"`
+}
+
+type ForgingSignerStakingNonceReply struct {
+	StakingNonce []byte `json:"stakingNonce" msgpack:"stakingNonce"`
+}
+
+type ForgingSignerDecryptBalanceRequest struct {
+	PublicKey        []byte `json:"publicKey" msgpack:"publicKey"`
+	EncryptedBalance []byte `json:"encryptedBalance" msgpack:"encryptedBalance"`
+}
+
+type ForgingSignerDecryptBalanceReply struct {
+	DecryptedBalance uint64 `json:"decryptedBalance" msgpack:"decryptedBalance"`
+}
+
+// ForgingSignerTxRequest has the prebuilt staking and reward transfers. The private key of the staking transfer is missing and it is filled by the signer.
+// The payload extras are not serialized and they are recreated by the signer
+type ForgingSignerTxRequest struct {
+	PublicKey             []byte                                        `json:"publicKey" msgpack:"publicKey"`
+	Transfers             []*wizard.WizardZetherTransfer                `json:"transfers" msgpack:"transfers"`
+	Reward                uint64                                        `json:"reward" msgpack:"reward"`
+	Emap                  map[string]map[string][]byte                  `json:"emap" msgpack:"emap"`
+	HasRollovers          map[string]bool                               `json:"hasRollovers" msgpack:"hasRollovers"`
+	RingsSenderMembers    [][][]byte                                    `json:"ringsSenderMembers" msgpack:"ringsSenderMembers"`
+	RingsRecipientMembers [][][]byte                                    `json:"ringsRecipientMembers" msgpack:"ringsRecipientMembers"`
+	ChainHeight           uint64                                        `json:"chainHeight" msgpack:"chainHeight"`
+	ChainKernelHash       []byte                                        `json:"chainKernelHash" msgpack:"chainKernelHash"`
+	PublicKeyIndexes      map[string]*wizard.WizardZetherPublicKeyIndex `json:"publicKeyIndexes" msgpack:"publicKeyIndexes"`
+}
+
+type ForgingSignerTxReply struct {
+	Tx []byte `json:"tx" msgpack:"tx"`
+}
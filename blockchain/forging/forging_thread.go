@@ -2,16 +2,17 @@ package forging
 
 import (
 	"bytes"
-	"fmt"
 	"pandora-pay/address_balance_decryptor"
 	"pandora-pay/blockchain/blockchain_types"
 	"pandora-pay/blockchain/blocks/block_complete"
 	"pandora-pay/blockchain/forging/forging_block_work"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/gui"
+	"pandora-pay/gui/gui_interface"
 	"pandora-pay/helpers/advanced_buffers"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/mempool"
+	"pandora-pay/metrics"
 	"pandora-pay/recovery"
 	"strconv"
 	"sync/atomic"
@@ -29,6 +30,7 @@ type ForgingThread struct {
 	workersDestroyedCn        chan struct{}
 	lastPrevKernelHash        *generics.Value[[]byte]
 	createForgingTransactions func(*block_complete.BlockComplete, []byte, uint64, []*transaction.Transaction) (*transaction.Transaction, error)
+	signer                    ForgingSigner
 }
 
 func (thread *ForgingThread) stopForging() {
@@ -44,7 +46,7 @@ func (thread *ForgingThread) startForging() {
 
 	forgingWorkerSolutionCn := make(chan *ForgingSolution)
 	for i := 0; i < len(thread.workers); i++ {
-		thread.workers[i] = createForgingWorkerThread(i, forgingWorkerSolutionCn, thread.addressBalanceDecryptor)
+		thread.workers[i] = createForgingWorkerThread(i, forgingWorkerSolutionCn, thread.addressBalanceDecryptor, thread.signer)
 		recovery.SafeGo(thread.workers[i].forge)
 	}
 	thread.workersCreatedCn <- thread.workers
@@ -53,11 +55,14 @@ func (thread *ForgingThread) startForging() {
 		for {
 
 			s := ""
+			total := uint64(0)
 			for i := 0; i < thread.threads; i++ {
 				hashesPerSecond := atomic.SwapUint32(&thread.workers[i].hashes, 0)
 				s += strconv.FormatUint(uint64(hashesPerSecond), 10) + " "
+				total += uint64(hashesPerSecond)
 			}
 			gui.GUI.InfoUpdate("Hashes/s", s)
+			metrics.ForgingHashesPerSecond.Set(float64(total))
 
 			time.Sleep(time.Second)
 		}
@@ -79,9 +84,9 @@ func (thread *ForgingThread) startForging() {
 			}
 
 			if newKernelHash, err = thread.publishSolution(solution); err != nil {
-				gui.GUI.Error(fmt.Errorf("Error publishing solution: %d error: %s ", solution.blkComplete.Height, err))
+				gui.Forging().Error("Error publishing solution", gui_interface.Field("height", solution.blkComplete.Height), gui_interface.Field("error", err))
 			} else {
-				gui.GUI.Info(fmt.Errorf("Block was forged! %d ", solution.blkComplete.Height))
+				gui.Forging().Info("Block was forged!", gui_interface.Field("height", solution.blkComplete.Height))
 				thread.lastPrevKernelHash.Store(newKernelHash)
 			}
 
@@ -145,7 +150,7 @@ func (thread *ForgingThread) publishSolution(solution *ForgingSolution) ([]byte,
 	return res.ChainKernelHash, res.Err
 }
 
-func createForgingThread(threads int, createForgingTransactions func(*block_complete.BlockComplete, []byte, uint64, []*transaction.Transaction) (*transaction.Transaction, error), mempool *mempool.Mempool, addressBalanceDecryptor *address_balance_decryptor.AddressBalanceDecryptor, solutionCn chan<- *blockchain_types.BlockchainSolution, nextBlockCreatedCn <-chan *forging_block_work.ForgingWork) *ForgingThread {
+func createForgingThread(threads int, createForgingTransactions func(*block_complete.BlockComplete, []byte, uint64, []*transaction.Transaction) (*transaction.Transaction, error), mempool *mempool.Mempool, addressBalanceDecryptor *address_balance_decryptor.AddressBalanceDecryptor, solutionCn chan<- *blockchain_types.BlockchainSolution, nextBlockCreatedCn <-chan *forging_block_work.ForgingWork, signer ForgingSigner) *ForgingThread {
 	return &ForgingThread{
 		mempool,
 		addressBalanceDecryptor,
@@ -157,5 +162,6 @@ func createForgingThread(threads int, createForgingTransactions func(*block_comp
 		make(chan struct{}),
 		&generics.Value[[]byte]{},
 		createForgingTransactions,
+		signer,
 	}
 }
@@ -43,9 +43,19 @@ type ForgingWalletAddressUpdate struct {
 	sharedStaked *shared_staked.WalletAddressSharedStaked
 	account      *account.Account
 	registration *registration.Registration
+	signer       bool //the private key is kept by the forging signer
 }
 
 func (w *ForgingWallet) AddWallet(publicKey []byte, sharedStaked *shared_staked.WalletAddressSharedStaked, hasAccount bool, account *account.Account, reg *registration.Registration, chainHeight uint64) (err error) {
+	return w.addWallet(publicKey, sharedStaked, false, hasAccount, account, reg, chainHeight)
+}
+
+// AddSignerWallet forges with an address whose private key is kept by the forging signer
+func (w *ForgingWallet) AddSignerWallet(publicKey []byte) error {
+	return w.addWallet(publicKey, nil, true, false, nil, nil, 0)
+}
+
+func (w *ForgingWallet) addWallet(publicKey []byte, sharedStaked *shared_staked.WalletAddressSharedStaked, signer, hasAccount bool, account *account.Account, reg *registration.Registration, chainHeight uint64) (err error) {
 
 	if !config_forging.FORGING_ENABLED || w.initialized.IsNotSet() {
 		return
@@ -84,6 +94,7 @@ func (w *ForgingWallet) AddWallet(publicKey []byte, sharedStaked *shared_staked.
 		sharedStaked,
 		account,
 		reg,
+		signer,
 	}
 	return
 }
@@ -109,7 +120,14 @@ func (w *ForgingWallet) runDecryptBalanceAndNotifyWorkers() {
 			continue
 		} else {
 			stakingAmountEncryptedBalanceSerialized := addr.account.Balance.Amount.Serialize()
-			addr.decryptedStakingBalance, _ = w.addressBalanceDecryptor.DecryptBalance("staking", addr.publicKey, addr.privateKey.Key, stakingAmountEncryptedBalanceSerialized, config_coins.NATIVE_ASSET_FULL, false, 0, true, context.Background(), func(string) {})
+			if addr.privateKey != nil {
+				addr.decryptedStakingBalance, _ = w.addressBalanceDecryptor.DecryptBalance("staking", addr.publicKey, addr.privateKey.Key, stakingAmountEncryptedBalanceSerialized, config_coins.NATIVE_ASSET_FULL, false, 0, true, context.Background(), func(string) {})
+			} else {
+				var err error
+				if addr.decryptedStakingBalance, err = w.forging.signer.DecryptStakingBalance(addr.publicKey, stakingAmountEncryptedBalanceSerialized); err != nil {
+					gui.Forging().Error("Error decrypting the staking balance by the forging signer", err)
+				}
+			}
 
 			w.workers[addr.workerIndex].addWalletAddressCn <- addr
 		}
@@ -188,7 +206,7 @@ func (w *ForgingWallet) runProcessUpdates() {
 			key := string(update.publicKey)
 
 			//let's delete it
-			if update.sharedStaked == nil || update.sharedStaked.PrivateKey == nil {
+			if !update.signer && (update.sharedStaked == nil || update.sharedStaked.PrivateKey == nil) {
 				w.removeAccountFromForgingWorkers(key)
 			} else {
 
@@ -208,11 +226,9 @@ func (w *ForgingWallet) runProcessUpdates() {
 					address := w.addressesMap[key]
 					if address == nil {
 
-						keyPoint := new(crypto.BNRed).SetBytes(update.sharedStaked.PrivateKey.Key)
-
 						address = &ForgingWalletAddress{
-							update.sharedStaked.PrivateKey,
-							keyPoint.BigInt(),
+							nil,
+							nil,
 							update.publicKey,
 							string(update.publicKey),
 							update.account,
@@ -220,6 +236,10 @@ func (w *ForgingWallet) runProcessUpdates() {
 							-1,
 							chainHash,
 						}
+						if !update.signer {
+							address.privateKey = update.sharedStaked.PrivateKey
+							address.privateKeyPoint = new(crypto.BNRed).SetBytes(update.sharedStaked.PrivateKey.Key).BigInt()
+						}
 						w.addressesMap[key] = address
 						w.updateAccountToForgingWorkers(address)
 					}
@@ -227,7 +247,7 @@ func (w *ForgingWallet) runProcessUpdates() {
 					return
 				}(); err != nil {
 					w.deleteAccount(key)
-					gui.GUI.Error(err)
+					gui.Forging().Error(err)
 				}
 
 			}
@@ -262,7 +282,7 @@ func (w *ForgingWallet) runProcessUpdates() {
 
 					} else if v.Stored == "delete" {
 						w.deleteAccount(k)
-						gui.GUI.Error("Account was deleted from Forging")
+						gui.Forging().Error("Account was deleted from Forging")
 					}
 
 				}
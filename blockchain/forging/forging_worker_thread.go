@@ -8,14 +8,11 @@ import (
 	"pandora-pay/blockchain/blocks/block_complete"
 	"pandora-pay/blockchain/forging/forging_block_work"
 	"pandora-pay/config"
-	"pandora-pay/config/config_coins"
 	"pandora-pay/cryptography"
-	"pandora-pay/cryptography/bn256"
-	"pandora-pay/cryptography/crypto"
 	"pandora-pay/gui"
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/generics"
-	"strconv"
+	"pandora-pay/recovery"
 	"sync/atomic"
 	"time"
 )
@@ -37,6 +34,8 @@ type ForgingWorkerThread struct {
 	workerSolutionCn        chan *ForgingSolution
 	addWalletAddressCn      chan *ForgingWalletAddress
 	removeWalletAddressCn   chan string //publicKey
+	stakingNonceCn          chan *forgingSignerStakingNonce
+	signer                  ForgingSigner
 }
 
 type ForgingWorkerThreadAddress struct {
@@ -44,20 +43,45 @@ type ForgingWorkerThreadAddress struct {
 	stakingAmount                   uint64
 	stakingNonce                    []byte
 	stakingNoncePrevChainKernelHash []byte
+	stakingNonceRequested           []byte //prev kernel hash of the staking nonce requested from the signer
+}
+
+// forgingSignerStakingNonce is the staking nonce received from the signer
+type forgingSignerStakingNonce struct {
+	publicKeyStr   string
+	prevKernelHash []byte
+	stakingNonce   []byte
+	err            error
+}
+
+// requestStakingNonce asks the signer for the staking nonce without blocking the worker. The address is staked once the nonce is received
+func (worker *ForgingWorkerThread) requestStakingNonce(threadAddr *ForgingWorkerThreadAddress, prevKernelHash []byte) {
+
+	if bytes.Equal(threadAddr.stakingNonceRequested, prevKernelHash) {
+		return
+	}
+	threadAddr.stakingNonceRequested = prevKernelHash
+
+	publicKey := threadAddr.walletAdr.publicKey
+	recovery.SafeGo(func() {
+		stakingNonce, err := worker.signer.GetStakingNonce(publicKey, prevKernelHash)
+		worker.stakingNonceCn <- &forgingSignerStakingNonce{string(publicKey), prevKernelHash, stakingNonce, err}
+	})
 }
 
 func (worker *ForgingWorkerThread) computeStakingAmount(threadAddr *ForgingWorkerThreadAddress, work *forging_block_work.ForgingWork) bool {
 
-	if threadAddr.walletAdr.account != nil && threadAddr.walletAdr.privateKey != nil {
+	if threadAddr.walletAdr.account != nil && (threadAddr.walletAdr.privateKey != nil || worker.signer != nil) {
 
 		if threadAddr.walletAdr.decryptedStakingBalance >= work.MinimumStake {
 
 			if !bytes.Equal(threadAddr.stakingNoncePrevChainKernelHash, work.BlkComplete.PrevKernelHash) {
-				uinput := append([]byte(crypto.PROTOCOL_CRYPTOPGRAPHY_CONSTANT), work.BlkComplete.PrevKernelHash[:]...)
-				uinput = append(uinput, config_coins.NATIVE_ASSET_FULL...)
-				uinput = append(uinput, strconv.Itoa(0)...)
-				u := new(bn256.G1).ScalarMult(crypto.HashToPoint(crypto.HashtoNumber(uinput)), threadAddr.walletAdr.privateKeyPoint)
-				threadAddr.stakingNonce = cryptography.SHA3(u.EncodeCompressed())
+				if threadAddr.walletAdr.privateKey == nil {
+					worker.requestStakingNonce(threadAddr, work.BlkComplete.PrevKernelHash)
+					threadAddr.stakingAmount = 0
+					return false
+				}
+				threadAddr.stakingNonce = ComputeStakingNonce(work.BlkComplete.PrevKernelHash, threadAddr.walletAdr.privateKeyPoint)
 				threadAddr.stakingNoncePrevChainKernelHash = work.BlkComplete.PrevKernelHash
 			}
 
@@ -140,6 +164,7 @@ func (worker *ForgingWorkerThread) forge() {
 				0,
 				nil,
 				nil,
+				nil,
 			}
 			wallets[newWalletAddr.publicKeyStr] = walletAddr
 		} else {
@@ -164,6 +189,28 @@ func (worker *ForgingWorkerThread) forge() {
 		validateWork()
 	}
 
+	newStakingNonce := func(received *forgingSignerStakingNonce) {
+		walletAddr := wallets[received.publicKeyStr]
+		if walletAddr == nil || !bytes.Equal(walletAddr.stakingNonceRequested, received.prevKernelHash) {
+			return
+		}
+		walletAddr.stakingNonceRequested = nil
+
+		if received.err != nil {
+			gui.Forging().Error("Error getting the staking nonce from the forging signer", received.err)
+			return
+		}
+		walletAddr.stakingNonce = received.stakingNonce
+		walletAddr.stakingNoncePrevChainKernelHash = received.prevKernelHash
+
+		if work != nil && !walletsStakedUsed[received.publicKeyStr] && worker.computeStakingAmount(walletAddr, work) {
+			walletsStaked[received.publicKeyStr] = walletAddr
+			walletsStakedTimestamp[received.publicKeyStr] = timestamp
+		}
+
+		validateWork()
+	}
+
 	removeWalletAddr := func(publicKeyStr string) {
 		if wallets[publicKeyStr] != nil {
 			delete(wallets, publicKeyStr)
@@ -185,6 +232,9 @@ func (worker *ForgingWorkerThread) forge() {
 		case publicKeyStr := <-worker.removeWalletAddressCn:
 			removeWalletAddr(publicKeyStr)
 			continue
+		case received := <-worker.stakingNonceCn:
+			newStakingNonce(received)
+			continue
 		case <-waitCn:
 		}
 
@@ -218,6 +268,8 @@ func (worker *ForgingWorkerThread) forge() {
 						if key == publicKeyStr {
 							goto done
 						}
+					case received := <-worker.stakingNonceCn:
+						newStakingNonce(received)
 					default:
 					}
 
@@ -242,7 +294,7 @@ func (worker *ForgingWorkerThread) forge() {
 
 						requireStakingAmount := new(big.Int).Div(new(big.Int).SetBytes(kernelHash), work.Target)
 
-						gui.GUI.Log("forged", worker.index, " -> ", work.BlkHeight, work.BlkComplete.PrevHash, address.walletAdr.decryptedStakingBalance)
+						gui.Forging().Log("forged", worker.index, " -> ", work.BlkHeight, work.BlkComplete.PrevHash, address.walletAdr.decryptedStakingBalance)
 
 						solution := &ForgingSolution{
 							localTimestamp,
@@ -273,7 +325,7 @@ func (worker *ForgingWorkerThread) forge() {
 						}
 
 					} /* else { // for debugging only
-						gui.GUI.Log(base64.StdEncoding.EncodeToString(kernelHash), strconv.FormatUint(timestamp, 10 ))
+						gui.Forging().Log(base64.StdEncoding.EncodeToString(kernelHash), strconv.FormatUint(timestamp, 10 ))
 					}*/
 
 					walletsStakedTimestamp[key] += 1
@@ -295,7 +347,7 @@ func (worker *ForgingWorkerThread) forge() {
 
 }
 
-func createForgingWorkerThread(index int, workerSolutionCn chan *ForgingSolution, addressBalanceDecryptor *address_balance_decryptor.AddressBalanceDecryptor) *ForgingWorkerThread {
+func createForgingWorkerThread(index int, workerSolutionCn chan *ForgingSolution, addressBalanceDecryptor *address_balance_decryptor.AddressBalanceDecryptor, signer ForgingSigner) *ForgingWorkerThread {
 	return &ForgingWorkerThread{
 		addressBalanceDecryptor: addressBalanceDecryptor,
 		index:                   index,
@@ -303,5 +355,7 @@ func createForgingWorkerThread(index int, workerSolutionCn chan *ForgingSolution
 		workerSolutionCn:        workerSolutionCn,
 		addWalletAddressCn:      make(chan *ForgingWalletAddress),
 		removeWalletAddressCn:   make(chan string),
+		stakingNonceCn:          make(chan *forgingSignerStakingNonce),
+		signer:                  signer,
 	}
 }
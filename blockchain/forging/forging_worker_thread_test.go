@@ -0,0 +1,58 @@
+package forging
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/data_storage/accounts/account"
+	"pandora-pay/blockchain/forging/forging_block_work"
+	"pandora-pay/cryptography"
+	"testing"
+)
+
+// testForgingSigner answers the staking nonce requests only when released
+type testForgingSigner struct {
+	requests chan []byte
+	release  chan struct{}
+}
+
+func (signer *testForgingSigner) GetPublicKeys() ([][]byte, error) {
+	return nil, nil
+}
+
+func (signer *testForgingSigner) GetStakingNonce(publicKey, prevKernelHash []byte) ([]byte, error) {
+	signer.requests <- prevKernelHash
+	<-signer.release
+	return cryptography.SHA3(prevKernelHash), nil
+}
+
+func (signer *testForgingSigner) DecryptStakingBalance(publicKey, encryptedBalance []byte) (uint64, error) {
+	return 0, nil
+}
+
+func TestForgingWorkerThread_SignerStakingNonce(t *testing.T) {
+
+	signer := &testForgingSigner{make(chan []byte, 10), make(chan struct{})}
+	worker := createForgingWorkerThread(0, nil, nil, signer)
+
+	publicKey := cryptography.RandomHash()
+	threadAddr := &ForgingWorkerThreadAddress{walletAdr: &ForgingWalletAddress{publicKey: publicKey, publicKeyStr: string(publicKey), account: &account.Account{}, decryptedStakingBalance: 100}}
+
+	work := &forging_block_work.ForgingWork{BlkComplete: block_complete.CreateEmptyBlockComplete(), MinimumStake: 10}
+	work.BlkComplete.PrevKernelHash = cryptography.RandomHash()
+
+	assert.False(t, worker.computeStakingAmount(threadAddr, work), "the worker doesn't wait for the signer")
+	assert.False(t, worker.computeStakingAmount(threadAddr, work))
+	assert.Equal(t, work.BlkComplete.PrevKernelHash, <-signer.requests)
+	assert.Equal(t, 0, len(signer.requests), "the staking nonce is requested once")
+
+	signer.release <- struct{}{}
+	received := <-worker.stakingNonceCn
+	assert.NoError(t, received.err)
+	assert.Equal(t, string(publicKey), received.publicKeyStr)
+	assert.Equal(t, cryptography.SHA3(work.BlkComplete.PrevKernelHash), received.stakingNonce)
+
+	threadAddr.stakingNonce, threadAddr.stakingNoncePrevChainKernelHash, threadAddr.stakingNonceRequested = received.stakingNonce, received.prevKernelHash, nil
+	assert.True(t, worker.computeStakingAmount(threadAddr, work))
+	assert.Equal(t, uint64(100), threadAddr.stakingAmount)
+	assert.Equal(t, 0, len(signer.requests))
+}
@@ -77,7 +77,7 @@ func CreateNewGenesisBlock() (*block.Block, error) {
 
 	var blk = block.Block{
 		BlockHeader: &block.BlockHeader{
-			Version: 0,
+			Version: block.GetBlockVersion(0),
 			Height:  0,
 		},
 		MerkleHash:     cryptography.SHA3([]byte{}),
@@ -86,6 +86,11 @@ func CreateNewGenesisBlock() (*block.Block, error) {
 		PrevKernelHash: GenesisData.KernelHash,
 	}
 
+	//the state root is computed when the block is included
+	if blk.Version == block.BLOCK_VERSION_STATE_HASH {
+		blk.StateHash = make([]byte, cryptography.HashSize)
+	}
+
 	return &blk, nil
 }
 
@@ -0,0 +1,102 @@
+package info
+
+import (
+	"bytes"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_extra"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_extra"
+	"pandora-pay/config/config_coins"
+)
+
+type AccountTxDirection byte
+
+const (
+	ACCOUNT_TX_DIRECTION_UNKNOWN AccountTxDirection = iota //zether ring members can't be distinguished
+	ACCOUNT_TX_DIRECTION_IN
+	ACCOUNT_TX_DIRECTION_OUT
+)
+
+type AccountTxInfo struct {
+	TxHash    []byte                              `json:"txHash" msgpack:"txHash"`
+	BlkHeight uint64                              `json:"blkHeight" msgpack:"blkHeight"`
+	Version   transaction_type.TransactionVersion `json:"version" msgpack:"version"`
+	Scripts   []uint64                            `json:"scripts" msgpack:"scripts"` //simple TxScript or the zether payload scripts
+	Assets    [][]byte                            `json:"assets,omitempty" msgpack:"assets,omitempty"`
+	Direction AccountTxDirection                  `json:"direction" msgpack:"direction"`
+}
+
+func (accTxInfo *AccountTxInfo) HasScript(script uint64) bool {
+	for _, it := range accTxInfo.Scripts {
+		if it == script {
+			return true
+		}
+	}
+	return false
+}
+
+func (accTxInfo *AccountTxInfo) addAsset(asset []byte) {
+	for _, it := range accTxInfo.Assets {
+		if bytes.Equal(it, asset) {
+			return
+		}
+	}
+	accTxInfo.Assets = append(accTxInfo.Assets, asset)
+}
+
+func CreateAccountTxInfoFromTx(tx *transaction.Transaction, blkHeight uint64, publicKey []byte) *AccountTxInfo {
+
+	accTxInfo := &AccountTxInfo{
+		tx.Bloom.Hash,
+		blkHeight,
+		tx.Version,
+		nil,
+		nil,
+		ACCOUNT_TX_DIRECTION_UNKNOWN,
+	}
+
+	switch tx.Version {
+	case transaction_type.TX_SIMPLE:
+		txBase := tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+		accTxInfo.Scripts = []uint64{uint64(txBase.TxScript)}
+		if txBase.HasVin() {
+			accTxInfo.addAsset(config_coins.NATIVE_ASSET_FULL) //the fee is paid in the native asset
+			if bytes.Equal(txBase.Vin.PublicKey, publicKey) {
+				accTxInfo.Direction = ACCOUNT_TX_DIRECTION_OUT
+			}
+		}
+		switch txExtra := txBase.Extra.(type) {
+		case *transaction_simple_extra.TransactionSimpleExtraClaim:
+			if accTxInfo.Direction == ACCOUNT_TX_DIRECTION_UNKNOWN && bytes.Equal(txExtra.ReceiverPublicKey, publicKey) {
+				accTxInfo.Direction = ACCOUNT_TX_DIRECTION_IN
+			}
+		case *transaction_simple_extra.TransactionSimpleExtraUpdateAssetFeeLiquidity:
+			for _, liquidity := range txExtra.Liquidities {
+				accTxInfo.addAsset(liquidity.Asset)
+			}
+		}
+	case transaction_type.TX_ZETHER:
+		txBase := tx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
+		accTxInfo.Scripts = make([]uint64, len(txBase.Payloads))
+		for i, payload := range txBase.Payloads {
+
+			accTxInfo.Scripts[i] = uint64(payload.PayloadScript)
+			accTxInfo.addAsset(payload.Asset)
+
+			switch payloadExtra := payload.Extra.(type) {
+			case *transaction_zether_payload_extra.TransactionZetherPayloadExtraPlainAccountFund:
+				if bytes.Equal(payloadExtra.PlainAccountPublicKey, publicKey) {
+					accTxInfo.Direction = ACCOUNT_TX_DIRECTION_IN
+				}
+			case *transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyIncrease:
+				if bytes.Equal(payloadExtra.ReceiverPublicKey, publicKey) {
+					accTxInfo.Direction = ACCOUNT_TX_DIRECTION_IN
+				}
+			}
+		}
+	}
+
+	return accTxInfo
+}
@@ -0,0 +1,31 @@
+package info
+
+import (
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_extra"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_script"
+)
+
+type ConditionalPaymentInfo struct {
+	PayloadIndex       byte     `json:"payloadIndex" msgpack:"payloadIndex"`
+	MultisigPublicKeys [][]byte `json:"multisigPublicKeys" msgpack:"multisigPublicKeys"`
+}
+
+func CreateConditionalPaymentsInfoFromTx(tx *transaction.Transaction) (list []*ConditionalPaymentInfo) {
+
+	if tx.Version != transaction_type.TX_ZETHER {
+		return
+	}
+
+	txBase := tx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
+	for i, payload := range txBase.Payloads {
+		if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT {
+			extra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPayment)
+			list = append(list, &ConditionalPaymentInfo{byte(i), extra.MultisigPublicKeys})
+		}
+	}
+
+	return
+}
@@ -56,6 +56,14 @@ func CreateTxPreviewFromTx(tx *transaction.Transaction) (*TxPreview, error) {
 				txBaseExtra.PayloadIndex,
 				txBaseExtra.Resolution,
 			}
+		case transaction_simple.SCRIPT_CLAIM:
+
+			txBaseExtra := txBase.Extra.(*transaction_simple_extra.TransactionSimpleExtraClaim)
+
+			previewBase.Extra = &TxPreviewSimpleExtraClaim{
+				txBaseExtra.Amount,
+				txBaseExtra.ReceiverPublicKey,
+			}
 		}
 
 		base = previewBase
@@ -87,6 +95,15 @@ func CreateTxPreviewFromTx(tx *transaction.Transaction) (*TxPreview, error) {
 			case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT:
 				txPayloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPayment)
 				payloadExtra = &TxPreviewZetherPayloadExtraPayToScript{txPayloadExtra.Deadline, txPayloadExtra.DefaultResolution, txPayloadExtra.MultisigThreshold}
+			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE:
+				txPayloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyDecrease)
+				payloadExtra = &TxPreviewZetherPayloadExtraAssetSupplyDecrease{txPayloadExtra.AssetSupplyPublicKey}
+			case transaction_zether_payload_script.SCRIPT_ASSET_UPDATE:
+				txPayloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetUpdate)
+				payloadExtra = &TxPreviewZetherPayloadExtraAssetUpdate{txPayloadExtra.AssetId, txPayloadExtra.UpdateType}
+			case transaction_zether_payload_script.SCRIPT_REGISTRATION_UPDATE:
+				txPayloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraRegistrationUpdate)
+				payloadExtra = &TxPreviewZetherPayloadExtraRegistrationUpdate{txPayloadExtra.PublicKey, txPayloadExtra.UpdateType}
 			}
 
 			payloads[i] = &TxPreviewZetherPayload{
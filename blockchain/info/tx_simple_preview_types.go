@@ -11,6 +11,11 @@ type TxPreviewSimpleExtraResolutionConditionalPayment struct {
 	Resolution   bool   `json:"resolution" msgpack:"resolution"`
 }
 
+type TxPreviewSimpleExtraClaim struct {
+	Amount            uint64 `json:"amount" msgpack:"amount"`
+	ReceiverPublicKey []byte `json:"receiverPublicKey" msgpack:"receiverPublicKey"`
+}
+
 type TxPreviewSimple struct {
 	TxScript    transaction_simple.ScriptType           `json:"txScript" msgpack:"txScript"`
 	DataVersion transaction_data.TransactionDataVersion `json:"dataVersion" msgpack:"dataVersion"`
@@ -2,6 +2,7 @@ package info
 
 import (
 	"pandora-pay/blockchain/transactions/transaction/transaction_data"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_extra"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_script"
 )
 
@@ -15,6 +16,20 @@ type TxPreviewZetherPayloadExtraStaking struct {
 type TxPreviewZetherPayloadExtraSpend struct {
 }
 
+type TxPreviewZetherPayloadExtraAssetSupplyDecrease struct {
+	AssetSupplyPublicKey []byte `json:"assetSupplyPublicKey" msgpack:"assetSupplyPublicKey"`
+}
+
+type TxPreviewZetherPayloadExtraAssetUpdate struct {
+	AssetId    []byte                                           `json:"assetId" msgpack:"assetId"`
+	UpdateType transaction_zether_payload_extra.AssetUpdateType `json:"updateType" msgpack:"updateType"`
+}
+
+type TxPreviewZetherPayloadExtraRegistrationUpdate struct {
+	PublicKey  []byte                                                  `json:"publicKey" msgpack:"publicKey"`
+	UpdateType transaction_zether_payload_extra.RegistrationUpdateType `json:"updateType" msgpack:"updateType"`
+}
+
 type TxPreviewZetherPayloadExtraPayToScript struct {
 	Deadline          uint64 `json:"deadline" msgpack:"dealine"`
 	DefaultResolution bool   `json:"defaultResolution" msgpack:"defaultResolution"`
@@ -69,6 +69,11 @@ type json_Only_TransactionSimpleExtraResolutionConditionalPayment struct {
 	Signatures         [][]byte `json:"signatures"`
 }
 
+type json_Only_TransactionSimpleExtraClaim struct {
+	Amount            uint64 `json:"amount"`
+	ReceiverPublicKey []byte `json:"receiverPublicKey"`
+}
+
 type json_Only_TransactionZether struct {
 	ChainHeight     uint64                          `json:"chainHeight"  msgpack:"chainHeight"`
 	ChainKernelHash []byte                          `json:"chainKernelHash"  msgpack:"chainKernelHash"`
@@ -100,6 +105,31 @@ type json_Only_TransactionZetherPayloadExtraAssetSupplyIncrease struct {
 	AssetSignature       []byte `json:"assetSignature"  msgpack:"assetSignature"`
 }
 
+type json_Only_TransactionZetherPayloadExtraAssetSupplyDecrease struct {
+	AssetSupplyPublicKey []byte `json:"assetSupplyPublicKey"  msgpack:"assetSupplyPublicKey"`
+	AssetSignature       []byte `json:"assetSignature"  msgpack:"assetSignature"`
+}
+
+type json_Only_TransactionZetherPayloadExtraAssetUpdate struct {
+	AssetId              []byte                                           `json:"assetId"  msgpack:"assetId"`
+	UpdateType           transaction_zether_payload_extra.AssetUpdateType `json:"updateType"  msgpack:"updateType"`
+	NewPublicKey         []byte                                           `json:"newPublicKey"  msgpack:"newPublicKey"`
+	Name                 string                                           `json:"name"  msgpack:"name"`
+	Description          string                                           `json:"description"  msgpack:"description"`
+	Data                 []byte                                           `json:"data"  msgpack:"data"`
+	AssetUpdatePublicKey []byte                                           `json:"assetUpdatePublicKey"  msgpack:"assetUpdatePublicKey"`
+	AssetSignature       []byte                                           `json:"assetSignature"  msgpack:"assetSignature"`
+}
+
+type json_Only_TransactionZetherPayloadExtraRegistrationUpdate struct {
+	PublicKey             []byte                                                  `json:"publicKey"  msgpack:"publicKey"`
+	UpdateType            transaction_zether_payload_extra.RegistrationUpdateType `json:"updateType"  msgpack:"updateType"`
+	NewSpendPublicKey     []byte                                                  `json:"newSpendPublicKey"  msgpack:"newSpendPublicKey"`
+	SpendPublicKey        []byte                                                  `json:"spendPublicKey"  msgpack:"spendPublicKey"`
+	RegistrationSignature []byte                                                  `json:"registrationSignature"  msgpack:"registrationSignature"`
+	SpendSignature        []byte                                                  `json:"spendSignature"  msgpack:"spendSignature"`
+}
+
 type json_Only_TransactionZetherPayloadExtraPlainAccountFund struct {
 	PlainAccountPublicKey []byte `json:"plainAccountPublicKey"  msgpack:"plainAccountPublicKey"`
 }
@@ -193,6 +223,12 @@ func marshalJSON(tx *Transaction, marshal func(any) ([]byte, error)) ([]byte, er
 				extra.MultisigPublicKeys,
 				extra.Signatures,
 			}
+		case transaction_simple.SCRIPT_CLAIM:
+			extra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraClaim)
+			simpleJson.Extra = json_Only_TransactionSimpleExtraClaim{
+				extra.Amount,
+				extra.ReceiverPublicKey,
+			}
 		default:
 			return nil, errors.New("Invalid simple.TxScript")
 		}
@@ -278,6 +314,34 @@ func marshalJSON(tx *Transaction, marshal func(any) ([]byte, error)) ([]byte, er
 					payloadExtra.MultisigThreshold,
 					payloadExtra.MultisigPublicKeys,
 				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE:
+				payloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyDecrease)
+				extra = &json_Only_TransactionZetherPayloadExtraAssetSupplyDecrease{
+					payloadExtra.AssetSupplyPublicKey,
+					payloadExtra.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_UPDATE:
+				payloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetUpdate)
+				extra = &json_Only_TransactionZetherPayloadExtraAssetUpdate{
+					payloadExtra.AssetId,
+					payloadExtra.UpdateType,
+					payloadExtra.NewPublicKey,
+					payloadExtra.Name,
+					payloadExtra.Description,
+					payloadExtra.Data,
+					payloadExtra.AssetUpdatePublicKey,
+					payloadExtra.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_REGISTRATION_UPDATE:
+				payloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraRegistrationUpdate)
+				extra = &json_Only_TransactionZetherPayloadExtraRegistrationUpdate{
+					payloadExtra.PublicKey,
+					payloadExtra.UpdateType,
+					payloadExtra.NewSpendPublicKey,
+					payloadExtra.SpendPublicKey,
+					payloadExtra.RegistrationSignature,
+					payloadExtra.SpendSignature,
+				}
 			default:
 				return nil, errors.New("Invalid zether.TxScript")
 			}
@@ -410,6 +474,16 @@ func (tx *Transaction) UnmarshalJSON(data []byte) (err error) {
 				extraJson.MultisigPublicKeys,
 				extraJson.Signatures,
 			}
+		case transaction_simple.SCRIPT_CLAIM:
+			extraJson := &json_Only_TransactionSimpleExtraClaim{}
+			if err = json.Unmarshal(data, extraJson); err != nil {
+				return
+			}
+
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraClaim{nil,
+				extraJson.Amount,
+				extraJson.ReceiverPublicKey,
+			}
 		default:
 			return errors.New("Invalid json Simple TxScript")
 		}
@@ -568,6 +642,46 @@ func (tx *Transaction) UnmarshalJSON(data []byte) (err error) {
 					extraJson.MultisigThreshold,
 					extraJson.MultisigPublicKeys,
 				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE:
+				extraJson := &json_Only_TransactionZetherPayloadExtraAssetSupplyDecrease{}
+				if err = json.Unmarshal(data, extraJson); err != nil {
+					return err
+				}
+				payloads[i].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyDecrease{
+					nil,
+					extraJson.AssetSupplyPublicKey,
+					extraJson.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_ASSET_UPDATE:
+				extraJson := &json_Only_TransactionZetherPayloadExtraAssetUpdate{}
+				if err = json.Unmarshal(data, extraJson); err != nil {
+					return err
+				}
+				payloads[i].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetUpdate{
+					nil,
+					extraJson.AssetId,
+					extraJson.UpdateType,
+					extraJson.NewPublicKey,
+					extraJson.Name,
+					extraJson.Description,
+					extraJson.Data,
+					extraJson.AssetUpdatePublicKey,
+					extraJson.AssetSignature,
+				}
+			case transaction_zether_payload_script.SCRIPT_REGISTRATION_UPDATE:
+				extraJson := &json_Only_TransactionZetherPayloadExtraRegistrationUpdate{}
+				if err = json.Unmarshal(data, extraJson); err != nil {
+					return err
+				}
+				payloads[i].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraRegistrationUpdate{
+					nil,
+					extraJson.PublicKey,
+					extraJson.UpdateType,
+					extraJson.NewSpendPublicKey,
+					extraJson.SpendPublicKey,
+					extraJson.RegistrationSignature,
+					extraJson.SpendSignature,
+				}
 			default:
 				return errors.New("Invalid Zether TxScript")
 			}
@@ -73,6 +73,10 @@ func (tx *TransactionSimple) ComputeAllKeys(out map[string]bool) {
 		out[string(tx.Vin.PublicKey)] = true
 	}
 
+	if tx.TxScript == SCRIPT_CLAIM {
+		out[string(tx.Extra.(*transaction_simple_extra.TransactionSimpleExtraClaim).ReceiverPublicKey)] = true
+	}
+
 	return
 }
 
@@ -82,13 +86,7 @@ func (tx *TransactionSimple) VerifySignatureManually(hashForSignature []byte) bo
 			return false
 		}
 	}
-	if tx.TxScript == SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT {
-		extra := tx.Extra.(*transaction_simple_extra.TransactionSimpleExtraResolutionConditionalPayment)
-		if !extra.VerifySignature() {
-			return false
-		}
-	}
-
+	//the multisig signatures of a resolution are verified against the threshold when the transaction is included
 	return true
 }
 
@@ -101,7 +99,7 @@ func (tx *TransactionSimple) Validate() (err error) {
 	}
 
 	switch tx.TxScript {
-	case SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY, SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT:
+	case SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY, SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT, SCRIPT_CLAIM:
 		if tx.Extra == nil {
 			return errors.New("extra is not assigned")
 		}
@@ -152,6 +150,8 @@ func (tx *TransactionSimple) Deserialize(r *advanced_buffers.BufferReader) (err
 		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraUpdateAssetFeeLiquidity{}
 	case SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT:
 		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraResolutionConditionalPayment{}
+	case SCRIPT_CLAIM:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraClaim{}
 	default:
 		return errors.New("INVALID SCRIPT TYPE")
 	}
@@ -194,7 +194,7 @@ func (tx *TransactionSimple) Deserialize(r *advanced_buffers.BufferReader) (err
 
 func (tx *TransactionSimple) HasVin() bool {
 	switch tx.TxScript {
-	case SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY:
+	case SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY, SCRIPT_CLAIM:
 		return true
 	default:
 		return false
@@ -0,0 +1,74 @@
+package transaction_simple_extra
+
+import (
+	"errors"
+	"math/big"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type TransactionSimpleExtraClaim struct {
+	TransactionSimpleExtraInterface
+	Amount            uint64
+	ReceiverPublicKey []byte //must be registered before
+}
+
+func (txExtra *TransactionSimpleExtraClaim) IncludeTransactionVin0(blockHeight uint64, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) (err error) {
+
+	if err = dataStorage.SubtractUnclaimed(plainAcc, txExtra.Amount, blockHeight); err != nil {
+		return errors.New("Not enought Unclaimed funds to claim")
+	}
+
+	reg, err := dataStorage.Regs.Get(string(txExtra.ReceiverPublicKey))
+	if err != nil {
+		return
+	}
+	if reg == nil {
+		return errors.New("Receiver must be registered before!")
+	}
+
+	//staked accounts receive the native asset as a pending stake
+	if reg.Staked {
+		amount := crypto.ConstructElGamal(crypto.ElGamal_ZERO, crypto.ElGamal_ZERO).Plus(new(big.Int).SetUint64(txExtra.Amount))
+		return dataStorage.AddPendingStake(txExtra.ReceiverPublicKey, amount, blockHeight+config_stake.GetPendingStakeWindow(blockHeight))
+	}
+
+	accs, acc, err := dataStorage.GetOrCreateAccount(config_coins.NATIVE_ASSET_FULL, txExtra.ReceiverPublicKey, false)
+	if err != nil {
+		return
+	}
+
+	acc.Balance.AddBalanceUint(txExtra.Amount)
+
+	return accs.Update(string(txExtra.ReceiverPublicKey), acc)
+}
+
+func (txExtra *TransactionSimpleExtraClaim) Validate(fee uint64) error {
+	if txExtra.Amount == 0 {
+		return errors.New("Claim Amount must be greater than zero")
+	}
+	if len(txExtra.ReceiverPublicKey) != cryptography.PublicKeySize {
+		return errors.New("Invalid Receiver Public Key")
+	}
+	return nil
+}
+
+func (txExtra *TransactionSimpleExtraClaim) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.WriteUvarint(txExtra.Amount)
+	w.Write(txExtra.ReceiverPublicKey)
+}
+
+func (txExtra *TransactionSimpleExtraClaim) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if txExtra.Amount, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if txExtra.ReceiverPublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	return
+}
@@ -3,7 +3,9 @@ package transaction_simple_extra
 import (
 	"errors"
 	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/config"
 	"pandora-pay/cryptography"
 	"pandora-pay/cryptography/crypto"
 	"pandora-pay/helpers/advanced_buffers"
@@ -55,10 +57,6 @@ func (this *TransactionSimpleExtraResolutionConditionalPayment) IncludeTransacti
 		return errors.New("Pending Future was already processed")
 	}
 
-	if int(condPayment.MultisigThreshold) > len(this.MultisigPublicKeys) {
-		return errors.New("Threshold not met")
-	}
-
 	unique := make(map[string]bool)
 	for i := range condPayment.MultisigPublicKeys {
 		unique[string(condPayment.MultisigPublicKeys[i])] = true
@@ -70,6 +68,11 @@ func (this *TransactionSimpleExtraResolutionConditionalPayment) IncludeTransacti
 		}
 	}
 
+	//the keys are distinct members, only the valid signatures count for the threshold
+	if this.countValidSignatures(blockHeight < config.NETWORK_SELECTED_RESOLUTION_SIGNATURE_NETWORK_HEIGHT) < int(condPayment.MultisigThreshold) {
+		return errors.New("Threshold not met")
+	}
+
 	if err = dataStorage.ProceedConditionalPayment(this.Resolution, condPayment); err != nil {
 		return
 	}
@@ -81,29 +84,51 @@ func (this *TransactionSimpleExtraResolutionConditionalPayment) IncludeTransacti
 	return
 }
 
+// the message is bound to the network to avoid replaying the signatures on a different chain
 func (this *TransactionSimpleExtraResolutionConditionalPayment) MessageForSigning() []byte {
+	return this.messageForSigning(true)
+}
+
+// the legacy message signed before NETWORK_SELECTED_RESOLUTION_SIGNATURE_NETWORK_HEIGHT doesn't include the network
+func (this *TransactionSimpleExtraResolutionConditionalPayment) messageForSigning(network bool) []byte {
 	w := advanced_buffers.NewBufferWriter()
+	if network {
+		w.WriteUvarint(config.NETWORK_SELECTED)
+	}
 	w.Write(this.TxId)
 	w.WriteByte(this.PayloadIndex)
 	w.WriteBool(this.Resolution)
 	return cryptography.SHA3(w.Bytes())
 }
 
-func (this *TransactionSimpleExtraResolutionConditionalPayment) VerifySignature() bool {
+// countValidSignatures verifies every signature once. The legacy signatures are accepted only before the activation
+func (this *TransactionSimpleExtraResolutionConditionalPayment) countValidSignatures(allowLegacy bool) (count int) {
+	msg := this.messageForSigning(true)
+
+	var legacyMsg []byte
+	if allowLegacy {
+		legacyMsg = this.messageForSigning(false)
+	}
+
 	for i := range this.MultisigPublicKeys {
-		msg := this.MessageForSigning()
-		if !crypto.VerifySignature(msg, this.Signatures[i], this.MultisigPublicKeys[i]) {
-			return false
+		if crypto.VerifySignature(msg, this.Signatures[i], this.MultisigPublicKeys[i]) || (allowLegacy && crypto.VerifySignature(legacyMsg, this.Signatures[i], this.MultisigPublicKeys[i])) {
+			count += 1
 		}
 	}
-	return true
+	return
+}
+
+// VerifySignature checks the signatures collected by the wallets. The block height is not known here,
+// the signatures are verified against the threshold by IncludeTransactionVin0
+func (this *TransactionSimpleExtraResolutionConditionalPayment) VerifySignature() bool {
+	return this.countValidSignatures(true) == len(this.MultisigPublicKeys)
 }
 
 func (this *TransactionSimpleExtraResolutionConditionalPayment) Validate(fee uint64) (err error) {
 	if len(this.MultisigPublicKeys) != len(this.Signatures) {
 		return errors.New("Signatures and Public Keys Mismatch")
 	}
-	if len(this.MultisigPublicKeys) == 0 || len(this.MultisigPublicKeys) > 5 {
+	if len(this.MultisigPublicKeys) == 0 || len(this.MultisigPublicKeys) > conditional_payment.MULTISIG_PUBLIC_KEYS_MAX {
 		return errors.New("Invalid number of Public Keys")
 	}
 	if fee != 0 {
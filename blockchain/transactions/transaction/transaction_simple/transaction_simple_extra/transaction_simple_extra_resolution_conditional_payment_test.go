@@ -0,0 +1,120 @@
+package transaction_simple_extra
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestResolutionConditionalPaymentLegacySignatures(t *testing.T) {
+
+	extra := &TransactionSimpleExtraResolutionConditionalPayment{nil, helpers.RandomBytes(cryptography.HashSize), 1, true, nil, nil}
+
+	privateKey := addresses.GenerateNewPrivateKey()
+	extra.MultisigPublicKeys = [][]byte{privateKey.GeneratePublicKey()}
+
+	signature, err := privateKey.Sign(extra.MessageForSigning())
+	assert.NoError(t, err)
+	extra.Signatures = [][]byte{signature}
+	assert.True(t, extra.VerifySignature())
+	assert.Equal(t, 1, extra.countValidSignatures(false))
+
+	legacySignature, err := privateKey.Sign(extra.messageForSigning(false))
+	assert.NoError(t, err)
+	extra.Signatures = [][]byte{legacySignature}
+	assert.True(t, extra.VerifySignature(), "the signatures made before the activation are still accepted")
+	assert.Equal(t, 0, extra.countValidSignatures(false), "the legacy signatures are rejected after the activation")
+
+	extra.Resolution = false
+	assert.False(t, extra.VerifySignature(), "the signature doesn't cover a different resolution")
+
+}
+
+func TestResolutionConditionalPaymentThreshold(t *testing.T) {
+
+	defer func(height uint64) { config.NETWORK_SELECTED_RESOLUTION_SIGNATURE_NETWORK_HEIGHT = height }(config.NETWORK_SELECTED_RESOLUTION_SIGNATURE_NETWORK_HEIGHT)
+	config.NETWORK_SELECTED_RESOLUTION_SIGNATURE_NETWORK_HEIGHT = 50
+
+	multisig := []*addresses.PrivateKey{addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey()}
+	multisigPublicKeys := make([][]byte, len(multisig))
+	for i := range multisig {
+		multisigPublicKeys[i] = multisig[i].GeneratePublicKey()
+	}
+
+	for _, test := range []struct {
+		name        string
+		blockHeight uint64
+		signers     []int
+		invalid     []int //signers with an invalid signature
+		legacy      bool
+		err         string
+	}{
+		{"threshold met", 60, []int{0, 1}, nil, false, ""},
+		{"all signers", 60, []int{0, 1, 2}, nil, false, ""},
+		{"an invalid signature is not counted", 60, []int{0, 1, 2}, []int{2}, false, ""},
+		{"threshold not met", 60, []int{0}, nil, false, "Threshold not met"},
+		{"threshold not met with an invalid signature", 60, []int{0, 1}, []int{1}, false, "Threshold not met"},
+		{"legacy signatures before the activation", 40, []int{0, 1}, nil, true, ""},
+		{"legacy signatures after the activation", 60, []int{0, 1}, nil, true, "Threshold not met"},
+	} {
+
+		db, err := store_db_memory.CreateStoreDBMemory("test")
+		assert.NoError(t, err)
+
+		senderPrivateKey, receiverPrivateKey := addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey()
+		sender, receiver := senderPrivateKey.GeneratePublicKey(), receiverPrivateKey.GeneratePublicKey()
+		txId := cryptography.RandomHash()
+
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+			dataStorage := data_storage.NewDataStorage(writer)
+			for _, publicKey := range [][]byte{sender, receiver} {
+				_, err = dataStorage.Regs.CreateNewRegistration(publicKey, false, nil)
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, dataStorage.AddConditionalPayment(100, txId, 0, config_coins.NATIVE_ASSET_FULL, false, true, [][]byte{sender, receiver}, []*crypto.ElGamal{
+				crypto.CommitElGamal(senderPrivateKey.GeneratePublicKeyPoint(), big.NewInt(0)),
+				crypto.CommitElGamal(receiverPrivateKey.GeneratePublicKeyPoint(), big.NewInt(10)),
+			}, 2, multisigPublicKeys))
+			return dataStorage.CommitChanges()
+		}))
+
+		extra := &TransactionSimpleExtraResolutionConditionalPayment{nil, txId, 0, true, nil, nil}
+		for _, signer := range test.signers {
+			msg := extra.messageForSigning(!test.legacy)
+			for _, invalid := range test.invalid {
+				if invalid == signer {
+					msg = helpers.RandomBytes(cryptography.HashSize)
+				}
+			}
+			signature, err := multisig[signer].Sign(msg)
+			assert.NoError(t, err)
+			extra.MultisigPublicKeys = append(extra.MultisigPublicKeys, multisigPublicKeys[signer])
+			extra.Signatures = append(extra.Signatures, signature)
+		}
+
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+			dataStorage := data_storage.NewDataStorage(writer)
+			err = extra.IncludeTransactionVin0(test.blockHeight, nil, dataStorage)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err, test.name)
+				return nil
+			}
+			assert.NoError(t, err, test.name)
+
+			condPayment, err := dataStorage.GetConditionalPayment(txId, 0)
+			assert.NoError(t, err)
+			assert.True(t, condPayment.Processed, test.name)
+			return nil
+		}))
+	}
+
+}
@@ -0,0 +1,107 @@
+package transaction_simple
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/accounts/account/account_balance_homomorphic"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_extra"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_parts"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestTransactionSimpleClaim(t *testing.T) {
+
+	const blockHeight = uint64(100)
+
+	for _, test := range []struct {
+		name           string
+		registered     bool
+		staked         bool
+		fee            uint64
+		amount         uint64
+		err            string
+		unclaimedAfter uint64
+	}{
+		{"claim", true, false, 100, 600, "", 300},
+		{"claim all", true, false, 100, 900, "", 0},
+		{"claim more than unclaimed", true, false, 100, 901, "Not enought Unclaimed funds to claim", 0},
+		{"fee more than unclaimed", true, false, 1001, 1, "Not enought Unclaimed funds to substract Tx.Fee", 0},
+		{"unregistered receiver", false, false, 100, 600, "Receiver must be registered before!", 0},
+		{"staked receiver", true, true, 100, 600, "", 300},
+	} {
+
+		db, err := store_db_memory.CreateStoreDBMemory("test")
+		assert.NoError(t, err)
+
+		senderPublicKey, receiverPublicKey := addresses.GenerateNewPrivateKey().GeneratePublicKey(), addresses.GenerateNewPrivateKey().GeneratePublicKey()
+
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+			dataStorage := data_storage.NewDataStorage(writer)
+
+			plainAcc, err := dataStorage.PlainAccs.CreateNewPlainAccount(senderPublicKey)
+			assert.NoError(t, err)
+			plainAcc.Unclaimed = 1000
+			assert.NoError(t, dataStorage.PlainAccs.Update(string(senderPublicKey), plainAcc))
+
+			if test.registered {
+				_, err = dataStorage.CreateRegistration(receiverPublicKey, test.staked, nil)
+				assert.NoError(t, err)
+			}
+
+			tx := &TransactionSimple{
+				Extra:    &transaction_simple_extra.TransactionSimpleExtraClaim{nil, test.amount, receiverPublicKey},
+				TxScript: SCRIPT_CLAIM,
+				Fee:      test.fee,
+				Vin:      &transaction_simple_parts.TransactionSimpleInput{PublicKey: senderPublicKey},
+			}
+
+			err = tx.IncludeTransaction(blockHeight, nil, dataStorage)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err, test.name)
+				return nil
+			}
+			assert.NoError(t, err, test.name)
+
+			plainAcc, err = dataStorage.PlainAccs.Get(string(senderPublicKey))
+			assert.NoError(t, err)
+			assert.Equal(t, test.unclaimedAfter, plainAcc.Unclaimed, test.name+" the unclaimed funds are debited by the amount and the fee")
+			assert.Equal(t, uint64(1), plainAcc.Nonce, test.name)
+
+			accs, err := dataStorage.AccsCollection.GetMap(config_coins.NATIVE_ASSET_FULL)
+			assert.NoError(t, err)
+			acc, err := accs.Get(string(receiverPublicKey))
+			assert.NoError(t, err)
+
+			pendingHeight := blockHeight + config_stake.GetPendingStakeWindow(blockHeight)
+			pendingStakes, err := dataStorage.PendingStakes.GetPendingStakes(pendingHeight)
+			assert.NoError(t, err)
+
+			if test.staked {
+				assert.Nil(t, acc, test.name+" the staked receiver doesn't get a balance")
+				assert.NotNil(t, pendingStakes, test.name)
+				assert.Equal(t, 1, len(pendingStakes.Pending), test.name)
+				assert.Equal(t, receiverPublicKey, pendingStakes.Pending[0].PublicKey, test.name)
+				assert.Equal(t, crypto.ConstructElGamal(crypto.ElGamal_ZERO, crypto.ElGamal_ZERO).Plus(new(big.Int).SetUint64(test.amount)).Serialize(), pendingStakes.Pending[0].PendingAmount, test.name)
+			} else {
+				assert.Nil(t, pendingStakes, test.name)
+				assert.NotNil(t, acc, test.name)
+
+				expected, err := account_balance_homomorphic.NewBalanceHomomorphicEmptyBalance(receiverPublicKey)
+				assert.NoError(t, err)
+				expected.AddBalanceUint(test.amount)
+				assert.Equal(t, expected.Amount.Serialize(), acc.Balance.Amount.Serialize(), test.name)
+			}
+
+			return nil
+		}))
+	}
+
+}
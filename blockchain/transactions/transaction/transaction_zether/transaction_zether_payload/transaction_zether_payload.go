@@ -7,6 +7,7 @@ import (
 	"pandora-pay/blockchain/data_storage"
 	"pandora-pay/blockchain/data_storage/accounts"
 	"pandora-pay/blockchain/data_storage/accounts/account"
+	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/blockchain/data_storage/registrations/registration"
 	"pandora-pay/blockchain/transactions/transaction/transaction_data"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_extra"
@@ -103,6 +104,19 @@ func (payload *TransactionZetherPayload) IncludePayload(txHash []byte, payloadIn
 	var balance *crypto.ElGamal
 
 	if !bytes.Equal(payload.Asset, config_coins.NATIVE_ASSET_FULL) {
+
+		var ast *asset.Asset
+		if ast, err = dataStorage.Asts.Get(string(payload.Asset)); err != nil {
+			return
+		}
+		if ast == nil {
+			return errors.New("Asset was not found")
+		}
+		//the pause suspends only the transfers, the supply can still be decreased by the supply key
+		if ast.Paused && payload.PayloadScript != transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE {
+			return errors.New("Asset is paused")
+		}
+
 		if err = payload.processAssetFee(payload.Asset, payload.Statement.Fee, payload.FeeRate, payload.FeeLeadingZeros, blockHeight, dataStorage); err != nil {
 			return
 		}
@@ -168,10 +182,16 @@ func (payload *TransactionZetherPayload) IncludePayload(txHash []byte, payloadIn
 
 			if payload.PayloadScript != transaction_zether_payload_script.SCRIPT_STAKING && payload.PayloadScript != transaction_zether_payload_script.SCRIPT_STAKING_REWARD {
 				if len(reg.SpendPublicKey) > 0 {
-					if payload.Extra == nil || payload.PayloadScript != transaction_zether_payload_script.SCRIPT_SPEND {
+					var spendPublicKey []byte
+					switch payload.PayloadScript {
+					case transaction_zether_payload_script.SCRIPT_SPEND:
+						spendPublicKey = payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraSpend).SenderSpendPublicKey.EncodeCompressed()
+					case transaction_zether_payload_script.SCRIPT_REGISTRATION_UPDATE: //the spend signature is verified by the extra
+						spendPublicKey = payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraRegistrationUpdate).SpendPublicKey
+					default:
 						return errors.New("PayloadScript should be spend")
 					}
-					if !bytes.Equal(reg.SpendPublicKey, payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraSpend).SenderSpendPublicKey.EncodeCompressed()) {
+					if !bytes.Equal(reg.SpendPublicKey, spendPublicKey) {
 						return errors.New("Spend Public Key is not matching")
 					}
 				}
@@ -278,7 +298,7 @@ func (payload *TransactionZetherPayload) Validate(payloadIndex byte) (err error)
 
 	switch payload.PayloadScript {
 	case transaction_zether_payload_script.SCRIPT_TRANSFER:
-	case transaction_zether_payload_script.SCRIPT_STAKING, transaction_zether_payload_script.SCRIPT_STAKING_REWARD, transaction_zether_payload_script.SCRIPT_SPEND, transaction_zether_payload_script.SCRIPT_ASSET_CREATE, transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE, transaction_zether_payload_script.SCRIPT_PLAIN_ACCOUNT_FUND, transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT:
+	case transaction_zether_payload_script.SCRIPT_STAKING, transaction_zether_payload_script.SCRIPT_STAKING_REWARD, transaction_zether_payload_script.SCRIPT_SPEND, transaction_zether_payload_script.SCRIPT_ASSET_CREATE, transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE, transaction_zether_payload_script.SCRIPT_PLAIN_ACCOUNT_FUND, transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT, transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE, transaction_zether_payload_script.SCRIPT_ASSET_UPDATE, transaction_zether_payload_script.SCRIPT_REGISTRATION_UPDATE:
 		if payload.Extra == nil {
 			return errors.New("extra is not assigned")
 		}
@@ -357,6 +377,12 @@ func (payload *TransactionZetherPayload) Deserialize(r *advanced_buffers.BufferR
 		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraSpend{}
 	case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT:
 		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPayment{}
+	case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE:
+		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyDecrease{}
+	case transaction_zether_payload_script.SCRIPT_ASSET_UPDATE:
+		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetUpdate{}
+	case transaction_zether_payload_script.SCRIPT_REGISTRATION_UPDATE:
+		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraRegistrationUpdate{}
 	default:
 		return errors.New("INVALID SCRIPT TYPE")
 	}
@@ -50,6 +50,9 @@ func (payloadExtra *TransactionZetherPayloadExtraAssetCreate) Validate(payloadRe
 	if payloadExtra.Asset.Supply != 0 {
 		return errors.New("AssetInfo Supply must be zero")
 	}
+	if payloadExtra.Asset.Version != asset.ASSET_VERSION_SIMPLE || payloadExtra.Asset.Paused || payloadExtra.Asset.Frozen {
+		return errors.New("AssetInfo can not be created paused or frozen")
+	}
 	if !bytes.Equal(payloadAsset, config_coins.NATIVE_ASSET_FULL) {
 		return errors.New("payloadAsset must be NATIVE_ASSET_FULL")
 	}
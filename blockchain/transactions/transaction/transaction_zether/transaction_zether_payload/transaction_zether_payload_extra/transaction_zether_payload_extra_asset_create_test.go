@@ -0,0 +1,42 @@
+package transaction_zether_payload_extra
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"testing"
+)
+
+func TestTransactionZetherPayloadExtraAssetCreate_Validate(t *testing.T) {
+
+	for _, test := range []struct {
+		name   string
+		update func(ast *asset.Asset)
+		err    string
+	}{
+		{"active", func(ast *asset.Asset) {}, ""},
+		{"status version", func(ast *asset.Asset) { ast.SetStatusVersion() }, "AssetInfo can not be created paused or frozen"},
+		{"paused", func(ast *asset.Asset) { ast.SetStatusVersion(); ast.Paused = true }, "AssetInfo can not be created paused or frozen"},
+		{"frozen", func(ast *asset.Asset) { ast.SetStatusVersion(); ast.Frozen = true }, "AssetInfo can not be created paused or frozen"},
+	} {
+
+		ast := asset.NewAsset(nil, 0)
+		ast.Name = "Test Asset"
+		ast.Ticker = "TEST"
+		ast.Description = "Test asset"
+		ast.MaxSupply = 1000
+		ast.UpdatePublicKey = addresses.GenerateNewPrivateKey().GeneratePublicKey()
+		ast.SupplyPublicKey = addresses.GenerateNewPrivateKey().GeneratePublicKey()
+		test.update(ast)
+		ast.SetKey(cryptography.RandomHash()[:config_coins.ASSET_LENGTH])
+
+		err := (&TransactionZetherPayloadExtraAssetCreate{nil, ast}).Validate(nil, 0, config_coins.NATIVE_ASSET_FULL, 0, nil, false)
+		if test.err == "" {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.EqualError(t, err, test.err, test.name)
+		}
+	}
+}
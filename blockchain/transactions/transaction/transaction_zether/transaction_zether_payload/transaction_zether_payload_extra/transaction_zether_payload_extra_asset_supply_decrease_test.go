@@ -0,0 +1,98 @@
+package transaction_zether_payload_extra
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+// createTestAsset stores a valid asset
+func createTestAsset(t *testing.T, db store_db_interface.StoreDBInterface, update func(ast *asset.Asset)) []byte {
+
+	assetId := cryptography.RandomHash()[:config_coins.ASSET_LENGTH]
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		dataStorage := data_storage.NewDataStorage(writer)
+		ast := asset.NewAsset(nil, 0)
+		ast.Name = "Test Asset"
+		ast.Ticker = "TEST"
+		ast.Description = "Test asset"
+		ast.MaxSupply = 1000
+		ast.UpdatePublicKey = addresses.GenerateNewPrivateKey().GeneratePublicKey()
+		ast.SupplyPublicKey = addresses.GenerateNewPrivateKey().GeneratePublicKey()
+		update(ast)
+		ast.SetKey(assetId)
+		if err = dataStorage.Asts.CreateAsset(assetId, ast); err != nil {
+			return
+		}
+		return dataStorage.CommitChanges()
+	}))
+
+	return assetId
+}
+
+func TestTransactionZetherPayloadExtraAssetSupplyDecrease_Include(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	supplyPrivateKey := addresses.GenerateNewPrivateKey()
+	assetId := createTestAsset(t, db, func(ast *asset.Asset) {
+		ast.CanBurn = true
+		ast.Supply = 500
+		ast.SupplyPublicKey = supplyPrivateKey.GeneratePublicKey()
+	})
+
+	include := func(supplyPublicKey []byte, burn uint64) (ast *asset.Asset, err error) {
+		err = db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			dataStorage := data_storage.NewDataStorage(writer)
+			payloadExtra := &TransactionZetherPayloadExtraAssetSupplyDecrease{AssetSupplyPublicKey: supplyPublicKey}
+			if err = payloadExtra.AfterIncludeTxPayload(nil, nil, 0, assetId, burn, nil, nil, 10, dataStorage); err != nil {
+				return err
+			}
+			if ast, err = dataStorage.Asts.Get(string(assetId)); err != nil {
+				return err
+			}
+			err = dataStorage.CommitChanges()
+			return err
+		})
+		return
+	}
+
+	ast, err := include(supplyPrivateKey.GeneratePublicKey(), 200)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(300), ast.Supply, "the burned value is removed from the supply")
+
+	_, err = include(addresses.GenerateNewPrivateKey().GeneratePublicKey(), 100)
+	assert.EqualError(t, err, "Asset SupplyPublicKey is not matching")
+
+	_, err = include(supplyPrivateKey.GeneratePublicKey(), 301)
+	assert.Error(t, err, "the supply can't become negative")
+
+	ast, err = include(supplyPrivateKey.GeneratePublicKey(), 300)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), ast.Supply)
+
+	assetId = createTestAsset(t, db, func(ast *asset.Asset) {
+		ast.CanBurn = true
+		ast.Supply = 500
+		ast.SupplyPublicKey = supplyPrivateKey.GeneratePublicKey()
+		ast.SetStatusVersion()
+		ast.Paused = true
+	})
+
+	ast, err = include(supplyPrivateKey.GeneratePublicKey(), 100)
+	assert.NoError(t, err, "the supply of a paused asset can be decreased")
+	assert.Equal(t, uint64(400), ast.Supply)
+
+	payloadExtra := &TransactionZetherPayloadExtraAssetSupplyDecrease{AssetSupplyPublicKey: supplyPrivateKey.GeneratePublicKey(), AssetSignature: make([]byte, cryptography.SignatureSize)}
+	assert.NoError(t, payloadExtra.Validate(nil, 0, assetId, 1, nil, false))
+	assert.Error(t, payloadExtra.Validate(nil, 0, assetId, 0, nil, false), "the burn value is required")
+	assert.Error(t, payloadExtra.Validate(nil, 0, config_coins.NATIVE_ASSET_FULL, 1, nil, false), "the native asset supply can't be decreased")
+}
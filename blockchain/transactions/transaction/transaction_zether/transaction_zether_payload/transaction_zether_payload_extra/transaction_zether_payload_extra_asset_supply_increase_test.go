@@ -0,0 +1,65 @@
+package transaction_zether_payload_extra
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestTransactionZetherPayloadExtraAssetSupplyIncrease_Include(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	supplyPrivateKey := addresses.GenerateNewPrivateKey()
+	receiver := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		dataStorage := data_storage.NewDataStorage(writer)
+		if _, err = dataStorage.CreateRegistration(receiver, false, nil); err != nil {
+			return
+		}
+		return dataStorage.CommitChanges()
+	}))
+
+	for _, test := range []struct {
+		name   string
+		paused bool
+		frozen bool
+		err    string
+	}{
+		{"active", false, false, ""},
+		{"paused", true, false, ""},
+		{"frozen", false, true, "Asset supply is frozen"},
+	} {
+
+		assetId := createTestAsset(t, db, func(ast *asset.Asset) {
+			ast.CanMint = true
+			ast.SupplyPublicKey = supplyPrivateKey.GeneratePublicKey()
+			ast.SetStatusVersion()
+			ast.Paused = test.paused
+			ast.Frozen = test.frozen
+		})
+
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			dataStorage := data_storage.NewDataStorage(writer)
+			payloadExtra := &TransactionZetherPayloadExtraAssetSupplyIncrease{nil, assetId, receiver, 100, supplyPrivateKey.GeneratePublicKey(), nil}
+
+			err := payloadExtra.AfterIncludeTxPayload(nil, nil, 0, nil, 0, nil, nil, 10, dataStorage)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err, test.name)
+				return nil
+			}
+			assert.NoError(t, err, test.name)
+
+			ast, err := dataStorage.Asts.Get(string(assetId))
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(100), ast.Supply, test.name)
+			return nil
+		}))
+	}
+}
@@ -0,0 +1,190 @@
+package transaction_zether_payload_extra
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_registrations"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type TransactionZetherPayloadExtraAssetUpdate struct {
+	TransactionZetherPayloadExtraInterface
+	AssetId              []byte
+	UpdateType           AssetUpdateType
+	NewPublicKey         []byte //only for ASSET_UPDATE_UPDATE_PUBLIC_KEY and ASSET_UPDATE_SUPPLY_PUBLIC_KEY
+	Name                 string //only for ASSET_UPDATE_INFO
+	Description          string //only for ASSET_UPDATE_INFO
+	Data                 []byte //only for ASSET_UPDATE_INFO
+	AssetUpdatePublicKey []byte //TODO: it can be bloomed
+	AssetSignature       []byte
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) BeforeIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) AfterIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+
+	ast, err := dataStorage.Asts.Get(string(payloadExtra.AssetId))
+	if err != nil {
+		return
+	}
+
+	if ast == nil {
+		return errors.New("Asset was not found")
+	}
+
+	if !bytes.Equal(payloadExtra.AssetUpdatePublicKey, ast.UpdatePublicKey) {
+		return errors.New("Asset UpdatePublicKey is not matching")
+	}
+
+	switch payloadExtra.UpdateType {
+	case ASSET_UPDATE_PAUSE, ASSET_UPDATE_UNPAUSE:
+		if !ast.CanPause {
+			return errors.New("Asset can't be paused")
+		}
+		pause := payloadExtra.UpdateType == ASSET_UPDATE_PAUSE
+		if ast.Paused == pause {
+			return errors.New("Asset pause status is already set")
+		}
+		ast.SetStatusVersion()
+		ast.Paused = pause
+	case ASSET_UPDATE_FREEZE:
+		if !ast.CanFreeze {
+			return errors.New("Asset can't be frozen")
+		}
+		if ast.Frozen {
+			return errors.New("Asset is already frozen")
+		}
+		ast.SetStatusVersion()
+		ast.Frozen = true
+	case ASSET_UPDATE_UPDATE_PUBLIC_KEY:
+		if !ast.CanChangeUpdatePublicKey {
+			return errors.New("Asset UpdatePublicKey can't be changed")
+		}
+		ast.UpdatePublicKey = payloadExtra.NewPublicKey
+	case ASSET_UPDATE_SUPPLY_PUBLIC_KEY:
+		if !ast.CanChangeSupplyPublicKey {
+			return errors.New("Asset SupplyPublicKey can't be changed")
+		}
+		ast.SupplyPublicKey = payloadExtra.NewPublicKey
+	case ASSET_UPDATE_INFO:
+		if !ast.CanUpgrade {
+			return errors.New("Asset can't be upgraded")
+		}
+		ast.Name = payloadExtra.Name
+		ast.Description = payloadExtra.Description
+		ast.Data = payloadExtra.Data
+	default:
+		return errors.New("Invalid Asset UpdateType")
+	}
+
+	//validation is done in Update
+	return dataStorage.Asts.Update(string(payloadExtra.AssetId), ast)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) ComputeAllKeys(out map[string]bool) {
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) VerifyExtraSignature(hashForSignature []byte, payloadStatement *crypto.Statement) bool {
+	return crypto.VerifySignature(hashForSignature, payloadExtra.AssetSignature, payloadExtra.AssetUpdatePublicKey)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) Validate(payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, payloadParity bool) error {
+	if !bytes.Equal(payloadAsset, config_coins.NATIVE_ASSET_FULL) {
+		return errors.New("payloadAsset must be NATIVE_ASSET_FULL")
+	}
+	if len(payloadExtra.AssetId) != config_coins.ASSET_LENGTH || bytes.Equal(payloadExtra.AssetId, config_coins.NATIVE_ASSET_FULL) {
+		return errors.New("Invalid AssetId")
+	}
+
+	switch payloadExtra.UpdateType {
+	case ASSET_UPDATE_PAUSE, ASSET_UPDATE_UNPAUSE, ASSET_UPDATE_FREEZE, ASSET_UPDATE_INFO:
+		if len(payloadExtra.NewPublicKey) != 0 {
+			return errors.New("NewPublicKey must be empty")
+		}
+	case ASSET_UPDATE_UPDATE_PUBLIC_KEY, ASSET_UPDATE_SUPPLY_PUBLIC_KEY:
+		if len(payloadExtra.NewPublicKey) != cryptography.PublicKeySize {
+			return errors.New("Invalid NewPublicKey")
+		}
+	default:
+		return errors.New("Invalid Asset UpdateType")
+	}
+
+	if payloadExtra.UpdateType != ASSET_UPDATE_INFO && (len(payloadExtra.Name) > 0 || len(payloadExtra.Description) > 0 || len(payloadExtra.Data) > 0) {
+		return errors.New("Asset Info must be empty")
+	}
+
+	if len(payloadExtra.AssetUpdatePublicKey) != cryptography.PublicKeySize {
+		return errors.New("Invalid Public Key")
+	}
+	if len(payloadExtra.AssetSignature) != cryptography.SignatureSize {
+		return errors.New("Invalid Signature")
+	}
+	return nil
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(payloadExtra.AssetId)
+	w.WriteByte(byte(payloadExtra.UpdateType))
+	switch payloadExtra.UpdateType {
+	case ASSET_UPDATE_UPDATE_PUBLIC_KEY, ASSET_UPDATE_SUPPLY_PUBLIC_KEY:
+		w.Write(payloadExtra.NewPublicKey)
+	case ASSET_UPDATE_INFO:
+		w.WriteString(payloadExtra.Name)
+		w.WriteString(payloadExtra.Description)
+		w.WriteVariableBytes(payloadExtra.Data)
+	}
+	w.Write(payloadExtra.AssetUpdatePublicKey)
+	if inclSignature {
+		w.Write(payloadExtra.AssetSignature)
+	}
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if payloadExtra.AssetId, err = r.ReadBytes(config_coins.ASSET_LENGTH); err != nil {
+		return
+	}
+
+	var n byte
+	if n, err = r.ReadByte(); err != nil {
+		return
+	}
+	payloadExtra.UpdateType = AssetUpdateType(n)
+
+	switch payloadExtra.UpdateType {
+	case ASSET_UPDATE_PAUSE, ASSET_UPDATE_UNPAUSE, ASSET_UPDATE_FREEZE:
+	case ASSET_UPDATE_UPDATE_PUBLIC_KEY, ASSET_UPDATE_SUPPLY_PUBLIC_KEY:
+		if payloadExtra.NewPublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+			return
+		}
+	case ASSET_UPDATE_INFO:
+		if payloadExtra.Name, err = r.ReadString(15); err != nil {
+			return
+		}
+		if payloadExtra.Description, err = r.ReadString(1024); err != nil {
+			return
+		}
+		if payloadExtra.Data, err = r.ReadVariableBytes(5120); err != nil {
+			return
+		}
+	default:
+		return errors.New("Invalid Asset UpdateType")
+	}
+
+	if payloadExtra.AssetUpdatePublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if payloadExtra.AssetSignature, err = r.ReadBytes(cryptography.SignatureSize); err != nil {
+		return
+	}
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraAssetUpdate) UpdateStatement(payloadStatement *crypto.Statement) error {
+	return nil
+}
@@ -0,0 +1,66 @@
+package transaction_zether_payload_extra
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestTransactionZetherPayloadExtraAssetUpdate_Include(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	updatePrivateKey := addresses.GenerateNewPrivateKey()
+	assetId := createTestAsset(t, db, func(ast *asset.Asset) {
+		ast.CanUpgrade = true
+		ast.CanPause = true
+		ast.UpdatePublicKey = updatePrivateKey.GeneratePublicKey()
+	})
+
+	include := func(payloadExtra *TransactionZetherPayloadExtraAssetUpdate) (ast *asset.Asset, err error) {
+		payloadExtra.AssetId = assetId
+		payloadExtra.AssetUpdatePublicKey = updatePrivateKey.GeneratePublicKey()
+		err = db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			dataStorage := data_storage.NewDataStorage(writer)
+			if err = payloadExtra.AfterIncludeTxPayload(nil, nil, 0, nil, 0, nil, nil, 10, dataStorage); err != nil {
+				return err
+			}
+			if ast, err = dataStorage.Asts.Get(string(assetId)); err != nil {
+				return err
+			}
+			err = dataStorage.CommitChanges()
+			return err
+		})
+		return
+	}
+
+	ast, err := include(&TransactionZetherPayloadExtraAssetUpdate{UpdateType: ASSET_UPDATE_INFO, Name: "First Name", Description: "First description"})
+	assert.NoError(t, err)
+	assert.Equal(t, "First Name", ast.Name)
+	assert.Equal(t, asset.ASSET_VERSION_SIMPLE, ast.Version, "the info update doesn't change the serialization version")
+
+	ast, err = include(&TransactionZetherPayloadExtraAssetUpdate{UpdateType: ASSET_UPDATE_INFO, Name: "Second Name", Description: "Second description", Data: []byte{1, 2}})
+	assert.NoError(t, err, "the asset can be upgraded multiple times")
+	assert.Equal(t, "Second Name", ast.Name)
+	assert.Equal(t, []byte{1, 2}, ast.Data)
+	assert.Equal(t, asset.ASSET_VERSION_SIMPLE, ast.Version)
+
+	ast, err = include(&TransactionZetherPayloadExtraAssetUpdate{UpdateType: ASSET_UPDATE_PAUSE})
+	assert.NoError(t, err)
+	assert.True(t, ast.Paused)
+	assert.Equal(t, asset.ASSET_VERSION_STATUS, ast.Version)
+
+	ast, err = include(&TransactionZetherPayloadExtraAssetUpdate{UpdateType: ASSET_UPDATE_INFO, Name: "Third Name", Description: "Third description"})
+	assert.NoError(t, err, "the asset can be upgraded after a pause")
+	assert.Equal(t, "Third Name", ast.Name)
+	assert.True(t, ast.Paused)
+	assert.Equal(t, asset.ASSET_VERSION_STATUS, ast.Version)
+
+	_, err = include(&TransactionZetherPayloadExtraAssetUpdate{UpdateType: ASSET_UPDATE_FREEZE})
+	assert.EqualError(t, err, "Asset can't be frozen")
+}
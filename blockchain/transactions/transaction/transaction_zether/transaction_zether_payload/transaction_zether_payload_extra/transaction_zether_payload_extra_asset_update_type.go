@@ -0,0 +1,31 @@
+package transaction_zether_payload_extra
+
+type AssetUpdateType byte
+
+const (
+	ASSET_UPDATE_PAUSE AssetUpdateType = iota
+	ASSET_UPDATE_UNPAUSE
+	ASSET_UPDATE_FREEZE
+	ASSET_UPDATE_UPDATE_PUBLIC_KEY
+	ASSET_UPDATE_SUPPLY_PUBLIC_KEY
+	ASSET_UPDATE_INFO
+)
+
+func (t AssetUpdateType) String() string {
+	switch t {
+	case ASSET_UPDATE_PAUSE:
+		return "ASSET_UPDATE_PAUSE"
+	case ASSET_UPDATE_UNPAUSE:
+		return "ASSET_UPDATE_UNPAUSE"
+	case ASSET_UPDATE_FREEZE:
+		return "ASSET_UPDATE_FREEZE"
+	case ASSET_UPDATE_UPDATE_PUBLIC_KEY:
+		return "ASSET_UPDATE_UPDATE_PUBLIC_KEY"
+	case ASSET_UPDATE_SUPPLY_PUBLIC_KEY:
+		return "ASSET_UPDATE_SUPPLY_PUBLIC_KEY"
+	case ASSET_UPDATE_INFO:
+		return "ASSET_UPDATE_INFO"
+	default:
+		return "Unknown AssetUpdateType"
+	}
+}
@@ -2,7 +2,9 @@ package transaction_zether_payload_extra
 
 import (
 	"errors"
+	"fmt"
 	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_registrations"
 	"pandora-pay/cryptography"
 	"pandora-pay/cryptography/crypto"
@@ -51,8 +53,8 @@ func (payloadExtra *TransactionZetherPayloadExtraConditionalPayment) Validate(pa
 	if payloadStatement.Fee != 0 {
 		return errors.New("Payload Fee must be zero")
 	}
-	if len(payloadExtra.MultisigPublicKeys) > 5 {
-		return errors.New("PublicKeys list is limited to 5 elements")
+	if len(payloadExtra.MultisigPublicKeys) > conditional_payment.MULTISIG_PUBLIC_KEYS_MAX {
+		return fmt.Errorf("PublicKeys list is limited to %d elements", conditional_payment.MULTISIG_PUBLIC_KEYS_MAX)
 	}
 	if payloadExtra.MultisigThreshold == 0 {
 		return errors.New("Threshold should not be zero")
@@ -0,0 +1,184 @@
+package transaction_zether_payload_extra
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_registrations"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type TransactionZetherPayloadExtraRegistrationUpdate struct {
+	TransactionZetherPayloadExtraInterface
+	PublicKey             []byte //account which registration is updated
+	UpdateType            RegistrationUpdateType
+	NewSpendPublicKey     []byte //only for REGISTRATION_UPDATE_SPEND_PUBLIC_KEY
+	SpendPublicKey        []byte //current spend public key of the registration. Empty if it has none
+	RegistrationSignature []byte //signed by the account private key
+	SpendSignature        []byte //signed by the current spend private key
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraRegistrationUpdate) BeforeIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraRegistrationUpdate) AfterIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+
+	reg, err := dataStorage.Regs.Get(string(payloadExtra.PublicKey))
+	if err != nil {
+		return
+	}
+
+	if reg == nil {
+		return errors.New("Account was not registered")
+	}
+
+	if !bytes.Equal(payloadExtra.SpendPublicKey, reg.SpendPublicKey) {
+		return errors.New("Spend Public Key is not matching")
+	}
+
+	switch payloadExtra.UpdateType {
+	case REGISTRATION_UPDATE_STAKE, REGISTRATION_UPDATE_UNSTAKE:
+		staked := payloadExtra.UpdateType == REGISTRATION_UPDATE_STAKE
+		if reg.Staked == staked {
+			return errors.New("Registration staked status is already set")
+		}
+		//the staked status can be toggled again only after the pending stake window
+		if blockHeight < reg.StakeCooldown {
+			return errors.New("Registration staked status can't be changed before the cooldown")
+		}
+		reg.Staked = staked
+		reg.SetStakeCooldown(blockHeight + config_stake.GetPendingStakeWindow(blockHeight))
+	case REGISTRATION_UPDATE_SPEND_PUBLIC_KEY:
+		if bytes.Equal(reg.SpendPublicKey, payloadExtra.NewSpendPublicKey) {
+			return errors.New("Spend Public Key is already set")
+		}
+		reg.SpendPublicKey = payloadExtra.NewSpendPublicKey
+	case REGISTRATION_UPDATE_REMOVE_SPEND_PUBLIC_KEY:
+		if len(reg.SpendPublicKey) == 0 {
+			return errors.New("Registration has no Spend Public Key")
+		}
+		reg.SpendPublicKey = nil
+	default:
+		return errors.New("Invalid Registration UpdateType")
+	}
+
+	return dataStorage.Regs.Update(string(payloadExtra.PublicKey), reg)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraRegistrationUpdate) ComputeAllKeys(out map[string]bool) {
+	out[string(payloadExtra.PublicKey)] = true
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraRegistrationUpdate) VerifyExtraSignature(hashForSignature []byte, payloadStatement *crypto.Statement) bool {
+	if !crypto.VerifySignature(hashForSignature, payloadExtra.RegistrationSignature, payloadExtra.PublicKey) {
+		return false
+	}
+	if len(payloadExtra.SpendPublicKey) > 0 {
+		return crypto.VerifySignature(hashForSignature, payloadExtra.SpendSignature, payloadExtra.SpendPublicKey)
+	}
+	return true
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraRegistrationUpdate) Validate(payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, payloadParity bool) error {
+	if !bytes.Equal(payloadAsset, config_coins.NATIVE_ASSET_FULL) {
+		return errors.New("payloadAsset must be NATIVE_ASSET_FULL")
+	}
+	if len(payloadExtra.PublicKey) != cryptography.PublicKeySize {
+		return errors.New("Invalid Public Key")
+	}
+
+	switch payloadExtra.UpdateType {
+	case REGISTRATION_UPDATE_STAKE, REGISTRATION_UPDATE_UNSTAKE, REGISTRATION_UPDATE_REMOVE_SPEND_PUBLIC_KEY:
+		if len(payloadExtra.NewSpendPublicKey) != 0 {
+			return errors.New("NewSpendPublicKey must be empty")
+		}
+	case REGISTRATION_UPDATE_SPEND_PUBLIC_KEY:
+		if len(payloadExtra.NewSpendPublicKey) != cryptography.PublicKeySize {
+			return errors.New("Invalid NewSpendPublicKey")
+		}
+	default:
+		return errors.New("Invalid Registration UpdateType")
+	}
+
+	if len(payloadExtra.RegistrationSignature) != cryptography.SignatureSize {
+		return errors.New("Invalid Registration Signature")
+	}
+
+	if len(payloadExtra.SpendPublicKey) > 0 {
+		if len(payloadExtra.SpendPublicKey) != cryptography.PublicKeySize {
+			return errors.New("Invalid Spend Public Key")
+		}
+		if len(payloadExtra.SpendSignature) != cryptography.SignatureSize {
+			return errors.New("Invalid Spend Signature")
+		}
+	} else if len(payloadExtra.SpendSignature) != 0 {
+		return errors.New("Spend Signature must be empty")
+	}
+
+	return nil
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraRegistrationUpdate) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(payloadExtra.PublicKey)
+	w.WriteByte(byte(payloadExtra.UpdateType))
+	if payloadExtra.UpdateType == REGISTRATION_UPDATE_SPEND_PUBLIC_KEY {
+		w.Write(payloadExtra.NewSpendPublicKey)
+	}
+	w.WriteBool(len(payloadExtra.SpendPublicKey) > 0)
+	w.Write(payloadExtra.SpendPublicKey)
+	if inclSignature {
+		w.Write(payloadExtra.RegistrationSignature)
+		w.Write(payloadExtra.SpendSignature)
+	}
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraRegistrationUpdate) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if payloadExtra.PublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+
+	var n byte
+	if n, err = r.ReadByte(); err != nil {
+		return
+	}
+	payloadExtra.UpdateType = RegistrationUpdateType(n)
+
+	switch payloadExtra.UpdateType {
+	case REGISTRATION_UPDATE_STAKE, REGISTRATION_UPDATE_UNSTAKE, REGISTRATION_UPDATE_REMOVE_SPEND_PUBLIC_KEY:
+	case REGISTRATION_UPDATE_SPEND_PUBLIC_KEY:
+		if payloadExtra.NewSpendPublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+			return
+		}
+	default:
+		return errors.New("Invalid Registration UpdateType")
+	}
+
+	var hasSpendPublicKey bool
+	if hasSpendPublicKey, err = r.ReadBool(); err != nil {
+		return
+	}
+	if hasSpendPublicKey {
+		if payloadExtra.SpendPublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+			return
+		}
+	}
+
+	if payloadExtra.RegistrationSignature, err = r.ReadBytes(cryptography.SignatureSize); err != nil {
+		return
+	}
+	if hasSpendPublicKey {
+		if payloadExtra.SpendSignature, err = r.ReadBytes(cryptography.SignatureSize); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraRegistrationUpdate) UpdateStatement(payloadStatement *crypto.Statement) error {
+	return nil
+}
@@ -0,0 +1,153 @@
+package transaction_zether_payload_extra
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/registrations/registration"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func createTestRegistrationUpdate(t *testing.T, privateKey, spendPrivateKey *addresses.PrivateKey, updateType RegistrationUpdateType, hashForSignature []byte) *TransactionZetherPayloadExtraRegistrationUpdate {
+
+	payloadExtra := &TransactionZetherPayloadExtraRegistrationUpdate{
+		PublicKey:  privateKey.GeneratePublicKey(),
+		UpdateType: updateType,
+	}
+	if updateType == REGISTRATION_UPDATE_SPEND_PUBLIC_KEY {
+		payloadExtra.NewSpendPublicKey = addresses.GenerateNewPrivateKey().GeneratePublicKey()
+	}
+
+	var err error
+	if payloadExtra.RegistrationSignature, err = privateKey.Sign(hashForSignature); err != nil {
+		t.Fatal(err)
+	}
+	if spendPrivateKey != nil {
+		payloadExtra.SpendPublicKey = spendPrivateKey.GeneratePublicKey()
+		if payloadExtra.SpendSignature, err = spendPrivateKey.Sign(hashForSignature); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return payloadExtra
+}
+
+func TestTransactionZetherPayloadExtraRegistrationUpdate_Serialize(t *testing.T) {
+
+	hashForSignature := cryptography.SHA3([]byte("registration update"))
+
+	for _, updateType := range []RegistrationUpdateType{REGISTRATION_UPDATE_STAKE, REGISTRATION_UPDATE_UNSTAKE, REGISTRATION_UPDATE_SPEND_PUBLIC_KEY, REGISTRATION_UPDATE_REMOVE_SPEND_PUBLIC_KEY} {
+		for _, spendPrivateKey := range []*addresses.PrivateKey{nil, addresses.GenerateNewPrivateKey()} {
+
+			payloadExtra := createTestRegistrationUpdate(t, addresses.GenerateNewPrivateKey(), spendPrivateKey, updateType, hashForSignature)
+			assert.NoError(t, payloadExtra.Validate(nil, 0, config_coins.NATIVE_ASSET_FULL, 0, nil, false))
+			assert.True(t, payloadExtra.VerifyExtraSignature(hashForSignature, nil))
+
+			w := advanced_buffers.NewBufferWriter()
+			payloadExtra.Serialize(w, true)
+
+			decoded := &TransactionZetherPayloadExtraRegistrationUpdate{}
+			assert.NoError(t, decoded.Deserialize(advanced_buffers.NewBufferReader(w.Bytes())))
+			assert.Equal(t, payloadExtra, decoded)
+			assert.NoError(t, decoded.Validate(nil, 0, config_coins.NATIVE_ASSET_FULL, 0, nil, false))
+		}
+	}
+
+	payloadExtra := createTestRegistrationUpdate(t, addresses.GenerateNewPrivateKey(), nil, REGISTRATION_UPDATE_STAKE, hashForSignature)
+	assert.Error(t, payloadExtra.Validate(nil, 0, cryptography.SHA3([]byte("asset"))[:config_coins.ASSET_LENGTH], 0, nil, false), "only the native asset is allowed")
+
+	payloadExtra.NewSpendPublicKey = addresses.GenerateNewPrivateKey().GeneratePublicKey()
+	assert.Error(t, payloadExtra.Validate(nil, 0, config_coins.NATIVE_ASSET_FULL, 0, nil, false), "NewSpendPublicKey is only for REGISTRATION_UPDATE_SPEND_PUBLIC_KEY")
+}
+
+func TestTransactionZetherPayloadExtraRegistrationUpdate_SpendSignature(t *testing.T) {
+
+	hashForSignature := cryptography.SHA3([]byte("registration update"))
+	spendPrivateKey := addresses.GenerateNewPrivateKey()
+
+	payloadExtra := createTestRegistrationUpdate(t, addresses.GenerateNewPrivateKey(), spendPrivateKey, REGISTRATION_UPDATE_UNSTAKE, hashForSignature)
+	assert.True(t, payloadExtra.VerifyExtraSignature(hashForSignature, nil))
+
+	signature := payloadExtra.SpendSignature
+	payloadExtra.SpendSignature = nil
+	assert.Error(t, payloadExtra.Validate(nil, 0, config_coins.NATIVE_ASSET_FULL, 0, nil, false), "the spend signature is required")
+	assert.False(t, payloadExtra.VerifyExtraSignature(hashForSignature, nil))
+
+	//signed by another spend key
+	payloadExtra.SpendSignature, _ = addresses.GenerateNewPrivateKey().Sign(hashForSignature)
+	assert.NoError(t, payloadExtra.Validate(nil, 0, config_coins.NATIVE_ASSET_FULL, 0, nil, false))
+	assert.False(t, payloadExtra.VerifyExtraSignature(hashForSignature, nil))
+
+	//signed for another tx
+	payloadExtra.SpendSignature, _ = spendPrivateKey.Sign(cryptography.SHA3([]byte("another tx")))
+	assert.False(t, payloadExtra.VerifyExtraSignature(hashForSignature, nil))
+
+	payloadExtra.SpendSignature = signature
+	payloadExtra.RegistrationSignature = signature
+	assert.False(t, payloadExtra.VerifyExtraSignature(hashForSignature, nil), "the registration must be signed by the account")
+
+	payloadExtra = createTestRegistrationUpdate(t, addresses.GenerateNewPrivateKey(), nil, REGISTRATION_UPDATE_STAKE, hashForSignature)
+	payloadExtra.SpendSignature = signature
+	assert.Error(t, payloadExtra.Validate(nil, 0, config_coins.NATIVE_ASSET_FULL, 0, nil, false), "the spend signature must be empty without a spend key")
+}
+
+func TestTransactionZetherPayloadExtraRegistrationUpdate_StakeCooldown(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	privateKey, spendPrivateKey := addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey()
+	publicKey := privateKey.GeneratePublicKey()
+	hashForSignature := cryptography.SHA3([]byte("registration update"))
+
+	include := func(updateType RegistrationUpdateType, spendPrivateKey *addresses.PrivateKey, blockHeight uint64) (reg *registration.Registration, err error) {
+		err = db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			dataStorage := data_storage.NewDataStorage(writer)
+			payloadExtra := createTestRegistrationUpdate(t, privateKey, spendPrivateKey, updateType, hashForSignature)
+			if err = payloadExtra.AfterIncludeTxPayload(hashForSignature, nil, 0, config_coins.NATIVE_ASSET_FULL, 0, nil, nil, blockHeight, dataStorage); err != nil {
+				return err
+			}
+			if reg, err = dataStorage.Regs.Get(string(publicKey)); err != nil {
+				return err
+			}
+			err = dataStorage.CommitChanges()
+			return err
+		})
+		return
+	}
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		dataStorage := data_storage.NewDataStorage(writer)
+		if _, err = dataStorage.Regs.CreateNewRegistration(publicKey, false, spendPrivateKey.GeneratePublicKey()); err != nil {
+			return
+		}
+		return dataStorage.CommitChanges()
+	}))
+
+	_, err = include(REGISTRATION_UPDATE_STAKE, nil, 100)
+	assert.EqualError(t, err, "Spend Public Key is not matching")
+
+	reg, err := include(REGISTRATION_UPDATE_STAKE, spendPrivateKey, 100)
+	assert.NoError(t, err)
+	assert.True(t, reg.Staked)
+	cooldown := 100 + config_stake.GetPendingStakeWindow(100)
+	assert.Equal(t, cooldown, reg.StakeCooldown)
+	assert.Equal(t, registration.REGISTRATION_VERSION_COOLDOWN, reg.Version)
+
+	_, err = include(REGISTRATION_UPDATE_STAKE, spendPrivateKey, cooldown)
+	assert.EqualError(t, err, "Registration staked status is already set")
+
+	_, err = include(REGISTRATION_UPDATE_UNSTAKE, spendPrivateKey, cooldown-1)
+	assert.EqualError(t, err, "Registration staked status can't be changed before the cooldown")
+
+	reg, err = include(REGISTRATION_UPDATE_UNSTAKE, spendPrivateKey, cooldown)
+	assert.NoError(t, err)
+	assert.False(t, reg.Staked)
+	assert.Equal(t, cooldown+config_stake.GetPendingStakeWindow(cooldown), reg.StakeCooldown)
+}
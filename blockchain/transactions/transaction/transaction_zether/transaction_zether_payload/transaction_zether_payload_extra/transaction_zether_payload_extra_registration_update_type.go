@@ -0,0 +1,25 @@
+package transaction_zether_payload_extra
+
+type RegistrationUpdateType byte
+
+const (
+	REGISTRATION_UPDATE_STAKE RegistrationUpdateType = iota
+	REGISTRATION_UPDATE_UNSTAKE
+	REGISTRATION_UPDATE_SPEND_PUBLIC_KEY
+	REGISTRATION_UPDATE_REMOVE_SPEND_PUBLIC_KEY
+)
+
+func (t RegistrationUpdateType) String() string {
+	switch t {
+	case REGISTRATION_UPDATE_STAKE:
+		return "REGISTRATION_UPDATE_STAKE"
+	case REGISTRATION_UPDATE_UNSTAKE:
+		return "REGISTRATION_UPDATE_UNSTAKE"
+	case REGISTRATION_UPDATE_SPEND_PUBLIC_KEY:
+		return "REGISTRATION_UPDATE_SPEND_PUBLIC_KEY"
+	case REGISTRATION_UPDATE_REMOVE_SPEND_PUBLIC_KEY:
+		return "REGISTRATION_UPDATE_REMOVE_SPEND_PUBLIC_KEY"
+	default:
+		return "Unknown RegistrationUpdateType"
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"pandora-pay/blockchain/transactions/transaction/transaction_data"
 	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
 	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_extra"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_script"
 	"pandora-pay/config"
 	"pandora-pay/config/config_coins"
@@ -87,6 +88,8 @@ func initialize() {
 			"ripemd":               js.FuncOf(ripemd),
 			"sign":                 js.FuncOf(sign),
 			"verify":               js.FuncOf(verify),
+			"verifyStateProof":     js.FuncOf(verifyStateProof),
+			"verifyTxProof":        js.FuncOf(verifyTxProof),
 		}),
 		"network": js.ValueOf(map[string]interface{}{
 			"networkDisconnect":                      js.FuncOf(networkDisconnect),
@@ -111,6 +114,7 @@ func initialize() {
 			"getNetworkMempool":                      js.FuncOf(getNetworkMempool),
 			"postNetworkMempoolBroadcastTransaction": js.FuncOf(postNetworkMempoolBroadcastTransaction),
 			"getNetworkFeeLiquidity":                 js.FuncOf(getNetworkFeeLiquidity),
+			"getNetworkFeeEstimate":                  js.FuncOf(getNetworkFeeEstimate),
 			"subscribeNetwork":                       js.FuncOf(subscribeNetwork),
 			"unsubscribeNetwork":                     js.FuncOf(unsubscribeNetwork),
 		}),
@@ -138,6 +142,7 @@ func initialize() {
 				"transactionSimple": js.ValueOf(map[string]interface{}{
 					"ScriptType": js.ValueOf(map[string]interface{}{
 						"SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY":     js.ValueOf(uint64(transaction_simple.SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY)),
+						"SCRIPT_CLAIM":                          js.ValueOf(uint64(transaction_simple.SCRIPT_CLAIM)),
 						"SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT": js.ValueOf(uint64(transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT)),
 					}),
 				}),
@@ -151,6 +156,23 @@ func initialize() {
 						"SCRIPT_ASSET_SUPPLY_INCREASE": js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE)),
 						"SCRIPT_PLAIN_ACCOUNT_FUND":    js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_PLAIN_ACCOUNT_FUND)),
 						"SCRIPT_CONDITIONAL_PAYMENT":   js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT)),
+						"SCRIPT_ASSET_SUPPLY_DECREASE": js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE)),
+						"SCRIPT_ASSET_UPDATE":          js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_UPDATE)),
+						"SCRIPT_REGISTRATION_UPDATE":   js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_REGISTRATION_UPDATE)),
+					}),
+					"AssetUpdateType": js.ValueOf(map[string]interface{}{
+						"ASSET_UPDATE_PAUSE":             js.ValueOf(uint64(transaction_zether_payload_extra.ASSET_UPDATE_PAUSE)),
+						"ASSET_UPDATE_UNPAUSE":           js.ValueOf(uint64(transaction_zether_payload_extra.ASSET_UPDATE_UNPAUSE)),
+						"ASSET_UPDATE_FREEZE":            js.ValueOf(uint64(transaction_zether_payload_extra.ASSET_UPDATE_FREEZE)),
+						"ASSET_UPDATE_UPDATE_PUBLIC_KEY": js.ValueOf(uint64(transaction_zether_payload_extra.ASSET_UPDATE_UPDATE_PUBLIC_KEY)),
+						"ASSET_UPDATE_SUPPLY_PUBLIC_KEY": js.ValueOf(uint64(transaction_zether_payload_extra.ASSET_UPDATE_SUPPLY_PUBLIC_KEY)),
+						"ASSET_UPDATE_INFO":              js.ValueOf(uint64(transaction_zether_payload_extra.ASSET_UPDATE_INFO)),
+					}),
+					"RegistrationUpdateType": js.ValueOf(map[string]interface{}{
+						"REGISTRATION_UPDATE_STAKE":                   js.ValueOf(uint64(transaction_zether_payload_extra.REGISTRATION_UPDATE_STAKE)),
+						"REGISTRATION_UPDATE_UNSTAKE":                 js.ValueOf(uint64(transaction_zether_payload_extra.REGISTRATION_UPDATE_UNSTAKE)),
+						"REGISTRATION_UPDATE_SPEND_PUBLIC_KEY":        js.ValueOf(uint64(transaction_zether_payload_extra.REGISTRATION_UPDATE_SPEND_PUBLIC_KEY)),
+						"REGISTRATION_UPDATE_REMOVE_SPEND_PUBLIC_KEY": js.ValueOf(uint64(transaction_zether_payload_extra.REGISTRATION_UPDATE_REMOVE_SPEND_PUBLIC_KEY)),
 					}),
 				}),
 			}),
@@ -177,6 +199,7 @@ func initialize() {
 						"SUBSCRIPTION_ASSET":                js.ValueOf(int(api_types.SUBSCRIPTION_ASSET)),
 						"SUBSCRIPTION_REGISTRATION":         js.ValueOf(int(api_types.SUBSCRIPTION_REGISTRATION)),
 						"SUBSCRIPTION_TRANSACTION":          js.ValueOf(int(api_types.SUBSCRIPTION_TRANSACTION)),
+						"SUBSCRIPTION_CONDITIONAL_PAYMENTS": js.ValueOf(int(api_types.SUBSCRIPTION_CONDITIONAL_PAYMENTS)),
 					}),
 				}),
 			}),
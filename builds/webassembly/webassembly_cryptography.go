@@ -2,9 +2,12 @@ package main
 
 import (
 	"encoding/base64"
+	"pandora-pay/blockchain/blocks/block"
 	"pandora-pay/builds/webassembly/webassembly_utils"
 	"pandora-pay/cryptography"
 	"pandora-pay/cryptography/crypto"
+	"pandora-pay/cryptography/merkle_tree"
+	"pandora-pay/store/state_tree"
 	"syscall/js"
 )
 
@@ -81,3 +84,67 @@ func verify(this js.Value, args []js.Value) interface{} {
 		return out, nil
 	})
 }
+
+// verifyStateProof verifies the proof of a serialized element returned by the api. An empty serialized proves a missing element
+func verifyStateProof(this js.Value, args []js.Value) interface{} {
+	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
+
+		root, err := base64.StdEncoding.DecodeString(args[0].String())
+		if err != nil {
+			return nil, err
+		}
+
+		//the accounts hashmaps are named by the asset
+		name := args[1].String()
+		asset, err := base64.StdEncoding.DecodeString(args[2].String())
+		if err != nil {
+			return nil, err
+		}
+		if len(asset) > 0 {
+			name = name + "_" + string(asset)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(args[3].String())
+		if err != nil {
+			return nil, err
+		}
+
+		serialized, err := base64.StdEncoding.DecodeString(args[4].String())
+		if err != nil {
+			return nil, err
+		}
+		if len(serialized) == 0 {
+			serialized = nil
+		}
+
+		proof := &state_tree.StateProof{}
+		if err = webassembly_utils.UnmarshalBytes(args[5], proof); err != nil {
+			return nil, err
+		}
+
+		return proof.Verify(root, name, key, serialized), nil
+	})
+}
+
+// verifyTxProof verifies that a tx hash is included in the MerkleHash of a block with the txs count and the version returned by tx-proof
+func verifyTxProof(this js.Value, args []js.Value) interface{} {
+	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
+
+		merkleHash, err := base64.StdEncoding.DecodeString(args[0].String())
+		if err != nil {
+			return nil, err
+		}
+
+		txHash, err := base64.StdEncoding.DecodeString(args[1].String())
+		if err != nil {
+			return nil, err
+		}
+
+		proof := &merkle_tree.MerkleProof{}
+		if err = webassembly_utils.UnmarshalBytes(args[2], proof); err != nil {
+			return nil, err
+		}
+
+		return proof.Verify(merkleHash, txHash, uint64(args[3].Int()), uint64(args[4].Int()) == block.BLOCK_VERSION_STATE_HASH), nil
+	})
+}
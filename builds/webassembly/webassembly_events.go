@@ -8,6 +8,7 @@ import (
 	"pandora-pay/app"
 	"pandora-pay/blockchain/data_storage/accounts/account"
 	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
 	"pandora-pay/blockchain/data_storage/registrations/registration"
 	"pandora-pay/builds/webassembly/webassembly_utils"
@@ -131,6 +132,16 @@ func listenNetworkNotifications(this js.Value, args []js.Value) interface{} {
 				case api_types.SUBSCRIPTION_TRANSACTION:
 					object = data.Data
 					extra = &api_types.APISubscriptionNotificationTxExtra{}
+				case api_types.SUBSCRIPTION_CONDITIONAL_PAYMENTS:
+					var condPayment *conditional_payment.ConditionalPayment
+					if data.Data != nil {
+						condPayment = conditional_payment.NewConditionalPayment(nil, 0, 0)
+						if err = condPayment.Deserialize(advanced_buffers.NewBufferReader(data.Data)); err != nil {
+							continue
+						}
+					}
+					object = condPayment
+					extra = &api_types.APISubscriptionNotificationConditionalPaymentExtra{}
 				}
 
 				if err = msgpack.Unmarshal(data.Extra, extra); err != nil {
@@ -148,7 +148,7 @@ func getNetworkAccountsByKeys(this js.Value, args []js.Value) interface{} {
 func getNetworkAccount(this js.Value, args []js.Value) interface{} {
 	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
 
-		request := &api_common.APIAccountRequest{api_types.APIAccountBaseRequest{}, api_types.RETURN_SERIALIZED}
+		request := &api_common.APIAccountRequest{api_types.APIAccountBaseRequest{}, api_types.RETURN_SERIALIZED, false}
 		err := webassembly_utils.UnmarshalBytes(args[0], request)
 		if err != nil {
 			return nil, err
@@ -327,7 +327,7 @@ func getNetworkAsset(this js.Value, args []js.Value) interface{} {
 			return nil, err
 		}
 
-		final, err := connection.SendJSONAwaitAnswer[api_common.APIAssetReply](app.Network.Websockets.GetFirstSocket(), []byte("asset"), &api_common.APIAssetRequest{request.Height, request.Hash, api_types.RETURN_SERIALIZED}, nil, 0)
+		final, err := connection.SendJSONAwaitAnswer[api_common.APIAssetReply](app.Network.Websockets.GetFirstSocket(), []byte("asset"), &api_common.APIAssetRequest{request.Height, request.Hash, api_types.RETURN_SERIALIZED, false}, nil, 0)
 		if err != nil {
 			return nil, err
 		}
@@ -381,6 +381,21 @@ func getNetworkFeeLiquidity(this js.Value, args []js.Value) interface{} {
 	})
 }
 
+func getNetworkFeeEstimate(this js.Value, args []js.Value) interface{} {
+	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
+
+		var asset []byte
+		if args[1].String() != "" {
+			var err error
+			if asset, err = base64.StdEncoding.DecodeString(args[1].String()); err != nil {
+				return nil, err
+			}
+		}
+
+		return webassembly_utils.ConvertToJSONBytes(connection.SendJSONAwaitAnswer[api_common.APIFeeEstimateReply](app.Network.Websockets.GetFirstSocket(), []byte("fee-estimate"), &api_common.APIFeeEstimateRequest{uint64(args[0].Int()), asset}, nil, 0))
+	})
+}
+
 func subscribeNetwork(this js.Value, args []js.Value) interface{} {
 	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
 
@@ -49,6 +49,8 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 			txData.Extra = &wizard.WizardTxSimpleExtraUpdateAssetFeeLiquidity{}
 		case transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT:
 			txData.Extra = &wizard.WizardTxSimpleExtraResolutionConditionalPayment{}
+		case transaction_simple.SCRIPT_CLAIM:
+			txData.Extra = &wizard.WizardTxSimpleExtraClaim{}
 		default:
 			txData.Extra = nil
 			return nil, errors.New("Invalid Tx Simple Script")
@@ -8,7 +8,7 @@ package arguments
 const commands = `PANDORA PAY.
 
 Usage:
-  pandorapay [--pprof] [--network=network] [--debug] [--forging] [--new-devnet] [--run-testnet-script] [--node-name=name] [--tcp-server-port=port] [--tcp-server-address=address] [--tcp-server-auto-tls-certificate] [--tcp-server-tls-cert-file=path] [--tcp-server-tls-key-file=path] [--tor-onion=onion] [--instance=prefix] [--instance-id=id] [--set-genesis=genesis] [--create-new-genesis=args] [--store-wallet-type=type] [--store-chain-type=type] [--consensus=type] [--tcp-max-clients=limit] [--tcp-max-server-sockets=limit] [--seed-wallet-nodes-info=bool] [--wallet-encrypt=args] [--wallet-decrypt=password] [--wallet-remove-encryption] [--wallet-export-shared-staked-address=args] [--wallet-import-secret-mnemonic=mnemonic] [--wallet-import-secret-entropy=entropy] [--hcaptcha-secret=args] [--faucet-testnet-enabled=args] [--delegator-enabled=bool] [--delegator-require-auth=bool] [--delegates-maximum=args] [--auth-users=args] [--light-computations] [--balance-decryptor-disable-init] [--balance-decryptor-table-size=size] [--exit] [--skip-init-sync]
+  pandorapay [--pprof] [--network=network] [--debug] [--gui-type=type] [--log-format=format] [--log-levels=args] [--log-max-size=bytes] [--log-max-files=count] [--forging] [--forging-signer=url] [--forging-signer-auth=args] [--forging-signer-server=address] [--forging-signer-reward-address=address] [--new-devnet] [--run-testnet-script] [--node-name=name] [--tcp-server-port=port] [--tcp-server-address=address] [--tcp-server-auto-tls-certificate] [--tcp-server-tls-cert-file=path] [--tcp-server-tls-key-file=path] [--tor-onion=onion] [--instance=prefix] [--instance-id=id] [--set-genesis=genesis] [--create-new-genesis=args] [--store-wallet-type=type] [--store-chain-type=type] [--consensus=type] [--tcp-max-clients=limit] [--tcp-max-server-sockets=limit] [--seed-wallet-nodes-info=bool] [--prune=blocks] [--sync-headers-first=bool] [--export-snapshot=path] [--import-snapshot=path] [--mempool-expire=seconds] [--mempool-max-txs=count] [--mempool-max-bytes=bytes] [--ready-min-peers=count] [--ready-max-block-age=seconds] [--import-snapshot-hash=hash] [--wallet-encrypt=args] [--wallet-decrypt=password] [--wallet-remove-encryption] [--wallet-export-shared-staked-address=args] [--wallet-import-secret-mnemonic=mnemonic] [--wallet-import-secret-entropy=entropy] [--hcaptcha-secret=args] [--faucet-testnet-enabled=args] [--delegator-enabled=bool] [--delegator-require-auth=bool] [--delegates-maximum=args] [--delegates-inactive-blocks=blocks] [--delegator-pool-enabled=bool] [--delegator-pool-fee=fee] [--delegator-pool-reward-address=address] [--delegator-pool-payout-interval=blocks] [--delegator-pool-payout-minimum=amount] [--delegator-pool-payout-batch=count] [--auth-users=args] [--light-computations] [--balance-decryptor-disable-init] [--balance-decryptor-table-size=size] [--exit] [--skip-init-sync]
   pandorapay -h | --help
   pandorapay -v | --version
 
@@ -23,7 +23,16 @@ Options:
   --store-wallet-type=type                           Set Wallet Store Type. Accepted values: "bolt|bunt|bunt-memory|memory". [default: bolt]
   --store-chain-type=type                            Set Chain Store Type. Accepted values: "bolt|bunt|bunt-memory|memory".  [default: bolt]
   --debug                                            Debug mode enabled (print log message).
+  --gui-type=type                                    GUI type. Accepted values: "interactive|non-interactive". The non-interactive GUI prints the logs on the standard output. [default: interactive]
+  --log-format=format                                Logs format. Accepted values: "text|json". The json format writes a line with time, level, subsystem, msg and fields for every log. [default: text]
+  --log-levels=args                                  Minimum logged level. Accepted levels: "debug|info|warn|error". Argument must be "level,subsystem=level" like "info,network=warn,consensus=debug". Subsystems: "general|network|consensus|mempool|forging|wallet".
+  --log-max-size=bytes                               Rotate the log file when it reaches the size. Use 0 to disable the rotation. [default: 52428800]
+  --log-max-files=count                              Number of rotated log files kept. [default: 5]
   --forging                                          Start Forging blocks.
+  --forging-signer=url                               Forge using the staking keys of a remote signer like "http://127.0.0.1:16500". The private keys are not required on this node.
+  --forging-signer-auth=args                         Credential for the remote signer. Argument must be a JSON "{'user': 'username', 'pass': 'secret'}".
+  --forging-signer-server=address                    Serve the staking keys of the wallet as a remote signer on the loopback address like "127.0.0.1:16500". Requires --auth-users.
+  --forging-signer-reward-address=address            Address allowed to receive the staking rewards signed by --forging-signer-server besides the staked address. Use the --delegator-pool-reward-address of the forging node.
   --node-name=name                                   Change node name.
   --instance=prefix                                  Prefix of the instance [default: 0].
   --instance-id=id                                   Number of forked instance (when you open multiple instances). It should be a string number like "1","2","3","4" etc
@@ -36,7 +45,17 @@ Options:
   --tcp-server-tls-key-file=path                     Load TLS ke file from given path.
   --tor-onion=onion                                  Define your tor onion address to be used.
   --consensus=type                                   Consensus type. Accepted values: "full|wallet|none" [default: full].
-  --seed-wallet-nodes-info=bool                      Storing and serving additional info to wallet nodes. [default: true]. To enable, it requires full node
+  --seed-wallet-nodes-info=bool                      Storing and serving additional info to wallet nodes. [default: true]. To enable, it requires full node. It is disabled by --prune
+  --prune=blocks                                     Delete block bodies and transactions older than the last number of blocks. It requires full node and disables --seed-wallet-nodes-info, as the pruned blocks are required by the wallet nodes.
+  --sync-headers-first=bool                          Download the headers of a fork first and the blocks in parallel from multiple nodes. [default: false]
+  --export-snapshot=path                             Export the current state of the chain into a snapshot file and exit.
+  --import-snapshot=path                             Bootstrap an empty chain store from a snapshot file. It requires --seed-wallet-nodes-info=false.
+  --mempool-expire=seconds                           Pending transactions older than the number of seconds are removed from the mempool and not reloaded after a restart. [default: 86400]
+  --mempool-max-txs=count                            Maximum number of pending transactions. When full, the transactions with the lowest fee per byte are evicted. [default: 20000]
+  --mempool-max-bytes=bytes                          Maximum size in bytes of the pending transactions. [default: 104857600]
+  --ready-min-peers=count                            Minimum connected nodes required by /ready. [default: 1]
+  --ready-max-block-age=seconds                      Maximum age of the last block accepted by /ready. [default: 900]
+  --import-snapshot-hash=hash                        Trusted hash of the last block of the imported snapshot. Its state root must match the snapshot state.
   --wallet-import-secret-mnemonic=mnemonic           Import Wallet from a given Mnemonic. It will delete your existing wallet. 
   --wallet-import-secret-entropy=entropy             Import Wallet from a given Entropy. It will delete your existing wallet.
   --wallet-encrypt=args                              Encrypt wallet. Argument must be "password,difficulty".
@@ -48,6 +67,13 @@ Options:
   --delegator-enabled=bool                           Enable Delegator. Will allow other users to Delegate to the node. Use "true" to enable it
   --delegator-require-auth=bool                      Delegator will require authentication.
   --delegates-maximum=args                           Maximum number of Delegates
+  --delegates-inactive-blocks=blocks                 Delegates which did not notify the node and whose balance did not change for this number of blocks are evicted. Use 0 to disable it [default: 0]
+  --delegator-pool-enabled=bool                      Delegator will share the staking rewards of the delegates using a pool. Use "true" to enable it
+  --delegator-pool-fee=fee                           Operator fee of the delegator pool in basis points [default: 500]
+  --delegator-pool-reward-address=address            Registered and staked wallet address receiving the rewards of the delegator pool. First wallet address by default.
+  --delegator-pool-payout-interval=blocks            Minimum number of blocks between two payouts of the same delegate [default: 100]
+  --delegator-pool-payout-minimum=amount             Minimum accrued amount required for a payout [default: 1]
+  --delegator-pool-payout-batch=count                Maximum number of delegates paid by a single payout transaction [default: 16]
   --auth-users=args                                  Credential for Authenticated Users. Arguments must be a JSON "[{'user': 'username', 'pass': 'secret'}]".
   --light-computations                               Reduces the computations for a testnet node.
   --balance-decryptor-disable-init                   Disable first balance decryptor initialization. 
@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"github.com/blang/semver/v4"
+	"math"
 	"math/big"
 	"math/rand"
 	"pandora-pay/config/config_auth"
@@ -47,22 +48,43 @@ const (
 	NETWORK_TIMESTAMP_DRIFT_MAX_INT int64  = 10
 )
 
+// FORK_DISABLED is the activation height of the hard forks which were not scheduled yet.
+// Scheduling a hard fork on a running network requires a reviewed height agreed with the node operators
+const FORK_DISABLED uint64 = math.MaxUint64
+
+const (
+	MAIN_NET_STATE_ROOT_HEIGHT = FORK_DISABLED
+	TEST_NET_STATE_ROOT_HEIGHT = FORK_DISABLED
+	DEV_NET_STATE_ROOT_HEIGHT  = uint64(0) //devnets start from a new genesis
+)
+
+const (
+	MAIN_NET_RESOLUTION_SIGNATURE_NETWORK_HEIGHT = FORK_DISABLED
+	TEST_NET_RESOLUTION_SIGNATURE_NETWORK_HEIGHT = FORK_DISABLED
+	DEV_NET_RESOLUTION_SIGNATURE_NETWORK_HEIGHT  = uint64(0) //devnets start from a new genesis
+)
+
 const (
 	BLOCK_MAX_SIZE          uint64 = 1024 * 1024
 	BLOCK_TIME              uint64 = 90 //seconds
 	DIFFICULTY_BLOCK_WINDOW uint64 = 10
 	FORK_MAX_UNCLE_ALLOWED  uint64 = 60
 	FORK_MAX_DOWNLOAD       uint64 = 20
+	FORK_MAX_HEADERS        uint64 = 500  //headers downloaded in a single request by the headers first sync
+	FORK_MAX_PARALLEL_CONNS        = 8    //connections used to download the blocks in parallel
+	PRUNE_MAX_BLOCKS_BATCH  uint64 = 1000 //maximum number of blocks pruned in a single update
 )
 
 var (
-	NETWORK_SELECTED                 = MAIN_NET_NETWORK_BYTE
-	NETWORK_SELECTED_BYTE_PREFIX     = MAIN_NET_NETWORK_BYTE_PREFIX
-	NETWORK_SELECTED_NAME            = MAIN_NET_NETWORK_NAME
-	NETWORK_SELECTED_SEEDS           = MAIN_NET_SEED_NODES
-	NETWORK_SELECTED_DELEGATOR_NODES = config_nodes.MAIN_NET_DELEGATOR_NODES
-	WEBSOCKETS_NETWORK_CLIENTS_MAX   = int64(50)
-	WEBSOCKETS_NETWORK_SERVER_MAX    = int64(500)
+	NETWORK_SELECTED                                     = MAIN_NET_NETWORK_BYTE
+	NETWORK_SELECTED_BYTE_PREFIX                         = MAIN_NET_NETWORK_BYTE_PREFIX
+	NETWORK_SELECTED_NAME                                = MAIN_NET_NETWORK_NAME
+	NETWORK_SELECTED_SEEDS                               = MAIN_NET_SEED_NODES
+	NETWORK_SELECTED_DELEGATOR_NODES                     = config_nodes.MAIN_NET_DELEGATOR_NODES
+	NETWORK_SELECTED_STATE_ROOT_HEIGHT                   = MAIN_NET_STATE_ROOT_HEIGHT                   //blocks starting with this height commit the state root
+	NETWORK_SELECTED_RESOLUTION_SIGNATURE_NETWORK_HEIGHT = MAIN_NET_RESOLUTION_SIGNATURE_NETWORK_HEIGHT //blocks starting with this height require the resolution signatures to be bound to the network
+	WEBSOCKETS_NETWORK_CLIENTS_MAX                       = int64(50)
+	WEBSOCKETS_NETWORK_SERVER_MAX                        = int64(500)
 )
 
 const (
@@ -76,9 +98,15 @@ const (
 )
 
 var (
-	API_MEMPOOL_MAX_TRANSACTIONS = 50
-	API_ACCOUNT_MAX_TXS          = uint64(10)
-	API_ASSETS_INFO_MAX_RESULTS  = 10
+	API_MEMPOOL_MAX_TRANSACTIONS                  = 50
+	API_ACCOUNT_MAX_TXS                           = uint64(10)
+	API_ACCOUNT_TXS_HISTORY_MAX_RESULTS           = uint64(50)
+	API_ACCOUNT_TXS_HISTORY_MAX_SCAN              = uint64(1000)
+	API_ASSETS_INFO_MAX_RESULTS                   = 10
+	API_CONDITIONAL_PAYMENTS_MAX_RESULTS          = uint64(10)
+	API_CONDITIONAL_PAYMENTS_EXPIRING_MAX_BLOCKS  = uint64(100)
+	API_CONDITIONAL_PAYMENTS_EXPIRING_MAX_RESULTS = 100
+	API_BLOCK_HEADERS_MAX_RESULTS                 = FORK_MAX_HEADERS
 )
 
 var (
@@ -96,6 +124,22 @@ var (
 var (
 	CONSENSUS              ConsensusType = CONSENSUS_TYPE_FULL
 	SEED_WALLET_NODES_INFO bool
+	PRUNE_BLOCKS           uint64                //0 means the node is not pruned
+	MEMPOOL_TX_EXPIRE      = int64(24 * 60 * 60) //seconds after which the pending mempool transactions expire and are not reloaded after a restart
+	MEMPOOL_MAX_TXS        = uint64(20000)
+	MEMPOOL_MAX_BYTES      = uint64(100 * BLOCK_MAX_SIZE)
+	SYNC_HEADERS_FIRST     = false //download the headers first and the blocks in parallel from multiple connections
+)
+
+var (
+	READY_MIN_PEERS      = int64(1)
+	READY_MAX_BLOCK_AGE  = 10 * BLOCK_TIME //seconds since the last block after which the node is not ready
+	HEALTH_CHECK_TIMEOUT = 5 * time.Second
+)
+
+var (
+	MEMPOOL_NEW_TXS_RATE_LIMIT = float64(10) //transactions per second accepted from a single connection or HTTP remote address
+	MEMPOOL_NEW_TXS_RATE_BURST = float64(50)
 )
 
 var (
@@ -103,6 +147,7 @@ var (
 	NETWORK_WEBSOCKET_ADDRESS_URL_STRING string
 	NETWORK_KNOWN_NODES_LIMIT            int32 = 5000
 	NETWORK_KNOWN_NODES_LIST_RETURN            = 100
+	NETWORK_KNOWN_NODES_SAVE_INTERVAL          = 1 * time.Minute
 )
 
 func InitConfig() (err error) {
@@ -115,12 +160,16 @@ func InitConfig() (err error) {
 		NETWORK_SELECTED_DELEGATOR_NODES = config_nodes.TEST_NET_DELEGATOR_NODES
 		NETWORK_SELECTED_NAME = TEST_NET_NETWORK_NAME
 		NETWORK_SELECTED_BYTE_PREFIX = TEST_NET_NETWORK_BYTE_PREFIX
+		NETWORK_SELECTED_STATE_ROOT_HEIGHT = TEST_NET_STATE_ROOT_HEIGHT
+		NETWORK_SELECTED_RESOLUTION_SIGNATURE_NETWORK_HEIGHT = TEST_NET_RESOLUTION_SIGNATURE_NETWORK_HEIGHT
 	} else if globals.Arguments["--network"] == "devnet" {
 		NETWORK_SELECTED = DEV_NET_NETWORK_BYTE
 		NETWORK_SELECTED_SEEDS = DEV_NET_SEED_NODES
 		NETWORK_SELECTED_DELEGATOR_NODES = config_nodes.DEV_NET_DELEGATOR_NODES
 		NETWORK_SELECTED_NAME = DEV_NET_NETWORK_NAME
 		NETWORK_SELECTED_BYTE_PREFIX = DEV_NET_NETWORK_BYTE_PREFIX
+		NETWORK_SELECTED_STATE_ROOT_HEIGHT = DEV_NET_STATE_ROOT_HEIGHT
+		NETWORK_SELECTED_RESOLUTION_SIGNATURE_NETWORK_HEIGHT = DEV_NET_RESOLUTION_SIGNATURE_NETWORK_HEIGHT
 	} else {
 		return errors.New("selected --network is invalid. Accepted only: mainnet, testnet, devnet")
 	}
@@ -141,11 +190,59 @@ func InitConfig() (err error) {
 		}
 	}
 
+	PRUNE_BLOCKS = 0
+	if globals.Arguments["--prune"] != nil {
+		if PRUNE_BLOCKS, err = strconv.ParseUint(globals.Arguments["--prune"].(string), 10, 64); err != nil {
+			return
+		}
+		if PRUNE_BLOCKS <= FORK_MAX_UNCLE_ALLOWED {
+			return errors.New("--prune must be greater than " + strconv.FormatUint(FORK_MAX_UNCLE_ALLOWED, 10) + " to allow reorgs")
+		}
+	}
+
+	if globals.Arguments["--mempool-expire"] != nil {
+		if MEMPOOL_TX_EXPIRE, err = strconv.ParseInt(globals.Arguments["--mempool-expire"].(string), 10, 64); err != nil {
+			return
+		}
+	}
+
+	if globals.Arguments["--sync-headers-first"] == "true" {
+		SYNC_HEADERS_FIRST = true
+	}
+
+	if globals.Arguments["--ready-min-peers"] != nil {
+		if READY_MIN_PEERS, err = strconv.ParseInt(globals.Arguments["--ready-min-peers"].(string), 10, 64); err != nil {
+			return
+		}
+	}
+
+	if globals.Arguments["--ready-max-block-age"] != nil {
+		if READY_MAX_BLOCK_AGE, err = strconv.ParseUint(globals.Arguments["--ready-max-block-age"].(string), 10, 64); err != nil {
+			return
+		}
+	}
+
+	if globals.Arguments["--mempool-max-txs"] != nil {
+		if MEMPOOL_MAX_TXS, err = strconv.ParseUint(globals.Arguments["--mempool-max-txs"].(string), 10, 64); err != nil {
+			return
+		}
+	}
+
+	if globals.Arguments["--mempool-max-bytes"] != nil {
+		if MEMPOOL_MAX_BYTES, err = strconv.ParseUint(globals.Arguments["--mempool-max-bytes"].(string), 10, 64); err != nil {
+			return
+		}
+		if MEMPOOL_MAX_BYTES < BLOCK_MAX_SIZE {
+			return errors.New("--mempool-max-bytes must be at least " + strconv.FormatUint(BLOCK_MAX_SIZE, 10))
+		}
+	}
+
 	SEED_WALLET_NODES_INFO = false
 	switch globals.Arguments["--consensus"] {
 	case "full":
 		CONSENSUS = CONSENSUS_TYPE_FULL
-		if globals.Arguments["--seed-wallet-nodes-info"] == "true" {
+		//pruned nodes don't have the history required by the wallet nodes, so --prune overrides --seed-wallet-nodes-info which is enabled by default
+		if globals.Arguments["--seed-wallet-nodes-info"] == "true" && PRUNE_BLOCKS == 0 {
 			SEED_WALLET_NODES_INFO = true
 		}
 	case "wallet":
@@ -156,6 +253,10 @@ func InitConfig() (err error) {
 		return errors.New("invalid consensus argument")
 	}
 
+	if PRUNE_BLOCKS > 0 && CONSENSUS != CONSENSUS_TYPE_FULL {
+		return errors.New("--prune requires a full node")
+	}
+
 	if globals.Arguments["--light-computations"] == true {
 		LIGHT_COMPUTATIONS = true
 	}
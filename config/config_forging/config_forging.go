@@ -1,9 +1,20 @@
 package config_forging
 
-import "pandora-pay/config/globals"
+import (
+	"encoding/json"
+	"errors"
+	"pandora-pay/config/config_auth"
+	"pandora-pay/config/globals"
+)
 
 var (
 	FORGING_ENABLED = true
+
+	FORGING_SIGNER        = ""                        //url of the remote signer keeping the staking private keys
+	FORGING_SIGNER_AUTH   = &config_auth.ConfigAuth{} //credential used to authenticate to the remote signer
+	FORGING_SIGNER_SERVER = ""                        //address on which this node serves as a remote signer
+
+	FORGING_SIGNER_REWARD_ADDRESS = "" //address allowed to receive the rewards signed by the remote signer besides the staked address
 )
 
 func InitConfig() (err error) {
@@ -12,5 +23,26 @@ func InitConfig() (err error) {
 		FORGING_ENABLED = false
 	}
 
+	if globals.Arguments["--forging-signer"] != nil {
+		FORGING_SIGNER = globals.Arguments["--forging-signer"].(string)
+	}
+
+	if globals.Arguments["--forging-signer-auth"] != nil {
+		if err = json.Unmarshal([]byte(globals.Arguments["--forging-signer-auth"].(string)), FORGING_SIGNER_AUTH); err != nil {
+			return
+		}
+	}
+
+	if globals.Arguments["--forging-signer-server"] != nil {
+		FORGING_SIGNER_SERVER = globals.Arguments["--forging-signer-server"].(string)
+		if len(config_auth.CONFIG_AUTH_USERS_LIST) == 0 {
+			return errors.New("--forging-signer-server requires --auth-users")
+		}
+	}
+
+	if globals.Arguments["--forging-signer-reward-address"] != nil {
+		FORGING_SIGNER_REWARD_ADDRESS = globals.Arguments["--forging-signer-reward-address"].(string)
+	}
+
 	return
 }
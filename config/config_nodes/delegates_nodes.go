@@ -1,6 +1,8 @@
 package config_nodes
 
 import (
+	"errors"
+	"pandora-pay/config/config_coins"
 	"pandora-pay/config/globals"
 	"strconv"
 )
@@ -63,6 +65,21 @@ var (
 	DELEGATOR_ENABLED      = false
 	DELEGATOR_REQUIRE_AUTH = false
 	DELEGATES_MAXIMUM      = 10000
+
+	DELEGATES_INACTIVE_BLOCKS   = uint64(0) //delegates which didn't notify and whose balance didn't change for this number of blocks are evicted. 0 disables it, as the delegates notify only once
+	DELEGATES_EVICTION_INTERVAL = uint64(100)
+
+	/* DELEGATOR_POOL_ENABLED
+	the staking rewards of the delegates are sent to the pool reward address and shared between all delegates by their stake
+	*/
+	DELEGATOR_POOL_ENABLED               = false
+	DELEGATOR_POOL_FEE                   = uint64(500) //operator fee in basis points, 500 = 5%
+	DELEGATOR_POOL_REWARD_ADDRESS        = ""          //empty to use the first wallet address
+	DELEGATOR_POOL_PAYOUT_INTERVAL       = uint64(100)
+	DELEGATOR_POOL_PAYOUT_MINIMUM        = config_coins.ConvertToUnitsUint64Forced(1)
+	DELEGATOR_POOL_PAYOUT_BATCH          = 16 //delegates paid by a single payout transaction
+	DELEGATOR_POOL_REWARD_CONFIRMATIONS  = uint64(10)
+	DELEGATOR_POOL_PENDING_EXPIRE_BLOCKS = uint64(100)
 )
 
 func InitConfig() (err error) {
@@ -73,6 +90,12 @@ func InitConfig() (err error) {
 		}
 	}
 
+	if globals.Arguments["--delegates-inactive-blocks"] != nil {
+		if DELEGATES_INACTIVE_BLOCKS, err = strconv.ParseUint(globals.Arguments["--delegates-inactive-blocks"].(string), 10, 64); err != nil {
+			return
+		}
+	}
+
 	if globals.Arguments["--delegator-enabled"] == "true" {
 		DELEGATOR_ENABLED = true
 	}
@@ -81,5 +104,50 @@ func InitConfig() (err error) {
 		DELEGATOR_REQUIRE_AUTH = true
 	}
 
+	if globals.Arguments["--delegator-pool-enabled"] == "true" {
+		DELEGATOR_POOL_ENABLED = true
+	}
+
+	if globals.Arguments["--delegator-pool-fee"] != nil {
+		if DELEGATOR_POOL_FEE, err = strconv.ParseUint(globals.Arguments["--delegator-pool-fee"].(string), 10, 64); err != nil {
+			return
+		}
+		if DELEGATOR_POOL_FEE > 10000 {
+			return errors.New("--delegator-pool-fee can not exceed 10000 basis points")
+		}
+	}
+
+	if globals.Arguments["--delegator-pool-reward-address"] != nil {
+		DELEGATOR_POOL_REWARD_ADDRESS = globals.Arguments["--delegator-pool-reward-address"].(string)
+	}
+
+	if globals.Arguments["--delegator-pool-payout-interval"] != nil {
+		if DELEGATOR_POOL_PAYOUT_INTERVAL, err = strconv.ParseUint(globals.Arguments["--delegator-pool-payout-interval"].(string), 10, 64); err != nil {
+			return
+		}
+		if DELEGATOR_POOL_PAYOUT_INTERVAL == 0 {
+			return errors.New("--delegator-pool-payout-interval must be greater than zero")
+		}
+	}
+
+	if globals.Arguments["--delegator-pool-payout-minimum"] != nil {
+		var minimum float64
+		if minimum, err = strconv.ParseFloat(globals.Arguments["--delegator-pool-payout-minimum"].(string), 64); err != nil {
+			return
+		}
+		if DELEGATOR_POOL_PAYOUT_MINIMUM, err = config_coins.ConvertToUnits(minimum); err != nil {
+			return
+		}
+	}
+
+	if globals.Arguments["--delegator-pool-payout-batch"] != nil {
+		if DELEGATOR_POOL_PAYOUT_BATCH, err = strconv.Atoi(globals.Arguments["--delegator-pool-payout-batch"].(string)); err != nil {
+			return
+		}
+		if DELEGATOR_POOL_PAYOUT_BATCH < 1 || DELEGATOR_POOL_PAYOUT_BATCH > 255 {
+			return errors.New("--delegator-pool-payout-batch must be between 1 and 255")
+		}
+	}
+
 	return nil
 }
@@ -303,6 +303,20 @@ func (e *G1) DecodeCompressed(encoding []byte) error {
 	return nil
 }
 
+// Double sets e to 2*a and then returns e.
+func (e *G1) Double(a *G1) *G1 {
+	if e.p == nil {
+		e.p = &curvePoint{}
+	}
+	e.p.Double(a.p)
+	return e
+}
+
+// IsInfinity returns true if e is the point at infinity
+func (e *G1) IsInfinity() bool {
+	return e.p == nil || e.p.IsInfinity()
+}
+
 func (e *G1) EncodeUncompressed() []byte {
 	return e.Marshal()
 }
@@ -0,0 +1,91 @@
+package crypto
+
+import (
+	"math/big"
+	"math/bits"
+	"pandora-pay/cryptography/bn256"
+)
+
+// MultiScalarMult computes the sum of points[i]*scalars[i] using the Pippenger bucket method
+func MultiScalarMult(points []*bn256.G1, scalars []*big.Int) *bn256.G1 {
+
+	result := new(bn256.G1).ScalarBaseMult(new(big.Int)) // set it to zero
+	if len(points) == 0 {
+		return result
+	}
+
+	reduced := make([]*big.Int, len(scalars))
+	maxBits := 0
+	for i, scalar := range scalars {
+		if scalar.Sign() < 0 || scalar.Cmp(bn256.Order) >= 0 {
+			reduced[i] = new(big.Int).Mod(scalar, bn256.Order)
+		} else {
+			reduced[i] = scalar
+		}
+		if reduced[i].BitLen() > maxBits {
+			maxBits = reduced[i].BitLen()
+		}
+	}
+
+	//window size c minimizes windows * (points + 2^c)
+	c := bits.Len(uint(len(points))) - 2
+	if c < 2 {
+		c = 2
+	} else if c > 16 {
+		c = 16
+	}
+
+	windows := (maxBits + c - 1) / c
+	buckets := make([]*bn256.G1, 1<<c)
+
+	for w := windows - 1; w >= 0; w-- {
+
+		for j := 0; j < c; j++ {
+			result.Double(result)
+		}
+
+		for j := range buckets {
+			buckets[j] = nil
+		}
+
+		for i, scalar := range reduced {
+			digit := 0
+			for j := 0; j < c; j++ {
+				digit |= int(scalar.Bit(w*c+j)) << j
+			}
+			if digit == 0 {
+				continue
+			}
+			if buckets[digit] == nil {
+				buckets[digit] = new(bn256.G1).Set(points[i])
+			} else {
+				buckets[digit].Add(buckets[digit], points[i])
+			}
+		}
+
+		//sum of j*buckets[j] computed with running sums
+		var sum, windowSum *bn256.G1
+		for j := len(buckets) - 1; j > 0; j-- {
+			if buckets[j] != nil {
+				if sum == nil {
+					sum = buckets[j]
+				} else {
+					sum.Add(sum, buckets[j])
+				}
+			}
+			if sum != nil {
+				if windowSum == nil {
+					windowSum = new(bn256.G1).Set(sum)
+				} else {
+					windowSum.Add(windowSum, sum)
+				}
+			}
+		}
+
+		if windowSum != nil {
+			result.Add(result, windowSum)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,30 @@
+package crypto
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/cryptography/bn256"
+	"testing"
+)
+
+func TestMultiScalarMult(t *testing.T) {
+
+	for _, count := range []int{0, 1, 2, 7, 64, 300} {
+
+		points := make([]*bn256.G1, count)
+		scalars := make([]*big.Int, count)
+
+		expected := new(bn256.G1).ScalarBaseMult(new(big.Int))
+		for i := range points {
+			points[i] = new(bn256.G1).ScalarBaseMult(RandomScalar())
+			scalars[i] = RandomScalar()
+			if i%5 == 0 {
+				scalars[i].Neg(scalars[i])
+			}
+			expected.Add(expected, new(bn256.G1).ScalarMult(points[i], new(big.Int).Mod(scalars[i], bn256.Order)))
+		}
+
+		assert.Equal(t, expected.String(), MultiScalarMult(points, scalars).String())
+	}
+
+}
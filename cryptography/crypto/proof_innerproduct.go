@@ -186,13 +186,18 @@ func (ip *InnerProduct) generateInnerProductProofNew(p *PedersenVectorCommitment
 
 }
 
-func (ip *InnerProduct) Verify(hs []*bn256.G1, u, P *bn256.G1, salt *big.Int, gp *GeneratorParams) bool {
+// addCheck adds to check the points which sum to zero only if the inner product proof is valid
+// the bases are gp.Gs and gp.Hs multiplied by hsExponents, and u is gp.H multiplied by uExponent
+func (ip *InnerProduct) addCheck(check *proofCheck, hsExponents []*big.Int, uExponent *big.Int, salt *big.Int, gp *GeneratorParams) bool {
 	log_n := uint(len(ip.ls))
 
 	if len(ip.ls) != len(ip.rs) { // length must be same
 		return false
 	}
 	n := uint(math.Pow(2, float64(log_n)))
+	if n > uint(gp.Gs.Length()) || n > uint(len(hsExponents)) {
+		return false
+	}
 
 	o := salt
 	var challenges []*big.Int
@@ -206,12 +211,12 @@ func (ip *InnerProduct) Verify(hs []*bn256.G1, u, P *bn256.G1, salt *big.Int, gp
 		challenges = append(challenges, o)
 
 		o_inv := new(big.Int).ModInverse(o, bn256.Order)
+		if o_inv == nil {
+			return false
+		}
 
-		PPrimeL := new(bn256.G1).ScalarMult(ip.ls[i], new(big.Int).Mod(new(big.Int).Mul(o, o), bn256.Order))         //L * (x*x)
-		PPrimeR := new(bn256.G1).ScalarMult(ip.rs[i], new(big.Int).Mod(new(big.Int).Mul(o_inv, o_inv), bn256.Order)) //L * (x*x)
-
-		PPrime := new(bn256.G1).Add(PPrimeL, PPrimeR)
-		P = new(bn256.G1).Add(PPrime, P)
+		check.add(ip.ls[i], new(big.Int).Mul(o, o))         //L * (x*x)
+		check.add(ip.rs[i], new(big.Int).Mul(o_inv, o_inv)) //R * (xinv*xinv)
 	}
 
 	exp := new(big.Int).SetUint64(1)
@@ -237,35 +242,12 @@ func (ip *InnerProduct) Verify(hs []*bn256.G1, u, P *bn256.G1, salt *big.Int, gp
 		}
 	}
 
-	var zeroes [64]byte
-	gtemp := new(bn256.G1) // obtain zero element, this should be static and
-	htemp := new(bn256.G1) // obtain zero element, this should be static and
-
-	if _, err := gtemp.Unmarshal(zeroes[:]); err != nil {
-		return false
-	}
-
-	if _, err := htemp.Unmarshal(zeroes[:]); err != nil {
-		return false
-	}
-
+	// P_calculated = gs^(exponents * a) + hs^(reversed exponents * b) + u^(a * b) must be equal to P
 	for i := uint(0); i < n; i++ {
-		gtemp = new(bn256.G1).Add(gtemp, new(bn256.G1).ScalarMult(gp.Gs.vector[i], exponents[i]))
-		htemp = new(bn256.G1).Add(htemp, new(bn256.G1).ScalarMult(hs[i], exponents[n-1-i]))
-	}
-	gtemp = new(bn256.G1).ScalarMult(gtemp, ip.a)
-	htemp = new(bn256.G1).ScalarMult(htemp, ip.b)
-	utemp := new(bn256.G1).ScalarMult(u, new(big.Int).Mod(new(big.Int).Mul(ip.a, ip.b), bn256.Order))
-
-	P_calculated := new(bn256.G1).Add(gtemp, htemp)
-	P_calculated = new(bn256.G1).Add(P_calculated, utemp)
-
-	// fmt.Printf("P %s\n",P.String())
-	// fmt.Printf("P_calculated %s\n",P_calculated.String())
-
-	if P_calculated.String() != P.String() { // need something better here
-		return false
+		check.add(gp.Gs.vector[i], new(big.Int).Neg(new(big.Int).Mul(exponents[i], ip.a)))
+		check.add(gp.Hs.vector[i], new(big.Int).Neg(new(big.Int).Mul(new(big.Int).Mul(exponents[n-1-i], ip.b), hsExponents[i])))
 	}
+	check.add(gp.H, new(big.Int).Neg(new(big.Int).Mul(new(big.Int).Mul(ip.a, ip.b), uExponent)))
 
 	return true
 }
@@ -59,6 +59,15 @@ var gparams = NewGeneratorParams(128)
 // verify proof
 // first generate supporting structures
 func (proof *Proof) Verify(assetId []byte, assetIndex int, chainHash []byte, s *Statement, txid []byte, extra_value uint64) bool {
+	batch := NewProofsBatch()
+	if !proof.VerifyBatch(assetId, assetIndex, chainHash, s, txid, extra_value, batch) {
+		return false
+	}
+	return batch.Verify()
+}
+
+// VerifyBatch verifies the proof, except the final checks which are added to the batch. The proof is valid only if the batch is also valid
+func (proof *Proof) VerifyBatch(assetId []byte, assetIndex int, chainHash []byte, s *Statement, txid []byte, extra_value uint64, batch *ProofsBatch) bool {
 
 	var anonsupport AnonSupport
 	var protsupport ProtocolSupport
@@ -102,27 +111,27 @@ func (proof *Proof) Verify(assetId []byte, assetIndex int, chainHash []byte, s *
 		return false
 	}
 
-	anonsupport.temp = new(bn256.G1)
+	if N > len(s.CLn) || len(s.CLn) != len(s.CRn) || 2*m+2 > gparams.Hs.Length() {
+		return false
+	}
+
 	var zeroes [64]byte
-	anonsupport.temp.Unmarshal(zeroes[:])
 
+	// check whether we successfuly recover B^w * A
+	// B^w * A - temp - H^z_A must be zero, where temp is the commitment to f
+	recoverCheck := &proofCheck{}
+	recoverCheck.add(proof.B, anonsupport.w)
+	recoverCheck.add(proof.A, big.NewInt(1))
 	for k := 0; k < 2*m; k++ {
-		anonsupport.temp = new(bn256.G1).Add(anonsupport.temp, new(bn256.G1).ScalarMult(gparams.Gs.vector[k], anonsupport.f[k][1]))
+		recoverCheck.add(gparams.Gs.vector[k], new(big.Int).Neg(anonsupport.f[k][1]))
 
 		t := new(big.Int).Mod(new(big.Int).Mul(anonsupport.f[k][1], anonsupport.f[k][0]), bn256.Order)
 
-		anonsupport.temp = new(bn256.G1).Add(anonsupport.temp, new(bn256.G1).ScalarMult(gparams.Hs.vector[k], t))
+		recoverCheck.add(gparams.Hs.vector[k], new(big.Int).Neg(t))
 	}
-
-	t0 := new(bn256.G1).ScalarMult(gparams.Hs.vector[0+2*m], new(big.Int).Mod(new(big.Int).Mul(anonsupport.f[0][1], anonsupport.f[m][1]), bn256.Order))
-	t1 := new(bn256.G1).ScalarMult(gparams.Hs.vector[1+2*m], new(big.Int).Mod(new(big.Int).Mul(anonsupport.f[0][0], anonsupport.f[m][0]), bn256.Order))
-
-	anonsupport.temp = new(bn256.G1).Add(anonsupport.temp, t0)
-	anonsupport.temp = new(bn256.G1).Add(anonsupport.temp, t1)
-
-	// check whether we successfuly recover B^w * A
-	stored := new(bn256.G1).Add(new(bn256.G1).ScalarMult(proof.B, anonsupport.w), proof.A)
-	computed := new(bn256.G1).Add(anonsupport.temp, new(bn256.G1).ScalarMult(gparams.H, proof.z_A))
+	recoverCheck.add(gparams.Hs.vector[0+2*m], new(big.Int).Neg(new(big.Int).Mul(anonsupport.f[0][1], anonsupport.f[m][1])))
+	recoverCheck.add(gparams.Hs.vector[1+2*m], new(big.Int).Neg(new(big.Int).Mul(anonsupport.f[0][0], anonsupport.f[m][0])))
+	recoverCheck.add(gparams.H, new(big.Int).Neg(proof.z_A))
 
 	//	for i := range proof.f.vector {
 	//		klog.V(2).Infof("proof.f %d %s\n", i, proof.f.vector[i].Text(16))
@@ -133,14 +142,6 @@ func (proof *Proof) Verify(assetId []byte, assetIndex int, chainHash []byte, s *
 	//	klog.V(2).Infof("proof.A %s\n", proof.A.String())
 	//	klog.V(2).Infof("gparams.H %s\n", gparams.H.String())
 
-	//	klog.V(2).Infof("stored %s\n", stored.String())
-	//	klog.V(2).Infof("computed %s\n", computed.String())
-
-	if stored.String() != computed.String() { // if failed bail out
-		//		klog.Warning("Recover key failed B^w * A")
-		return false
-	}
-
 	anonsupport.r = assemblepolynomials(anonsupport.f)
 
 	//	for i := 0; i < len(anonsupport.r); i++ {
@@ -150,14 +151,12 @@ func (proof *Proof) Verify(assetId []byte, assetIndex int, chainHash []byte, s *
 	//		klog.V(2).Infof("proof.q %d %s\n", i, anonsupport.r[i][1].Text(16))
 	//	}
 
-	anonsupport.CLnR = new(bn256.G1)
-	anonsupport.CRnR = new(bn256.G1)
-	anonsupport.CLnR.Unmarshal(zeroes[:])
-	anonsupport.CRnR.Unmarshal(zeroes[:])
+	rExponents := make([]*big.Int, N)
 	for i := 0; i < N; i++ {
-		anonsupport.CLnR = new(bn256.G1).Add(anonsupport.CLnR, new(bn256.G1).ScalarMult(s.CLn[i], anonsupport.r[i][0]))
-		anonsupport.CRnR = new(bn256.G1).Add(anonsupport.CRnR, new(bn256.G1).ScalarMult(s.CRn[i], anonsupport.r[i][0]))
+		rExponents[i] = anonsupport.r[i][0]
 	}
+	anonsupport.CLnR = MultiScalarMult(s.CLn[:N], rExponents)
+	anonsupport.CRnR = MultiScalarMult(s.CRn[:N], rExponents)
 
 	//	klog.V(2).Infof("qCrnR %s\n", anonsupport.CRnR.String())
 
@@ -194,19 +193,21 @@ func (proof *Proof) Verify(assetId []byte, assetIndex int, chainHash []byte, s *
 
 	anonsupport.vPow = new(big.Int).SetUint64(1)
 
-	anonsupport.C_XR = new(bn256.G1)
-	anonsupport.y_XR = new(bn256.G1)
-	anonsupport.C_XR.Unmarshal(zeroes[:])
-	anonsupport.y_XR.Unmarshal(zeroes[:])
+	CRPoints := make([]*bn256.G1, N)
+	yRPoints := make([]*bn256.G1, N)
+	vExponents := make([]*big.Int, N)
 	for i := 0; i < N; i++ {
-		anonsupport.C_XR.Add(new(bn256.G1).Set(anonsupport.C_XR), new(bn256.G1).ScalarMult(anonsupport.CR[i/2][i%2], anonsupport.vPow))
-		anonsupport.y_XR.Add(new(bn256.G1).Set(anonsupport.y_XR), new(bn256.G1).ScalarMult(anonsupport.yR[i/2][i%2], anonsupport.vPow))
+		CRPoints[i] = anonsupport.CR[i/2][i%2]
+		yRPoints[i] = anonsupport.yR[i/2][i%2]
+		vExponents[i] = anonsupport.vPow
 
 		if i > 0 {
 			anonsupport.vPow = new(big.Int).Mod(new(big.Int).Mul(anonsupport.vPow, anonsupport.v), bn256.Order)
 			//			klog.V(2).Infof("vPow %s\n", anonsupport.vPow.Text(16))
 		}
 	}
+	anonsupport.C_XR = MultiScalarMult(CRPoints, vExponents)
+	anonsupport.y_XR = MultiScalarMult(yRPoints, vExponents)
 
 	//	klog.V(2).Infof("vPow %s\n", anonsupport.vPow.Text(16))
 	//	klog.V(2).Infof("v %s\n", anonsupport.v.Text(16))
@@ -387,38 +388,41 @@ func (proof *Proof) Verify(assetId []byte, assetIndex int, chainHash []byte, s *
 
 	o := reducedhash(ConvertBigIntToByte(proof.c))
 
-	u_x := new(bn256.G1).ScalarMult(gparams.H, o)
+	yInv := new(big.Int).ModInverse(protsupport.y, bn256.Order)
+	if yInv == nil {
+		return false
+	}
 
-	var hPrimes []*bn256.G1
-	hPrimeSum := new(bn256.G1)
+	// P = BA + BS^x + GSUM^-z + hPrimeSum + H^-mu + u_x^that, where hPrimes are Hs^(1/ys) and u_x is H^o
+	innerProductCheck := &proofCheck{}
+	innerProductCheck.add(proof.BA, big.NewInt(1))
+	innerProductCheck.add(proof.BS, x)
+	innerProductCheck.add(gparams.GSUM, new(big.Int).Neg(protsupport.z))
 
-	hPrimeSum.Unmarshal(zeroes[:])
+	ysInv := make([]*big.Int, 128)
+	ysInv[0] = big.NewInt(1)
 	for i := 0; i < 128; i++ {
-		hPrimes = append(hPrimes, new(bn256.G1).ScalarMult(gparams.Hs.vector[i], new(big.Int).ModInverse(protsupport.ys[i], bn256.Order)))
-
-		//		klog.V(2).Infof("hPrimes %d %s\n", i, hPrimes[i].String())
+		if i > 0 {
+			ysInv[i] = new(big.Int).Mod(new(big.Int).Mul(ysInv[i-1], yInv), bn256.Order)
+		}
 
 		tmp := new(big.Int).Mod(new(big.Int).Mul(protsupport.ys[i], protsupport.z), bn256.Order)
 		tmp = new(big.Int).Mod(new(big.Int).Add(tmp, protsupport.twoTimesZSquared[i]), bn256.Order)
 
-		hPrimeSum = new(bn256.G1).Add(hPrimeSum, new(bn256.G1).ScalarMult(hPrimes[i], tmp))
-
+		innerProductCheck.add(gparams.Hs.vector[i], new(big.Int).Mul(tmp, ysInv[i]))
 	}
 
-	P := new(bn256.G1).Add(proof.BA, new(bn256.G1).ScalarMult(proof.BS, x))
-	P = new(bn256.G1).Add(P, new(bn256.G1).ScalarMult(gparams.GSUM, new(big.Int).Mod(new(big.Int).Neg(protsupport.z), bn256.Order)))
-	P = new(bn256.G1).Add(P, hPrimeSum)
-
-	P = new(bn256.G1).Add(P, new(bn256.G1).ScalarMult(gparams.H, new(big.Int).Mod(new(big.Int).Neg(proof.mu), bn256.Order)))
-	P = new(bn256.G1).Add(P, new(bn256.G1).ScalarMult(u_x, new(big.Int).Mod(new(big.Int).Set(proof.that), bn256.Order)))
+	innerProductCheck.add(gparams.H, new(big.Int).Sub(new(big.Int).Mul(o, proof.that), proof.mu))
 
 	//	klog.V(2).Infof("P  %s\n", P.String())
 
-	if !proof.ip.Verify(hPrimes, u_x, P, o, gparams) {
+	if !proof.ip.addCheck(innerProductCheck, ysInv, o, o, gparams) {
 		//		klog.Warning("inner proof failed")
 		return false
 	}
 
+	batch.add(recoverCheck, innerProductCheck)
+
 	// klog.V(2).Infof("proof %s\n", proof.String())
 	//	klog.V(2).Infof("Proof successful verified\n")
 
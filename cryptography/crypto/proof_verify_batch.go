@@ -0,0 +1,67 @@
+package crypto
+
+import (
+	"math/big"
+	"pandora-pay/cryptography/bn256"
+	"sync"
+)
+
+// ProofsBatch accumulates the final checks of many proofs, each multiplied by a random weight, to verify them using a single multi scalar multiplication
+type ProofsBatch struct {
+	points  []*bn256.G1
+	scalars []*big.Int
+	indexes map[*bn256.G1]int //the generators are shared by all proofs
+	lock    *sync.Mutex
+}
+
+// proofCheck is a sum of points that must be zero
+type proofCheck struct {
+	points  []*bn256.G1
+	scalars []*big.Int
+}
+
+func (check *proofCheck) add(point *bn256.G1, scalar *big.Int) {
+	check.points = append(check.points, point)
+	check.scalars = append(check.scalars, scalar)
+}
+
+func (batch *ProofsBatch) add(checks ...*proofCheck) {
+
+	weights := make([]*big.Int, len(checks))
+	for i := range checks {
+		weights[i] = RandomScalar()
+	}
+
+	batch.lock.Lock()
+	defer batch.lock.Unlock()
+
+	for i, check := range checks {
+		for j, point := range check.points {
+			scalar := new(big.Int).Mul(check.scalars[j], weights[i])
+			if index, ok := batch.indexes[point]; ok {
+				batch.scalars[index] = new(big.Int).Mod(scalar.Add(scalar, batch.scalars[index]), bn256.Order)
+				continue
+			}
+			batch.indexes[point] = len(batch.points)
+			batch.points = append(batch.points, point)
+			batch.scalars = append(batch.scalars, scalar.Mod(scalar, bn256.Order))
+		}
+	}
+}
+
+// Verify returns true if all the proofs added are valid
+func (batch *ProofsBatch) Verify() bool {
+	batch.lock.Lock()
+	defer batch.lock.Unlock()
+
+	return MultiScalarMult(batch.points, batch.scalars).IsInfinity()
+}
+
+func NewProofsBatch() *ProofsBatch {
+	return &ProofsBatch{
+		nil,
+		nil,
+		make(map[*bn256.G1]int),
+		&sync.Mutex{},
+	}
+}
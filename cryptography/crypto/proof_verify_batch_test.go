@@ -0,0 +1,45 @@
+package crypto
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/cryptography/bn256"
+	"testing"
+)
+
+// newTestCheck returns a check of shared*a + point*b - shared*a - point*b, which is zero unless tampered
+func newTestCheck(shared *bn256.G1, tampered bool) *proofCheck {
+
+	point := new(bn256.G1).ScalarBaseMult(RandomScalar())
+	a, b := RandomScalar(), RandomScalar()
+
+	check := &proofCheck{}
+	check.add(shared, a)
+	check.add(point, b)
+	check.add(shared, new(big.Int).Neg(a))
+	if tampered {
+		b = new(big.Int).Add(b, big.NewInt(1))
+	}
+	check.add(point, new(big.Int).Neg(b))
+	return check
+}
+
+func TestProofsBatch_Verify(t *testing.T) {
+
+	shared := new(bn256.G1).ScalarBaseMult(RandomScalar())
+
+	batch := NewProofsBatch()
+	assert.True(t, batch.Verify(), "an empty batch is valid")
+
+	for i := 0; i < 5; i++ {
+		batch.add(newTestCheck(shared, false), newTestCheck(shared, false))
+	}
+	assert.True(t, batch.Verify())
+	assert.Equal(t, 1+5*2, len(batch.points), "the shared points are added only once")
+
+	batch.add(newTestCheck(shared, true))
+	assert.False(t, batch.Verify(), "a single tampered check fails the batch")
+
+	batch.add(newTestCheck(shared, false))
+	assert.False(t, batch.Verify())
+}
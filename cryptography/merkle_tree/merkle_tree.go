@@ -7,7 +7,7 @@ import (
 
 /**
 Fast Merkle Tree Construction
-It can't generate efficient proofs
+Proofs are generated from the full tree
 */
 
 func roundNextPowerOfTwo(number int) int {
@@ -20,13 +20,27 @@ func roundNextPowerOfTwo(number int) int {
 	return 1 << exp
 }
 
-func hashMerkleNode(left []byte, right []byte) []byte {
-	// Concatenate the left and right nodes.
-	hash := append(left, right...)
+// prefixes of the trees with domain separation. A leaf can't be presented as an internal node and vice versa
+const (
+	merkleLeafPrefix = byte(0)
+	merkleNodePrefix = byte(1)
+)
+
+func hashMerkleLeaf(hash []byte) []byte {
+	return cryptography.SHA3(append([]byte{merkleLeafPrefix}, hash...))
+}
+
+func hashMerkleNode(left []byte, right []byte, domainSeparation bool) []byte {
+	// Concatenate the left and right nodes. A new slice is used to not alter the left node
+	hash := make([]byte, 0, 1+len(left)+len(right))
+	if domainSeparation {
+		hash = append(hash, merkleNodePrefix)
+	}
+	hash = append(append(hash, left...), right...)
 	return cryptography.SHA3(hash)
 }
 
-func buildMerkleTree(hashes [][]byte) [][]byte {
+func buildMerkleTree(hashes [][]byte, domainSeparation bool) [][]byte {
 
 	if len(hashes) == 0 {
 		return [][]byte{}
@@ -37,7 +51,11 @@ func buildMerkleTree(hashes [][]byte) [][]byte {
 	nodes := make([][]byte, arraySize)
 
 	for i := range hashes {
-		nodes[i] = hashes[i]
+		if domainSeparation {
+			nodes[i] = hashMerkleLeaf(hashes[i])
+		} else {
+			nodes[i] = hashes[i]
+		}
 	}
 
 	offset := roundedNextPowerOfTwo
@@ -49,11 +67,11 @@ func buildMerkleTree(hashes [][]byte) [][]byte {
 			nodes[offset] = nil
 
 		case nodes[i+1] == nil:
-			newHash := hashMerkleNode(nodes[i], nodes[i])
+			newHash := hashMerkleNode(nodes[i], nodes[i], domainSeparation)
 			nodes[offset] = newHash
 
 		default:
-			newHash := hashMerkleNode(nodes[i], nodes[i+1])
+			newHash := hashMerkleNode(nodes[i], nodes[i+1], domainSeparation)
 			nodes[offset] = newHash
 		}
 		offset++
@@ -61,7 +79,8 @@ func buildMerkleTree(hashes [][]byte) [][]byte {
 	return nodes
 }
 
-func MerkleRoot(hashes [][]byte) []byte {
-	merkles := buildMerkleTree(hashes)
+// MerkleRoot computes the root of the hashes. The trees without domain separation are kept only for the blocks created before it
+func MerkleRoot(hashes [][]byte, domainSeparation bool) []byte {
+	merkles := buildMerkleTree(hashes, domainSeparation)
 	return merkles[len(merkles)-1] //return last element
 }
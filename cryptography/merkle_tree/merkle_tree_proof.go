@@ -0,0 +1,82 @@
+package merkle_tree
+
+import (
+	"bytes"
+	"errors"
+	"math/bits"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+)
+
+// MerkleProof proves the inclusion of a hash in a merkle root
+type MerkleProof struct {
+	Index    uint64           `json:"index" msgpack:"index"`       //position of the hash
+	Siblings []helpers.Base64 `json:"siblings" msgpack:"siblings"` //starting from the leaves. A missing right node is replaced by the left one
+}
+
+func MerkleProofGenerate(hashes [][]byte, index int, domainSeparation bool) (*MerkleProof, error) {
+
+	if index < 0 || index >= len(hashes) {
+		return nil, errors.New("Index is invalid")
+	}
+
+	nodes := buildMerkleTree(hashes, domainSeparation)
+
+	proof := &MerkleProof{
+		uint64(index),
+		[]helpers.Base64{},
+	}
+
+	offset := 0
+	for width := roundNextPowerOfTwo(len(hashes)); width > 1; width /= 2 {
+
+		sibling := nodes[offset+(index^1)]
+		if sibling == nil {
+			sibling = nodes[offset+index]
+		}
+		proof.Siblings = append(proof.Siblings, sibling)
+
+		offset += width
+		index /= 2
+	}
+
+	return proof, nil
+}
+
+// ComputeRoot returns the root of a tree of count hashes. The index must be lower than count, otherwise the duplicated last hash
+// could be proven at a position after it
+func (proof *MerkleProof) ComputeRoot(hash []byte, count uint64, domainSeparation bool) ([]byte, error) {
+
+	if proof.Index >= count {
+		return nil, errors.New("Index is invalid")
+	}
+	if len(proof.Siblings) != bits.Len64(count-1) {
+		return nil, errors.New("Invalid proof siblings")
+	}
+
+	current := hash
+	if domainSeparation {
+		current = hashMerkleLeaf(hash)
+	}
+	index := proof.Index
+	for _, sibling := range proof.Siblings {
+
+		if len(sibling) != cryptography.HashSize {
+			return nil, errors.New("Invalid proof siblings")
+		}
+
+		if index&1 == 0 {
+			current = hashMerkleNode(current, sibling, domainSeparation)
+		} else {
+			current = hashMerkleNode(sibling, current, domainSeparation)
+		}
+		index /= 2
+	}
+
+	return current, nil
+}
+
+func (proof *MerkleProof) Verify(root, hash []byte, count uint64, domainSeparation bool) bool {
+	computed, err := proof.ComputeRoot(hash, count, domainSeparation)
+	return err == nil && bytes.Equal(computed, root)
+}
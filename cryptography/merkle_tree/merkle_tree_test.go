@@ -3,6 +3,7 @@ package merkle_tree
 import (
 	"github.com/stretchr/testify/assert"
 	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
 	"testing"
 )
 
@@ -12,9 +13,84 @@ func TestMerkleRoot(t *testing.T) {
 	hashes = append(hashes, cryptography.RandomHash())
 	hashes = append(hashes, cryptography.RandomHash())
 
-	root := MerkleRoot(hashes)
+	root := MerkleRoot(hashes, false)
 
-	hash := hashMerkleNode(hashes[0], hashes[1])
+	hash := hashMerkleNode(hashes[0], hashes[1], false)
 	assert.Equal(t, root, hash, "Merkle Tree Hashes are invalid")
 
+	root = MerkleRoot(hashes, true)
+
+	hash = cryptography.SHA3(append(append([]byte{merkleNodePrefix}, hashMerkleLeaf(hashes[0])...), hashMerkleLeaf(hashes[1])...))
+	assert.Equal(t, root, hash, "Merkle Tree Hashes with domain separation are invalid")
+}
+
+func TestMerkleProof(t *testing.T) {
+
+	for _, domainSeparation := range []bool{false, true} {
+		for count := 1; count <= 9; count++ {
+
+			hashes := make([][]byte, count)
+			for i := range hashes {
+				hashes[i] = cryptography.RandomHash()
+			}
+
+			root := MerkleRoot(hashes, domainSeparation)
+
+			for i := range hashes {
+				proof, err := MerkleProofGenerate(hashes, i, domainSeparation)
+				assert.Nil(t, err)
+				assert.True(t, proof.Verify(root, hashes[i], uint64(count), domainSeparation), "Merkle Proof is invalid")
+				assert.False(t, proof.Verify(root, cryptography.RandomHash(), uint64(count), domainSeparation), "Merkle Proof verified a different hash")
+				assert.False(t, proof.Verify(root, hashes[i], uint64(count), !domainSeparation), "Merkle Proof verified with a different domain separation")
+			}
+
+			_, err := MerkleProofGenerate(hashes, count, domainSeparation)
+			assert.NotNil(t, err)
+		}
+	}
+
+}
+
+func TestMerkleProofIndexAfterCount(t *testing.T) {
+
+	for _, domainSeparation := range []bool{false, true} {
+
+		//the last node is duplicated when the count is odd
+		hashes := [][]byte{cryptography.RandomHash(), cryptography.RandomHash(), cryptography.RandomHash()}
+		root := MerkleRoot(hashes, domainSeparation)
+
+		proof, err := MerkleProofGenerate(hashes, 2, domainSeparation)
+		assert.NoError(t, err)
+
+		proof.Index = 3
+		computed, err := proof.ComputeRoot(hashes[2], 4, domainSeparation)
+		assert.NoError(t, err)
+		assert.Equal(t, root, computed, "the duplicated hash computes the same root")
+
+		assert.False(t, proof.Verify(root, hashes[2], 3, domainSeparation), "the index after the count is rejected")
+		_, err = proof.ComputeRoot(hashes[2], 3, domainSeparation)
+		assert.EqualError(t, err, "Index is invalid")
+	}
+}
+
+func TestMerkleProofInternalNode(t *testing.T) {
+
+	hashes := [][]byte{cryptography.RandomHash(), cryptography.RandomHash(), cryptography.RandomHash(), cryptography.RandomHash()}
+
+	for _, test := range []struct {
+		name             string
+		domainSeparation bool
+		verified         bool
+	}{
+		{"without domain separation", false, true},
+		{"with domain separation", true, false},
+	} {
+
+		nodes := buildMerkleTree(hashes, test.domainSeparation)
+		root := MerkleRoot(hashes, test.domainSeparation)
+
+		//the left internal node is presented as the first leaf of a tree of two leaves
+		proof := &MerkleProof{0, []helpers.Base64{nodes[5]}}
+		assert.Equal(t, test.verified, proof.Verify(root, nodes[4], 2, test.domainSeparation), test.name)
+	}
 }
@@ -4,12 +4,24 @@
 package gui
 
 import (
+	"errors"
+	"pandora-pay/config/globals"
 	"pandora-pay/gui/gui_interactive"
+	"pandora-pay/gui/gui_non_interactive"
 )
 
 func create_gui() (err error) {
-	if GUI, err = gui_interactive.CreateGUIInteractive(); err != nil {
-		return
+	switch globals.Arguments["--gui-type"] {
+	case nil, "interactive":
+		if GUI, err = gui_interactive.CreateGUIInteractive(); err != nil {
+			return
+		}
+	case "non-interactive":
+		if GUI, err = gui_non_interactive.CreateGUINonInteractive(true); err != nil {
+			return
+		}
+	default:
+		return errors.New("invalid --gui-type argument. Accepted only: interactive, non-interactive")
 	}
 	return
 }
@@ -22,3 +22,23 @@ func InitGUI() (err error) {
 
 	return
 }
+
+func Network() gui_interface.GUISubsystemInterface {
+	return GUI.Subsystem(gui_interface.LOG_SUBSYSTEM_NETWORK)
+}
+
+func Consensus() gui_interface.GUISubsystemInterface {
+	return GUI.Subsystem(gui_interface.LOG_SUBSYSTEM_CONSENSUS)
+}
+
+func Mempool() gui_interface.GUISubsystemInterface {
+	return GUI.Subsystem(gui_interface.LOG_SUBSYSTEM_MEMPOOL)
+}
+
+func Forging() gui_interface.GUISubsystemInterface {
+	return GUI.Subsystem(gui_interface.LOG_SUBSYSTEM_FORGING)
+}
+
+func Wallet() gui_interface.GUISubsystemInterface {
+	return GUI.Subsystem(gui_interface.LOG_SUBSYSTEM_WALLET)
+}
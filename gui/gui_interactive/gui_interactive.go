@@ -49,12 +49,12 @@ func (g *GUIInteractive) Close() {
 	g.tickerRender.Stop()
 	ui.Clear()
 	ui.Close()
-	g.logger.GeneralLog.Close()
+	g.logger.Close()
 }
 
 func CreateGUIInteractive() (*GUIInteractive, error) {
 
-	logger, err := gui_logger.CreateLogger()
+	logger, err := gui_logger.CreateLogger(true)
 	if err != nil {
 		return nil, err
 	}
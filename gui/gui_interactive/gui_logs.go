@@ -4,6 +4,7 @@ import (
 	"github.com/gizak/termui/v3/widgets"
 	"pandora-pay/config"
 	"pandora-pay/gui/gui_interface"
+	"pandora-pay/gui/gui_logger"
 	"strings"
 	"time"
 )
@@ -19,9 +20,14 @@ func (g *GUIInteractive) logsRender() {
 	g.logs.Unlock()
 }
 
-func (g *GUIInteractive) message(prefix string, color string, any ...interface{}) {
+func (g *GUIInteractive) message(level gui_interface.LogLevel, subsystem string, any ...interface{}) {
 
-	text := gui_interface.ProcessArgument(any...)
+	if !g.logger.IsEnabled(level, subsystem) {
+		return
+	}
+
+	text, fields := gui_interface.SplitArguments(any...)
+	text = gui_logger.FormatMessage(subsystem, text, fields)
 
 	if config.DEBUG {
 		text = time.Now().Format("2006-01-02 15:04:05  ") + text
@@ -29,34 +35,52 @@ func (g *GUIInteractive) message(prefix string, color string, any ...interface{}
 		text = time.Now().Format("15:04:05  ") + text
 	}
 
-	final1 := prefix + " " + text + "\n"
+	var color string
+	switch level {
+	case gui_interface.LOG_LEVEL_DEBUG:
+		color = "()"
+	case gui_interface.LOG_LEVEL_INFO:
+		color = "(fg:blue)"
+	case gui_interface.LOG_LEVEL_WARNING:
+		color = "(fg:yellow)"
+	case gui_interface.LOG_LEVEL_ERROR:
+		color = "(fg:red)"
+	default:
+		color = "(fg:red,fg:bold)"
+	}
+
+	final1 := g.logger.FormatLine(level, subsystem, any...)
 	final2 := "[" + text + "]" + color + "\n"
 
 	g.logs.Lock()
-	g.logger.GeneralLog.WriteString(final1)
+	g.logger.WriteLine(final1)
 	g.logs.Text += final2
 	g.logs.Unlock()
 }
 
 func (g *GUIInteractive) Log(any ...interface{}) {
-	g.message("LOG", "()", any...)
+	g.message(gui_interface.LOG_LEVEL_DEBUG, gui_interface.LOG_SUBSYSTEM_GENERAL, any...)
 }
 
 func (g *GUIInteractive) Info(any ...interface{}) {
-	g.message("INF", "(fg:blue)", any...)
+	g.message(gui_interface.LOG_LEVEL_INFO, gui_interface.LOG_SUBSYSTEM_GENERAL, any...)
 }
 
 func (g *GUIInteractive) Warning(any ...interface{}) {
-	g.message("WARN", "(fg:yellow)", any...)
+	g.message(gui_interface.LOG_LEVEL_WARNING, gui_interface.LOG_SUBSYSTEM_GENERAL, any...)
 }
 
 func (g *GUIInteractive) Fatal(any ...interface{}) {
-	g.message("FATAL", "(fg:red,fg:bold)", any...)
+	g.message(gui_interface.LOG_LEVEL_FATAL, gui_interface.LOG_SUBSYSTEM_GENERAL, any...)
 	panic(any)
 }
 
 func (g *GUIInteractive) Error(any ...interface{}) {
-	g.message("ERR", "(fg:red)", any...)
+	g.message(gui_interface.LOG_LEVEL_ERROR, gui_interface.LOG_SUBSYSTEM_GENERAL, any...)
+}
+
+func (g *GUIInteractive) Subsystem(subsystem string) gui_interface.GUISubsystemInterface {
+	return gui_interface.NewGUISubsystem(subsystem, g.message)
 }
 
 func (g *GUIInteractive) logsInit() {
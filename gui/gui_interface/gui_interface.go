@@ -17,6 +17,7 @@ type GUIInterface interface {
 	Warning(any ...interface{})
 	Fatal(any ...interface{})
 	Error(any ...interface{})
+	Subsystem(subsystem string) GUISubsystemInterface
 	InfoUpdate(key string, text string)
 	Info2Update(key string, text string)
 	OutputWrite(any ...interface{})
@@ -57,6 +58,8 @@ func ProcessArgument(any ...interface{}) string {
 			s += base64.StdEncoding.EncodeToString(v)
 		case error:
 			s += v.Error()
+		case *LogField:
+			s += v.Key + "=" + ProcessArgument(v.Value)
 		case interface{}:
 			str, err := json.Marshal(v)
 			if err == nil {
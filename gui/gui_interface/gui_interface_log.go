@@ -0,0 +1,99 @@
+package gui_interface
+
+type LogLevel int
+
+const (
+	LOG_LEVEL_DEBUG LogLevel = iota
+	LOG_LEVEL_INFO
+	LOG_LEVEL_WARNING
+	LOG_LEVEL_ERROR
+	LOG_LEVEL_FATAL
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LOG_LEVEL_DEBUG:
+		return "debug"
+	case LOG_LEVEL_INFO:
+		return "info"
+	case LOG_LEVEL_WARNING:
+		return "warn"
+	case LOG_LEVEL_ERROR:
+		return "error"
+	case LOG_LEVEL_FATAL:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	LOG_SUBSYSTEM_GENERAL   = "general"
+	LOG_SUBSYSTEM_NETWORK   = "network"
+	LOG_SUBSYSTEM_CONSENSUS = "consensus"
+	LOG_SUBSYSTEM_MEMPOOL   = "mempool"
+	LOG_SUBSYSTEM_FORGING   = "forging"
+	LOG_SUBSYSTEM_WALLET    = "wallet"
+)
+
+var LOG_SUBSYSTEMS = []string{LOG_SUBSYSTEM_GENERAL, LOG_SUBSYSTEM_NETWORK, LOG_SUBSYSTEM_CONSENSUS, LOG_SUBSYSTEM_MEMPOOL, LOG_SUBSYSTEM_FORGING, LOG_SUBSYSTEM_WALLET}
+
+// LogField is a key/value pair passed among the log arguments.
+// The text logs print it as key=value and the json logs store it in "fields"
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+func Field(key string, value interface{}) *LogField {
+	return &LogField{key, value}
+}
+
+// SplitArguments separates the fields from the arguments which form the message
+func SplitArguments(any ...interface{}) (string, []*LogField) {
+
+	var fields []*LogField
+	args := make([]interface{}, 0, len(any))
+
+	for _, it := range any {
+		if field, ok := it.(*LogField); ok {
+			fields = append(fields, field)
+		} else {
+			args = append(args, it)
+		}
+	}
+
+	return ProcessArgument(args...), fields
+}
+
+type GUISubsystemInterface interface {
+	Log(any ...interface{})
+	Info(any ...interface{})
+	Warning(any ...interface{})
+	Error(any ...interface{})
+}
+
+type GUISubsystem struct {
+	subsystem string
+	message   func(level LogLevel, subsystem string, any ...interface{})
+}
+
+func (s *GUISubsystem) Log(any ...interface{}) {
+	s.message(LOG_LEVEL_DEBUG, s.subsystem, any...)
+}
+
+func (s *GUISubsystem) Info(any ...interface{}) {
+	s.message(LOG_LEVEL_INFO, s.subsystem, any...)
+}
+
+func (s *GUISubsystem) Warning(any ...interface{}) {
+	s.message(LOG_LEVEL_WARNING, s.subsystem, any...)
+}
+
+func (s *GUISubsystem) Error(any ...interface{}) {
+	s.message(LOG_LEVEL_ERROR, s.subsystem, any...)
+}
+
+func NewGUISubsystem(subsystem string, message func(level LogLevel, subsystem string, any ...interface{})) *GUISubsystem {
+	return &GUISubsystem{subsystem, message}
+}
@@ -1,19 +1,87 @@
 package gui_logger
 
 import (
+	"errors"
 	"os"
+	"pandora-pay/config/globals"
+	"pandora-pay/gui/gui_interface"
+	"strconv"
 	"time"
 )
 
 type GUILogger struct {
-	GeneralLog *os.File
+	GeneralLog *LogFile //nil when the logs are not written on disk
+	Format     LogFormat
+	Levels     *LogLevels
 }
 
-func CreateLogger() (*GUILogger, error) {
+func (logger *GUILogger) IsEnabled(level gui_interface.LogLevel, subsystem string) bool {
+	return logger.Levels.IsEnabled(level, subsystem)
+}
+
+// FormatLine returns the line without the new line using the format selected by --log-format
+func (logger *GUILogger) FormatLine(level gui_interface.LogLevel, subsystem string, any ...interface{}) string {
+	text, fields := gui_interface.SplitArguments(any...)
+	if logger.Format == LOG_FORMAT_JSON {
+		return FormatJSON(time.Now(), level, subsystem, text, fields)
+	}
+	return FormatText(time.Now(), level, subsystem, text, fields)
+}
+
+func (logger *GUILogger) WriteLine(line string) {
+	if logger.GeneralLog != nil {
+		logger.GeneralLog.WriteString(line + "\n")
+	}
+}
 
-	logger := &GUILogger{}
+func (logger *GUILogger) Close() {
+	if logger.GeneralLog != nil {
+		logger.GeneralLog.Close()
+	}
+}
+
+func CreateLogger(writeFile bool) (*GUILogger, error) {
+
+	logger := &GUILogger{
+		Format: LOG_FORMAT_TEXT,
+	}
 	var err error
 
+	if globals.Arguments["--log-format"] != nil {
+		switch globals.Arguments["--log-format"] {
+		case "text":
+			logger.Format = LOG_FORMAT_TEXT
+		case "json":
+			logger.Format = LOG_FORMAT_JSON
+		default:
+			return nil, errors.New("invalid --log-format argument. Accepted only: text, json")
+		}
+	}
+
+	levels := ""
+	if globals.Arguments["--log-levels"] != nil {
+		levels = globals.Arguments["--log-levels"].(string)
+	}
+	if logger.Levels, err = ParseLogLevels(levels); err != nil {
+		return nil, err
+	}
+
+	if !writeFile {
+		return logger, nil
+	}
+
+	maxSize, maxFiles := int64(LOG_FILE_MAX_SIZE), LOG_FILE_MAX_FILES
+	if globals.Arguments["--log-max-size"] != nil {
+		if maxSize, err = strconv.ParseInt(globals.Arguments["--log-max-size"].(string), 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if globals.Arguments["--log-max-files"] != nil {
+		if maxFiles, err = strconv.Atoi(globals.Arguments["--log-max-files"].(string)); err != nil {
+			return nil, err
+		}
+	}
+
 	if _, err = os.Stat("./logs"); os.IsNotExist(err) {
 		if err = os.Mkdir("./logs", 0755); err != nil {
 			return nil, err
@@ -23,7 +91,7 @@ func CreateLogger() (*GUILogger, error) {
 	t := time.Now()
 	filename := "log_" + t.Format("2006_01_02") + ".log"
 
-	if logger.GeneralLog, err = os.OpenFile("./logs/"+filename, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666); err != nil {
+	if logger.GeneralLog, err = OpenLogFile("./logs/"+filename, maxSize, maxFiles); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,103 @@
+package gui_logger
+
+import (
+	"os"
+	"strconv"
+	"sync"
+)
+
+const (
+	LOG_FILE_MAX_SIZE  = 50 * 1024 * 1024
+	LOG_FILE_MAX_FILES = 5
+)
+
+// LogFile rotates the file once it reaches maxSize bytes. The rotated files are renamed path.1, path.2, ... path.maxFiles and the oldest one is removed
+type LogFile struct {
+	path     string
+	maxSize  int64 //0 disables the rotation
+	maxFiles int
+	file     *os.File
+	size     int64
+	lock     sync.Mutex
+}
+
+func (f *LogFile) open() (err error) {
+
+	if f.file, err = os.OpenFile(f.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666); err != nil {
+		return
+	}
+
+	info, err := f.file.Stat()
+	if err != nil {
+		f.file.Close()
+		return
+	}
+	f.size = info.Size()
+
+	return
+}
+
+func (f *LogFile) rotate() (err error) {
+
+	if err = f.file.Close(); err != nil {
+		return
+	}
+
+	if f.maxFiles > 0 {
+		os.Remove(f.path + "." + strconv.Itoa(f.maxFiles))
+		for i := f.maxFiles - 1; i > 0; i-- {
+			os.Rename(f.path+"."+strconv.Itoa(i), f.path+"."+strconv.Itoa(i+1))
+		}
+		if err = os.Rename(f.path, f.path+".1"); err != nil {
+			return
+		}
+	} else if err = os.Remove(f.path); err != nil {
+		return
+	}
+
+	return f.open()
+}
+
+func (f *LogFile) WriteString(s string) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(s)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			f.file = nil
+			return 0, err
+		}
+	}
+
+	n, err := f.file.WriteString(s)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *LogFile) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func OpenLogFile(path string, maxSize int64, maxFiles int) (*LogFile, error) {
+	f := &LogFile{
+		path:     path,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
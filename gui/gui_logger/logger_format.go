@@ -0,0 +1,83 @@
+package gui_logger
+
+import (
+	"encoding/json"
+	"pandora-pay/gui/gui_interface"
+	"time"
+)
+
+type LogFormat int
+
+const (
+	LOG_FORMAT_TEXT LogFormat = iota
+	LOG_FORMAT_JSON
+)
+
+type logLineJSON struct {
+	Time      string                 `json:"time"`
+	Level     string                 `json:"level"`
+	Subsystem string                 `json:"subsystem"`
+	Msg       string                 `json:"msg"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+}
+
+func LevelPrefix(level gui_interface.LogLevel) string {
+	switch level {
+	case gui_interface.LOG_LEVEL_DEBUG:
+		return "LOG"
+	case gui_interface.LOG_LEVEL_INFO:
+		return "INF"
+	case gui_interface.LOG_LEVEL_WARNING:
+		return "WARN"
+	case gui_interface.LOG_LEVEL_ERROR:
+		return "ERR"
+	default:
+		return "FATAL"
+	}
+}
+
+// FormatMessage returns the message text prefixed by the subsystem, except the general one, and followed by the fields
+func FormatMessage(subsystem, text string, fields []*gui_interface.LogField) string {
+	if subsystem != gui_interface.LOG_SUBSYSTEM_GENERAL {
+		text = subsystem + ": " + text
+	}
+	for _, field := range fields {
+		text += " " + gui_interface.ProcessArgument(field)
+	}
+	return text
+}
+
+func FormatText(t time.Time, level gui_interface.LogLevel, subsystem, text string, fields []*gui_interface.LogField) string {
+	return LevelPrefix(level) + " " + t.Format("2006-01-02 15:04:05  ") + FormatMessage(subsystem, text, fields)
+}
+
+func FormatJSON(t time.Time, level gui_interface.LogLevel, subsystem, text string, fields []*gui_interface.LogField) string {
+
+	line := &logLineJSON{
+		Time:      t.UTC().Format(time.RFC3339Nano),
+		Level:     level.String(),
+		Subsystem: subsystem,
+		Msg:       text,
+	}
+
+	if len(fields) > 0 {
+		line.Fields = make(map[string]interface{})
+		for _, field := range fields {
+			switch v := field.Value.(type) {
+			case error:
+				line.Fields[field.Key] = v.Error()
+			default:
+				line.Fields[field.Key] = v
+			}
+		}
+	}
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		//the fields couldn't be marshaled
+		line.Fields = map[string]interface{}{"error": "error marshaling fields"}
+		data, _ = json.Marshal(line)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,80 @@
+package gui_logger
+
+import (
+	"errors"
+	"pandora-pay/gui/gui_interface"
+	"strings"
+)
+
+type LogLevels struct {
+	Default    gui_interface.LogLevel
+	Subsystems map[string]gui_interface.LogLevel
+}
+
+func (levels *LogLevels) IsEnabled(level gui_interface.LogLevel, subsystem string) bool {
+	if min, ok := levels.Subsystems[subsystem]; ok {
+		return level >= min
+	}
+	return level >= levels.Default
+}
+
+func ParseLogLevel(s string) (gui_interface.LogLevel, error) {
+	switch s {
+	case "debug":
+		return gui_interface.LOG_LEVEL_DEBUG, nil
+	case "info":
+		return gui_interface.LOG_LEVEL_INFO, nil
+	case "warn":
+		return gui_interface.LOG_LEVEL_WARNING, nil
+	case "error":
+		return gui_interface.LOG_LEVEL_ERROR, nil
+	default:
+		return 0, errors.New("invalid log level " + s + ". Accepted only: debug, info, warn, error")
+	}
+}
+
+// ParseLogLevels parses "level,subsystem=level,...". The level without a subsystem is used for the rest of the subsystems
+func ParseLogLevels(s string) (*LogLevels, error) {
+
+	levels := &LogLevels{
+		gui_interface.LOG_LEVEL_DEBUG,
+		make(map[string]gui_interface.LogLevel),
+	}
+
+	if s == "" {
+		return levels, nil
+	}
+
+	for _, it := range strings.Split(s, ",") {
+
+		parts := strings.Split(strings.TrimSpace(it), "=")
+		if len(parts) > 2 {
+			return nil, errors.New("invalid log levels " + it)
+		}
+
+		level, err := ParseLogLevel(parts[len(parts)-1])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(parts) == 1 {
+			levels.Default = level
+			continue
+		}
+
+		found := false
+		for _, subsystem := range gui_interface.LOG_SUBSYSTEMS {
+			if subsystem == parts[0] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.New("invalid log subsystem " + parts[0] + ". Accepted only: " + strings.Join(gui_interface.LOG_SUBSYSTEMS, ", "))
+		}
+
+		levels.Subsystems[parts[0]] = level
+	}
+
+	return levels, nil
+}
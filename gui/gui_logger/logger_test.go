@@ -0,0 +1,75 @@
+package gui_logger
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"pandora-pay/gui/gui_interface"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseLogLevels(t *testing.T) {
+
+	levels, err := ParseLogLevels("warn,network=debug,consensus=error")
+	assert.Nil(t, err)
+
+	assert.False(t, levels.IsEnabled(gui_interface.LOG_LEVEL_INFO, gui_interface.LOG_SUBSYSTEM_GENERAL))
+	assert.True(t, levels.IsEnabled(gui_interface.LOG_LEVEL_WARNING, gui_interface.LOG_SUBSYSTEM_MEMPOOL))
+	assert.True(t, levels.IsEnabled(gui_interface.LOG_LEVEL_DEBUG, gui_interface.LOG_SUBSYSTEM_NETWORK))
+	assert.False(t, levels.IsEnabled(gui_interface.LOG_LEVEL_WARNING, gui_interface.LOG_SUBSYSTEM_CONSENSUS))
+	assert.True(t, levels.IsEnabled(gui_interface.LOG_LEVEL_FATAL, gui_interface.LOG_SUBSYSTEM_CONSENSUS))
+
+	_, err = ParseLogLevels("storage=info")
+	assert.NotNil(t, err)
+
+	_, err = ParseLogLevels("network=verbose")
+	assert.NotNil(t, err)
+}
+
+func TestFormatJSON(t *testing.T) {
+
+	text, fields := gui_interface.SplitArguments("Block was forged!", gui_interface.Field("height", uint64(12)), gui_interface.Field("error", errors.New("invalid")))
+	assert.Equal(t, "Block was forged!", text)
+
+	line := FormatJSON(time.Unix(0, 0), gui_interface.LOG_LEVEL_INFO, gui_interface.LOG_SUBSYSTEM_FORGING, text, fields)
+
+	out := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal([]byte(line), &out))
+	assert.Equal(t, "1970-01-01T00:00:00Z", out["time"])
+	assert.Equal(t, "info", out["level"])
+	assert.Equal(t, "forging", out["subsystem"])
+	assert.Equal(t, "Block was forged!", out["msg"])
+	assert.Equal(t, map[string]interface{}{"height": float64(12), "error": "invalid"}, out["fields"])
+
+	assert.Equal(t, "forging: Block was forged! height=12 error=invalid", FormatMessage(gui_interface.LOG_SUBSYSTEM_FORGING, text, fields))
+}
+
+func TestLogFileRotation(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "log.log")
+
+	f, err := OpenLogFile(path, 10, 2)
+	assert.Nil(t, err)
+
+	for _, s := range []string{"aaaaaaaa\n", "bbbbbbbb\n", "cccccccc\n", "dddddddd\n"} {
+		_, err = f.WriteString(s)
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, f.Close())
+
+	read := func(path string) string {
+		data, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		return string(data)
+	}
+
+	assert.Equal(t, "dddddddd\n", read(path))
+	assert.Equal(t, "cccccccc\n", read(path+".1"))
+	assert.Equal(t, "bbbbbbbb\n", read(path+".2"))
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
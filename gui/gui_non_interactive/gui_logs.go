@@ -3,34 +3,67 @@ package gui_non_interactive
 import (
 	"fmt"
 	"pandora-pay/gui/gui_interface"
+	"pandora-pay/gui/gui_logger"
 )
 
-func (g *GUINonInteractive) message(prefix string, color string, any ...interface{}) {
-	text := gui_interface.ProcessArgument(any...)
-	final := prefix + " " + color + " " + text
+func (g *GUINonInteractive) message(level gui_interface.LogLevel, subsystem string, any ...interface{}) {
+
+	if !g.logger.IsEnabled(level, subsystem) {
+		return
+	}
+
+	line := g.logger.FormatLine(level, subsystem, any...)
+
+	var final string
+	if g.logger.Format == gui_logger.LOG_FORMAT_JSON {
+		final = line
+	} else {
+		text, fields := gui_interface.SplitArguments(any...)
+
+		var color string
+		switch level {
+		case gui_interface.LOG_LEVEL_DEBUG:
+			color = g.colorLog
+		case gui_interface.LOG_LEVEL_INFO:
+			color = g.colorInfo
+		case gui_interface.LOG_LEVEL_WARNING:
+			color = g.colorWarning
+		case gui_interface.LOG_LEVEL_ERROR:
+			color = g.colorError
+		default:
+			color = g.colorFatal
+		}
+
+		final = gui_logger.LevelPrefix(level) + " " + color + " " + gui_logger.FormatMessage(subsystem, text, fields)
+	}
 
 	g.writingMutex.Lock()
 	fmt.Println(final)
+	g.logger.WriteLine(line)
 	g.writingMutex.Unlock()
 }
 
 func (g *GUINonInteractive) Log(any ...interface{}) {
-	g.message("LOG", g.colorLog, any...)
+	g.message(gui_interface.LOG_LEVEL_DEBUG, gui_interface.LOG_SUBSYSTEM_GENERAL, any...)
 }
 
 func (g *GUINonInteractive) Info(any ...interface{}) {
-	g.message("INF", g.colorInfo, any...)
+	g.message(gui_interface.LOG_LEVEL_INFO, gui_interface.LOG_SUBSYSTEM_GENERAL, any...)
 }
 
 func (g *GUINonInteractive) Warning(any ...interface{}) {
-	g.message("WARN", g.colorWarning, any...)
+	g.message(gui_interface.LOG_LEVEL_WARNING, gui_interface.LOG_SUBSYSTEM_GENERAL, any...)
 }
 
 func (g *GUINonInteractive) Fatal(any ...interface{}) {
-	g.message("FATAL", g.colorFatal, any...)
+	g.message(gui_interface.LOG_LEVEL_FATAL, gui_interface.LOG_SUBSYSTEM_GENERAL, any...)
 	panic(any)
 }
 
 func (g *GUINonInteractive) Error(any ...interface{}) {
-	g.message("ERR", g.colorError, any...)
+	g.message(gui_interface.LOG_LEVEL_ERROR, gui_interface.LOG_SUBSYSTEM_GENERAL, any...)
+}
+
+func (g *GUINonInteractive) Subsystem(subsystem string) gui_interface.GUISubsystemInterface {
+	return gui_interface.NewGUISubsystem(subsystem, g.message)
 }
@@ -20,11 +20,20 @@ type GUINonInteractive struct {
 }
 
 func (g *GUINonInteractive) Close() {
+	g.logger.Close()
 }
 
-func CreateGUINonInteractive() (*GUINonInteractive, error) {
+// CreateGUINonInteractive prints the logs on the standard output. The logs are also written in ./logs when writeFile is true
+func CreateGUINonInteractive(writeFile bool) (*GUINonInteractive, error) {
 
-	g := &GUINonInteractive{}
+	logger, err := gui_logger.CreateLogger(writeFile)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &GUINonInteractive{
+		logger: logger,
+	}
 
 	switch runtime.GOARCH {
 	default:
@@ -0,0 +1,45 @@
+package rate_limiter
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket refilled with rate tokens per second up to burst tokens
+type RateLimiter struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	lock   *sync.Mutex
+}
+
+func (self *RateLimiter) Allow() bool {
+
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	now := time.Now()
+	self.tokens += now.Sub(self.last).Seconds() * self.rate
+	if self.tokens > self.burst {
+		self.tokens = self.burst
+	}
+	self.last = now
+
+	if self.tokens < 1 {
+		return false
+	}
+
+	self.tokens -= 1
+	return true
+}
+
+func NewRateLimiter(rate, burst float64) *RateLimiter {
+	return &RateLimiter{
+		rate,
+		burst,
+		burst,
+		time.Now(),
+		&sync.Mutex{},
+	}
+}
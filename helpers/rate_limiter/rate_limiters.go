@@ -0,0 +1,64 @@
+package rate_limiter
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// RateLimiters keeps a RateLimiter for every key, like the remote address of a HTTP request
+type RateLimiters struct {
+	rate     float64
+	burst    float64
+	limiters map[string]*RateLimiter
+	lock     *sync.Mutex
+}
+
+func (self *RateLimiters) Allow(key string) bool {
+
+	self.lock.Lock()
+	limiter := self.limiters[key]
+	if limiter == nil {
+		self.prune()
+		limiter = NewRateLimiter(self.rate, self.burst)
+		self.limiters[key] = limiter
+	}
+	self.lock.Unlock()
+
+	return limiter.Allow()
+}
+
+// AllowRemoteAddr limits the host of a remote address, as every connection of the client uses a different port
+func (self *RateLimiters) AllowRemoteAddr(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	return self.Allow(host)
+}
+
+// prune removes the limiters that were refilled completely, as they behave like new ones
+func (self *RateLimiters) prune() {
+
+	if len(self.limiters) < 1000 {
+		return
+	}
+
+	refilled := time.Now().Add(-time.Duration(self.burst / self.rate * float64(time.Second)))
+	for key, limiter := range self.limiters {
+		limiter.lock.Lock()
+		if limiter.last.Before(refilled) {
+			delete(self.limiters, key)
+		}
+		limiter.lock.Unlock()
+	}
+}
+
+func NewRateLimiters(rate, burst float64) *RateLimiters {
+	return &RateLimiters{
+		rate,
+		burst,
+		make(map[string]*RateLimiter),
+		&sync.Mutex{},
+	}
+}
@@ -0,0 +1,21 @@
+package rate_limiter
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRateLimiters_Allow(t *testing.T) {
+
+	limiters := NewRateLimiters(0.001, 2)
+
+	assert.True(t, limiters.Allow("1.1.1.1"))
+	assert.True(t, limiters.Allow("1.1.1.1"))
+	assert.False(t, limiters.Allow("1.1.1.1"), "the burst is consumed")
+
+	assert.True(t, limiters.Allow("2.2.2.2"), "every remote address has its own limit")
+
+	assert.True(t, limiters.AllowRemoteAddr("3.3.3.3:1000"))
+	assert.True(t, limiters.AllowRemoteAddr("3.3.3.3:2000"))
+	assert.False(t, limiters.AllowRemoteAddr("3.3.3.3:3000"), "the ports of the remote address share the limit")
+}
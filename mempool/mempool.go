@@ -20,11 +20,12 @@ import (
 )
 
 type mempoolTx struct {
-	Tx          *transaction.Transaction `json:"tx" msgpack:"tx"`
-	Added       int64                    `json:"added" msgpack:"added"`
-	Mine        bool                     `json:"mine" msgpack:"mine"`
-	FeePerByte  uint64                   `json:"feePerByte" msgpack:"feePerByte"`
-	ChainHeight uint64                   `json:"chainHeight" msgpack:"chainHeight"`
+	Tx           *transaction.Transaction `json:"tx" msgpack:"tx"`
+	Added        int64                    `json:"added" msgpack:"added"`
+	Mine         bool                     `json:"mine" msgpack:"mine"`
+	FeePerByte   uint64                   `json:"feePerByte" msgpack:"feePerByte"`
+	ChainHeight  uint64                   `json:"chainHeight" msgpack:"chainHeight"`
+	conflictKeys []string
 }
 
 type Mempool struct {
@@ -36,7 +37,9 @@ type Mempool struct {
 	addTransactionCn          chan *MempoolWorkerAddTx
 	removeTransactionsCn      chan *MempoolWorkerRemoveTxs
 	insertTransactionsCn      chan *MempoolWorkerInsertTxs
+	pingCn                    chan *MempoolWorkerPing
 	Txs                       *MempoolTxs
+	FeeEstimator              *FeeEstimator
 	OnBroadcastNewTransaction func([]*transaction.Transaction, bool, bool, advanced_connection_types.UUID, context.Context) []error
 }
 
@@ -67,11 +70,42 @@ func (mempool *Mempool) InsertRemovedTxsFromBlockchain(txs []*transaction.Transa
 
 }
 
+// Ping returns an error when the worker doesn't answer before ctx is done
+func (mempool *Mempool) Ping(ctx context.Context) error {
+	answerCn := make(chan struct{}, 1)
+	select {
+	case mempool.pingCn <- &MempoolWorkerPing{answerCn}:
+	case <-ctx.Done():
+		return errors.New("Mempool worker is not responding")
+	}
+	select {
+	case <-answerCn:
+		return nil
+	case <-ctx.Done():
+		return errors.New("Mempool worker is not responding")
+	}
+}
+
 func (mempool *Mempool) AddTxToMempool(tx *transaction.Transaction, height uint64, justCreated bool, awaitAnswer, awaitBroadcasting bool, exceptSocketUUID advanced_connection_types.UUID, ctx context.Context) error {
 	result := mempool.AddTxsToMempool([]*transaction.Transaction{tx}, height, justCreated, awaitAnswer, awaitBroadcasting, exceptSocketUUID, ctx)
 	return result[0]
 }
 
+func getTxFeePerByte(tx *transaction.Transaction) (uint64, error) {
+
+	minerFee, err := tx.GetAllFee()
+	if err != nil {
+		return 0, err
+	}
+
+	computedFeePerByte := minerFee
+	if err = helpers.SafeUint64Sub(&computedFeePerByte, tx.SpaceExtra*config_fees.FEE_PER_BYTE_EXTRA_SPACE); err != nil {
+		return 0, err
+	}
+
+	return minerFee / tx.Bloom.Size, nil
+}
+
 func (mempool *Mempool) processTxsToMempool(txs []*transaction.Transaction, height uint64, ctx context.Context) (finalTxs []*mempoolTx, errs []error) {
 
 	finalTxs = make([]*mempoolTx, len(txs))
@@ -105,19 +139,12 @@ func (mempool *Mempool) processTxsToMempool(txs []*transaction.Transaction, heig
 
 		checkFee := true
 
-		minerFee, err := tx.GetAllFee()
+		computedFeePerByte, err := getTxFeePerByte(tx)
 		if err != nil {
 			errs[i] = err
 			continue
 		}
 
-		computedFeePerByte := minerFee
-		if errs[i] = helpers.SafeUint64Sub(&computedFeePerByte, tx.SpaceExtra*config_fees.FEE_PER_BYTE_EXTRA_SPACE); errs[i] != nil {
-			continue
-		}
-
-		computedFeePerByte = minerFee / tx.Bloom.Size
-
 		requiredFeePerByte := uint64(0)
 		switch tx.Version {
 		case transaction_type.TX_SIMPLE:
@@ -156,6 +183,22 @@ func (mempool *Mempool) AddTxsToMempool(txs []*transaction.Transaction, height u
 
 	finalTxs, errs := mempool.processTxsToMempool(txs, height, ctx)
 
+	minFeePerByte := mempool.Txs.GetMinFeePerByte()
+	for i, finalTx := range finalTxs {
+		if finalTx != nil {
+			finalTx.Mine = justCreated
+			if !justCreated && finalTx.FeePerByte < minFeePerByte {
+				errs[i] = errors.New("Transaction fee is below the mempool minimum fee")
+				finalTxs[i] = nil
+			}
+		}
+	}
+
+	return mempool.addProcessedTxsToMempool(finalTxs, errs, justCreated, awaitAnswer, awaitBroadcasting, exceptSocketUUID, ctx)
+}
+
+func (mempool *Mempool) addProcessedTxsToMempool(finalTxs []*mempoolTx, errs []error, justCreated, awaitAnswer, awaitBroadcasting bool, exceptSocketUUID advanced_connection_types.UUID, ctx context.Context) []error {
+
 	//making sure that the transaction is not inserted twice
 	if runtime.GOARCH != "wasm" {
 		for i, finalTx := range finalTxs {
@@ -236,7 +279,7 @@ func (mempool *Mempool) ContinueWork() {
 
 func CreateMempool(txsValidator *txs_validator.TxsValidator) (*Mempool, error) {
 
-	gui.GUI.Log("Mempool init...")
+	gui.Mempool().Log("Mempool init...")
 
 	mempool := &Mempool{
 		txsValidator,
@@ -247,13 +290,15 @@ func CreateMempool(txsValidator *txs_validator.TxsValidator) (*Mempool, error) {
 		make(chan *MempoolWorkerAddTx, 1000),
 		make(chan *MempoolWorkerRemoveTxs),
 		make(chan *MempoolWorkerInsertTxs),
+		make(chan *MempoolWorkerPing),
 		createMempoolTxs(),
+		createFeeEstimator(),
 		nil,
 	}
 
 	worker := new(mempoolWorker)
 	recovery.SafeGo(func() {
-		worker.processing(mempool.newWorkCn, mempool.SuspendProcessingCn, mempool.ContinueProcessingCn, mempool.addTransactionCn, mempool.insertTransactionsCn, mempool.removeTransactionsCn, mempool.Txs)
+		worker.processing(mempool.newWorkCn, mempool.SuspendProcessingCn, mempool.ContinueProcessingCn, mempool.addTransactionCn, mempool.insertTransactionsCn, mempool.removeTransactionsCn, mempool.pingCn, mempool.Txs)
 	})
 
 	mempool.initCLI()
@@ -0,0 +1,119 @@
+package mempool
+
+import (
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config"
+	"sort"
+	"sync"
+)
+
+const FEE_ESTIMATOR_BLOCKS = 20
+
+type feeEstimatorBlock struct {
+	height        uint64
+	size          uint64
+	minFeePerByte uint64 //0 in case the block has no paying transactions
+}
+
+// FeeEstimator keeps the fees per byte of the last included blocks
+type FeeEstimator struct {
+	blocks []*feeEstimatorBlock //ordered by height
+	lock   *sync.RWMutex
+}
+
+func (self *FeeEstimator) AddBlock(height uint64, txs []*transaction.Transaction) {
+
+	blk := &feeEstimatorBlock{height, 0, 0}
+	for _, tx := range txs {
+		blk.size += tx.Bloom.Size
+
+		feePerByte, err := getTxFeePerByte(tx)
+		if err != nil || feePerByte == 0 { //staking rewards and resolutions don't pay fees
+			continue
+		}
+		if blk.minFeePerByte == 0 || feePerByte < blk.minFeePerByte {
+			blk.minFeePerByte = feePerByte
+		}
+	}
+
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	//blocks removed by a reorg are replaced
+	for len(self.blocks) > 0 && self.blocks[len(self.blocks)-1].height >= height {
+		self.blocks = self.blocks[:len(self.blocks)-1]
+	}
+
+	self.blocks = append(self.blocks, blk)
+	if len(self.blocks) > FEE_ESTIMATOR_BLOCKS {
+		self.blocks = self.blocks[len(self.blocks)-FEE_ESTIMATOR_BLOCKS:]
+	}
+}
+
+// estimateFromBlocks returns the fee per byte required by the congested blocks. The lower the target, the higher the percentile
+func (self *FeeEstimator) estimateFromBlocks(target uint64) uint64 {
+
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+
+	if len(self.blocks) == 0 {
+		return 0
+	}
+
+	fees := make([]uint64, len(self.blocks))
+	for i, blk := range self.blocks {
+		if blk.size >= config.BLOCK_MAX_SIZE*9/10 && blk.minFeePerByte > 0 {
+			fees[i] = blk.minFeePerByte + 1
+		}
+	}
+	sort.Slice(fees, func(i, j int) bool {
+		return fees[i] < fees[j]
+	})
+
+	last := uint64(len(fees) - 1)
+	return fees[last-last*(target-1)/target]
+}
+
+// EstimateFeePerByte returns the fee per byte required for a transaction to be included in the next target blocks.
+// The target is clamped to FEE_ESTIMATOR_BLOCKS, the blocks the estimate is computed from
+func (mempool *Mempool) EstimateFeePerByte(target uint64) uint64 {
+
+	if target == 0 {
+		target = 1
+	}
+	if target > FEE_ESTIMATOR_BLOCKS {
+		target = FEE_ESTIMATOR_BLOCKS
+	}
+
+	feePerByte := mempool.FeeEstimator.estimateFromBlocks(target)
+
+	if minFeePerByte := mempool.Txs.GetMinFeePerByte(); minFeePerByte > feePerByte {
+		feePerByte = minFeePerByte
+	}
+
+	//the transactions paying more are forged first
+	txs := mempool.Txs.GetTxsList()
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].FeePerByte > txs[j].FeePerByte
+	})
+
+	size := uint64(0)
+	for _, tx := range txs {
+		size += tx.Tx.Bloom.Size
+		if size >= target*config.BLOCK_MAX_SIZE {
+			if tx.FeePerByte+1 > feePerByte {
+				feePerByte = tx.FeePerByte + 1
+			}
+			break
+		}
+	}
+
+	return feePerByte
+}
+
+func createFeeEstimator() *FeeEstimator {
+	return &FeeEstimator{
+		[]*feeEstimatorBlock{},
+		&sync.RWMutex{},
+	}
+}
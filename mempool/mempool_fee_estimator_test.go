@@ -0,0 +1,132 @@
+package mempool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"sync/atomic"
+	"testing"
+)
+
+// createTestFeeTx creates a transaction of the given size paying the native fee feePerByte*size
+func createTestFeeTx(size, feePerByte uint64) *transaction.Transaction {
+	return createTestFeePayloadsTx(size, &transaction_zether_payload.TransactionZetherPayload{Asset: config_coins.NATIVE_ASSET_FULL, Statement: &crypto.Statement{Fee: feePerByte * size}})
+}
+
+func createTestFeePayloadsTx(size uint64, payloads ...*transaction_zether_payload.TransactionZetherPayload) *transaction.Transaction {
+	hash := cryptography.RandomHash()
+	return &transaction.Transaction{
+		TransactionBaseInterface: &transaction_zether.TransactionZether{Payloads: payloads},
+		Version:                  transaction_type.TX_ZETHER,
+		Bloom:                    &transaction.TransactionBloom{Size: size, Hash: hash, HashStr: string(hash)},
+	}
+}
+
+func TestFeeEstimator_EstimateFromBlocks(t *testing.T) {
+
+	size := config.BLOCK_MAX_SIZE
+
+	//the asset fee 40*size is converted to 40*size*2/10 = 8*size
+	mixedAssetsTx := createTestFeePayloadsTx(size,
+		&transaction_zether_payload.TransactionZetherPayload{Asset: config_coins.NATIVE_ASSET_FULL, Statement: &crypto.Statement{Fee: 2 * size}},
+		&transaction_zether_payload.TransactionZetherPayload{Asset: cryptography.RandomHash()[:config_coins.ASSET_LENGTH], FeeRate: 2, FeeLeadingZeros: 1, Statement: &crypto.Statement{Fee: 40 * size}},
+	)
+
+	percentileBlocks := [][]*transaction.Transaction{
+		{createTestFeeTx(size, 10)},
+		{createTestFeeTx(size, 20)},
+		{createTestFeeTx(size/2, 50)},
+		{createTestFeeTx(size, 30)},
+	}
+
+	for _, test := range []struct {
+		name     string
+		blocks   [][]*transaction.Transaction
+		target   uint64
+		expected uint64
+	}{
+		{"no blocks", nil, 1, 0},
+		{"empty blocks", [][]*transaction.Transaction{{}, {}}, 1, 0},
+		{"blocks not congested", [][]*transaction.Transaction{{createTestFeeTx(size/2, 10)}}, 1, 0},
+		{"congested block", [][]*transaction.Transaction{{createTestFeeTx(size, 10)}}, 1, 11},
+		{"congested block without fees", [][]*transaction.Transaction{{createTestFeeTx(size, 0)}}, 1, 0},
+		{"minimum fee of the block", [][]*transaction.Transaction{{createTestFeeTx(size/2, 20), createTestFeeTx(size/2, 10), createTestFeeTx(size/10, 0)}}, 1, 11},
+		{"mixed assets", [][]*transaction.Transaction{{mixedAssetsTx}}, 1, 11},
+		{"percentile target 1", percentileBlocks, 1, 31},
+		{"percentile target 2", percentileBlocks, 2, 21},
+		{"percentile target 3", percentileBlocks, 3, 11},
+	} {
+
+		estimator := createFeeEstimator()
+		for i, txs := range test.blocks {
+			estimator.AddBlock(uint64(i+1), txs)
+		}
+
+		assert.Equal(t, test.expected, estimator.estimateFromBlocks(test.target), test.name)
+	}
+}
+
+func TestFeeEstimator_AddBlock(t *testing.T) {
+
+	size := config.BLOCK_MAX_SIZE
+
+	estimator := createFeeEstimator()
+	for height := uint64(1); height <= FEE_ESTIMATOR_BLOCKS+5; height++ {
+		estimator.AddBlock(height, []*transaction.Transaction{createTestFeeTx(size, height)})
+	}
+	assert.Equal(t, FEE_ESTIMATOR_BLOCKS, len(estimator.blocks), "only the last blocks are kept")
+	assert.Equal(t, uint64(6), estimator.blocks[0].height)
+	assert.Equal(t, uint64(FEE_ESTIMATOR_BLOCKS+5+1), estimator.estimateFromBlocks(1))
+
+	//a reorg replaces the removed blocks
+	estimator.AddBlock(FEE_ESTIMATOR_BLOCKS, []*transaction.Transaction{})
+	assert.Equal(t, FEE_ESTIMATOR_BLOCKS-5, len(estimator.blocks))
+	assert.Equal(t, uint64(FEE_ESTIMATOR_BLOCKS), estimator.blocks[len(estimator.blocks)-1].height)
+	assert.Equal(t, uint64(FEE_ESTIMATOR_BLOCKS), estimator.estimateFromBlocks(1), "the last congested block left pays 19")
+}
+
+func TestMempool_EstimateFeePerByte(t *testing.T) {
+
+	size := config.BLOCK_MAX_SIZE
+
+	for _, test := range []struct {
+		name          string
+		blocksFees    []uint64 //fee per byte of the congested blocks
+		mempoolFees   []uint64 //fee per byte of the mempool txs of half a block
+		minFeePerByte uint64
+		target        uint64
+		expected      uint64
+	}{
+		{"empty", nil, nil, 0, 1, 0},
+		{"blocks", []uint64{10}, nil, 0, 0, 11},
+		{"minimum fee floor", nil, nil, 7, 1, 7},
+		{"minimum fee floor lower than the blocks", []uint64{10}, nil, 7, 1, 11},
+		{"minimum fee floor higher than the blocks", []uint64{10}, nil, 15, 1, 15},
+		{"mempool not congested", nil, []uint64{50}, 0, 1, 0},
+		{"mempool percentile target 1", nil, []uint64{20, 50, 30, 40}, 0, 1, 41},
+		{"mempool percentile target 2", nil, []uint64{20, 50, 30, 40}, 0, 2, 21},
+		{"mempool percentile target 3", nil, []uint64{20, 50, 30, 40}, 7, 3, 7},
+		{"mempool lower than the blocks", []uint64{100}, []uint64{20, 50, 30, 40}, 0, 1, 101},
+		{"mempool lower than the minimum fee floor", nil, []uint64{20, 50, 30, 40}, 60, 1, 60},
+		{"target clamped to the estimator blocks", nil, []uint64{20, 50, 30, 40}, 0, 1 << 62, 0}, //target*BLOCK_MAX_SIZE would overflow
+	} {
+
+		mempool := &Mempool{Txs: createMempoolTxs(), FeeEstimator: createFeeEstimator()}
+
+		for i, feePerByte := range test.blocksFees {
+			mempool.FeeEstimator.AddBlock(uint64(i+1), []*transaction.Transaction{createTestFeeTx(size, feePerByte)})
+		}
+		for _, feePerByte := range test.mempoolFees {
+			assert.True(t, mempool.Txs.insertTx(&mempoolTx{Tx: createTestFeeTx(size/2, feePerByte), FeePerByte: feePerByte}))
+		}
+		atomic.StoreUint64(&mempool.Txs.minFeePerByte, test.minFeePerByte)
+
+		assert.Equal(t, test.expected, mempool.EstimateFeePerByte(test.target), test.name)
+	}
+}
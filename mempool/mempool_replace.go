@@ -0,0 +1,70 @@
+package mempool
+
+import (
+	"errors"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers"
+	"strconv"
+)
+
+// getConflictKeys returns the state spent by the transaction. Only one of the transactions having a common key can be included
+func getConflictKeys(tx *transaction.Transaction) []string {
+
+	keys := []string{}
+
+	switch tx.Version {
+	case transaction_type.TX_SIMPLE:
+		txBase := tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+		if txBase.HasVin() {
+			keys = append(keys, "nonce:"+string(txBase.Vin.PublicKey)+":"+strconv.FormatUint(txBase.Nonce, 10))
+		}
+	case transaction_type.TX_ZETHER:
+		txBase := tx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
+		for t, payload := range txBase.Payloads {
+			//the senders ring members prove the balance they had before the transaction. Any transaction using them changes it
+			for i, publicKey := range txBase.Bloom.PublicKeyLists[t] {
+				if (i%2 == 0) == payload.Parity {
+					echanges := crypto.ConstructElGamal(payload.Statement.C[i], payload.Statement.D)
+					balance := crypto.ConstructElGamal(payload.Statement.CLn[i], payload.Statement.CRn[i]).Add(echanges.Neg())
+					keys = append(keys, "balance:"+string(payload.Asset)+string(publicKey)+string(balance.Serialize()))
+				}
+			}
+		}
+	}
+
+	return keys
+}
+
+// canReplaceTxs accepts a replacement only if it pays a higher fee per byte than each replaced tx and at least their total fee
+func canReplaceTxs(tx *mempoolTx, replaced []*mempoolTx) error {
+
+	fee, err := tx.Tx.GetAllFee()
+	if err != nil {
+		return err
+	}
+
+	replacedFee := uint64(0)
+	for _, replacedTx := range replaced {
+		if tx.FeePerByte <= replacedTx.FeePerByte {
+			return errors.New("Transaction is conflicting with a transaction from mempool paying a higher or equal fee per byte")
+		}
+
+		var txFee uint64
+		if txFee, err = replacedTx.Tx.GetAllFee(); err != nil {
+			return err
+		}
+		if err = helpers.SafeUint64Add(&replacedFee, txFee); err != nil {
+			return err
+		}
+	}
+
+	if fee < replacedFee {
+		return errors.New("Replacement fee must be at least the fee of the replaced transactions")
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package mempool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/bn256"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"testing"
+	"time"
+)
+
+func testPoint(n int64) *bn256.G1 {
+	return new(bn256.G1).ScalarMult(crypto.G, big.NewInt(n))
+}
+
+// createTestZetherTx creates a transaction spending from the ring member senderIndex. The balance proven for the sender is (balance, balance)
+func createTestZetherTx(ring [][]byte, senderIndex int, balance int64) *transaction.Transaction {
+
+	statement := &crypto.Statement{
+		RingSize: len(ring),
+		D:        testPoint(3),
+	}
+	for i := range ring {
+		commitment := int64(5 + i)
+		statement.C = append(statement.C, testPoint(commitment))
+		statement.CLn = append(statement.CLn, testPoint(balance+commitment))
+		statement.CRn = append(statement.CRn, testPoint(balance+3))
+	}
+
+	return &transaction.Transaction{
+		TransactionBaseInterface: &transaction_zether.TransactionZether{
+			Payloads: []*transaction_zether_payload.TransactionZetherPayload{{
+				Asset:     config_coins.NATIVE_ASSET_FULL,
+				Parity:    senderIndex%2 == 0,
+				Statement: statement,
+			}},
+			Bloom: &transaction_zether.TransactionZetherBloom{PublicKeyLists: [][][]byte{ring}},
+		},
+		Version: transaction_type.TX_ZETHER,
+	}
+}
+
+func TestGetConflictKeys_Nonce(t *testing.T) {
+
+	key := addresses.GenerateNewPrivateKey().Key
+
+	tx := createTestTxFrom(t, key, 5, 0)
+	keys := getConflictKeys(tx)
+	assert.Equal(t, 1, len(keys))
+
+	assert.Equal(t, keys, getConflictKeys(createTestTxFrom(t, key, 5, 10)), "the same nonce of the same sender conflicts")
+	assert.NotEqual(t, keys, getConflictKeys(createTestTxFrom(t, key, 6, 0)))
+	assert.NotEqual(t, keys, getConflictKeys(createTestTx(t, 5)))
+}
+
+func TestGetConflictKeys_ZetherBalance(t *testing.T) {
+
+	sender, decoy, other := helpers.RandomBytes(cryptography.PublicKeySize), helpers.RandomBytes(cryptography.PublicKeySize), helpers.RandomBytes(cryptography.PublicKeySize)
+
+	keys := getConflictKeys(createTestZetherTx([][]byte{sender, decoy}, 0, 100))
+	assert.Equal(t, 1, len(keys), "only the ring members of the sender parity are keys")
+
+	assert.Equal(t, keys, getConflictKeys(createTestZetherTx([][]byte{other, sender}, 1, 100)), "spending the same balance conflicts")
+	assert.NotEqual(t, keys, getConflictKeys(createTestZetherTx([][]byte{sender, decoy}, 0, 200)), "spending a later balance doesn't conflict")
+	assert.NotEqual(t, keys, getConflictKeys(createTestZetherTx([][]byte{decoy, sender}, 0, 100)), "the receiver side doesn't conflict")
+}
+
+func TestCanReplaceTxs(t *testing.T) {
+
+	key := addresses.GenerateNewPrivateKey().Key
+
+	newMempoolTx := func(fee, feePerByte uint64) *mempoolTx {
+		tx := createTestTxFrom(t, key, 5, fee)
+		txFee, err := tx.GetAllFee()
+		assert.NoError(t, err)
+		assert.Equal(t, fee, txFee)
+		return &mempoolTx{Tx: tx, FeePerByte: feePerByte}
+	}
+
+	replaced := []*mempoolTx{newMempoolTx(100, 10), newMempoolTx(200, 20)}
+
+	assert.NoError(t, canReplaceTxs(newMempoolTx(300, 21), replaced))
+	assert.NoError(t, canReplaceTxs(newMempoolTx(1000, 100), replaced))
+
+	assert.Error(t, canReplaceTxs(newMempoolTx(1000, 20), replaced), "the fee per byte must be higher than each replaced tx")
+	assert.Error(t, canReplaceTxs(newMempoolTx(1000, 15), replaced))
+	assert.Error(t, canReplaceTxs(newMempoolTx(299, 100), replaced), "the fee must cover the total fee of the replaced txs")
+}
+
+func createTestClaimTx(t *testing.T, key []byte, nonce, fee, amount uint64, receiver []byte) *transaction.Transaction {
+
+	transfer := &wizard.WizardTxSimpleTransfer{
+		&wizard.WizardTxSimpleExtraClaim{nil, amount, receiver},
+		&wizard.WizardTransactionData{nil, false},
+		&wizard.WizardTransactionFee{fee, 0, 0, false},
+		nonce,
+		key,
+	}
+
+	tx, err := wizard.CreateSimpleTx(transfer, false, func(string) {})
+	assert.NoError(t, err)
+	return tx
+}
+
+func TestMempoolWorkerReplaceTx(t *testing.T) {
+
+	initTestMempoolStore(t)
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{Name: "blockchain", Opened: true, DB: db}
+
+	privateKey := addresses.GenerateNewPrivateKey()
+	receiver := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(writer)
+
+		plainAcc, err := dataStorage.CreatePlainAccount(privateKey.GeneratePublicKey(), false)
+		assert.NoError(t, err)
+		plainAcc.Nonce = 5
+		plainAcc.Unclaimed = 1000
+		assert.NoError(t, dataStorage.PlainAccs.Update(string(plainAcc.Key), plainAcc))
+
+		_, err = dataStorage.CreateRegistration(receiver, false, nil)
+		assert.NoError(t, err)
+
+		return dataStorage.CommitChanges()
+	}))
+
+	txs := createMempoolTxs()
+
+	newWorkCn := make(chan *mempoolWork)
+	addTransactionCn := make(chan *MempoolWorkerAddTx)
+	insertTransactionsCn := make(chan *MempoolWorkerInsertTxs)
+
+	worker := new(mempoolWorker)
+	go worker.processing(newWorkCn, make(chan struct{}), make(chan ContinueProcessingType), addTransactionCn, insertTransactionsCn, make(chan *MempoolWorkerRemoveTxs), make(chan *MempoolWorkerPing), txs)
+
+	result := &MempoolResult{txs: &generics.Value[[]*mempoolTx]{}}
+	result.txs.Store([]*mempoolTx{})
+	newWorkCn <- &mempoolWork{cryptography.RandomHash(), 10, result}
+
+	now := time.Now().Unix()
+	victim := &mempoolTx{Tx: createTestClaimTx(t, privateKey.Key, 5, 0, 1000, receiver), Added: now, FeePerByte: 0}
+
+	inserted := make(chan bool)
+	insertTransactionsCn <- &MempoolWorkerInsertTxs{[]*mempoolTx{victim}, inserted}
+	assert.True(t, <-inserted)
+
+	addTx := func(tx *mempoolTx) error {
+		answer := make(chan error, 1)
+		addTransactionCn <- &MempoolWorkerAddTx{tx, answer}
+		return <-answer
+	}
+
+	//spends more than the unclaimed funds of the sender, but pays a higher fee
+	stale := &mempoolTx{Tx: createTestClaimTx(t, privateKey.Key, 5, 100, 1000, receiver), Added: now, FeePerByte: 10}
+	assert.Error(t, addTx(stale), "a replacement that can't be included is rejected")
+	assert.True(t, txs.Exists(victim.Tx.Bloom.HashStr), "a stale replacement must not evict anything")
+	assert.False(t, txs.Exists(stale.Tx.Bloom.HashStr))
+
+	replacement := &mempoolTx{Tx: createTestClaimTx(t, privateKey.Key, 5, 100, 900, receiver), Added: now, FeePerByte: 10}
+	assert.NoError(t, addTx(replacement))
+	assert.False(t, txs.Exists(victim.Tx.Bloom.HashStr), "a valid replacement evicts the conflicting tx")
+	assert.True(t, txs.Exists(replacement.Tx.Bloom.HashStr))
+
+	//the store is written in background and must be finished before the next test replaces it
+	assert.Eventually(t, func() (persisted bool) {
+		assert.NoError(t, store.StoreMempool.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+			persisted = reader.Exists("mempoolTx:"+replacement.Tx.Bloom.HashStr) && !reader.Exists("mempoolTx:"+victim.Tx.Bloom.HashStr)
+			return nil
+		}))
+		return
+	}, time.Second, 10*time.Millisecond, "the replacement is persisted")
+}
@@ -0,0 +1,155 @@
+package mempool
+
+import (
+	"context"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_interface"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"time"
+)
+
+type mempoolStoredTx struct {
+	Tx          []byte `msgpack:"tx"`
+	Added       int64  `msgpack:"added"`
+	Mine        bool   `msgpack:"mine"`
+	ChainHeight uint64 `msgpack:"chainHeight"`
+}
+
+type mempoolStoreUpdate struct {
+	hashStr string
+	data    []byte //nil when the tx is removed
+}
+
+func (self *MempoolTxs) storeTx(tx *mempoolTx) {
+
+	data, err := msgpack.Marshal(&mempoolStoredTx{tx.Tx.Bloom.Serialized, tx.Added, tx.Mine, tx.ChainHeight})
+	if err != nil {
+		gui.Mempool().Error("Error storing mempool tx", err)
+		return
+	}
+
+	self.storeUpdatesCn <- &mempoolStoreUpdate{tx.Tx.Bloom.HashStr, data}
+}
+
+func (self *MempoolTxs) removeStoredTx(hashStr string) {
+	self.storeUpdatesCn <- &mempoolStoreUpdate{hashStr, nil}
+}
+
+func (self *MempoolTxs) processStoreUpdates() {
+	for {
+		self.writeStoreUpdates(<-self.storeUpdatesCn)
+	}
+}
+
+// writeStoreUpdates writes the update together with all the pending ones in a single db transaction
+func (self *MempoolTxs) writeStoreUpdates(update *mempoolStoreUpdate) {
+
+	updates := []*mempoolStoreUpdate{update}
+
+	for done := false; !done; {
+		select {
+		case update = <-self.storeUpdatesCn:
+			updates = append(updates, update)
+		default:
+			done = true
+		}
+	}
+
+	if err := store.StoreMempool.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		for _, update := range updates {
+			if update.data == nil {
+				writer.Delete("mempoolTx:" + update.hashStr)
+			} else {
+				writer.Put("mempoolTx:"+update.hashStr, update.data)
+			}
+		}
+		return nil
+	}); err != nil {
+		gui.Mempool().Error("Error storing mempool txs", err)
+	}
+}
+
+// loadStoredTxs reads the stored transactions. The expired and the invalid ones are returned to be removed
+func loadStoredTxs(expire int64) (txs []*transaction.Transaction, storedTxs []*mempoolStoredTx, removed []string, err error) {
+
+	err = store.StoreMempool.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+
+		iterable, ok := reader.(store_db_interface.StoreDBTransactionIterableInterface)
+		if !ok {
+			return nil
+		}
+
+		return iterable.IterateByPrefix("mempoolTx:", func(key string, value []byte) error {
+
+			hashStr := key[len("mempoolTx:"):]
+
+			storedTx := &mempoolStoredTx{}
+			if err := msgpack.Unmarshal(value, storedTx); err != nil || storedTx.Added < expire {
+				removed = append(removed, hashStr)
+				return nil
+			}
+
+			tx := &transaction.Transaction{}
+			if err := tx.Deserialize(advanced_buffers.NewBufferReader(storedTx.Tx)); err != nil {
+				removed = append(removed, hashStr)
+				return nil
+			}
+
+			txs = append(txs, tx)
+			storedTxs = append(storedTxs, storedTx)
+			return nil
+		})
+	})
+	return
+}
+
+// ReloadStoredTxs revalidates the transactions stored before the restart and broadcasts them again
+func (mempool *Mempool) ReloadStoredTxs(height uint64, ctx context.Context) error {
+
+	txs, storedTxs, removed, err := loadStoredTxs(time.Now().Unix() - config.MEMPOOL_TX_EXPIRE)
+	if err != nil {
+		return err
+	}
+
+	for _, hashStr := range removed {
+		mempool.Txs.removeStoredTx(hashStr)
+	}
+
+	if len(txs) == 0 {
+		return nil
+	}
+
+	finalTxs := make([]*mempoolTx, len(txs))
+	errs := make([]error, len(txs))
+
+	//processed one by one as an invalid tx stops the processing
+	for i, tx := range txs {
+
+		processedTxs, processedErrs := mempool.processTxsToMempool([]*transaction.Transaction{tx}, height, ctx)
+		if finalTxs[i], errs[i] = processedTxs[0], processedErrs[0]; finalTxs[i] != nil {
+			finalTxs[i].Added = storedTxs[i].Added
+			finalTxs[i].Mine = storedTxs[i].Mine
+			finalTxs[i].ChainHeight = storedTxs[i].ChainHeight
+		}
+	}
+
+	errs = mempool.addProcessedTxsToMempool(finalTxs, errs, false, true, false, advanced_connection_types.UUID_ALL, ctx)
+
+	count := 0
+	for i, tx := range txs {
+		if errs[i] != nil {
+			mempool.Txs.removeStoredTx(tx.Bloom.HashStr)
+		} else if finalTxs[i] != nil {
+			count++
+		}
+	}
+
+	gui.Mempool().Info("Mempool reloaded", gui_interface.Field("txs", count))
+	return nil
+}
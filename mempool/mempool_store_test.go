@@ -0,0 +1,129 @@
+package mempool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"testing"
+	"time"
+)
+
+func initTestMempoolStore(t *testing.T) {
+	db, err := store_db_memory.CreateStoreDBMemory("mempool")
+	assert.NoError(t, err)
+	store.StoreMempool = &store.Store{Name: "mempool", Opened: true, DB: db}
+}
+
+func createTestTx(t *testing.T, nonce uint64) *transaction.Transaction {
+	return createTestTxFrom(t, addresses.GenerateNewPrivateKey().Key, nonce, 0)
+}
+
+func createTestTxFrom(t *testing.T, key []byte, nonce, fee uint64) *transaction.Transaction {
+
+	transfer := &wizard.WizardTxSimpleTransfer{
+		&wizard.WizardTxSimpleExtraClaim{nil, 1000, addresses.GenerateNewPrivateKey().GeneratePublicKey()},
+		&wizard.WizardTransactionData{nil, false},
+		&wizard.WizardTransactionFee{fee, 0, 0, false},
+		nonce,
+		key,
+	}
+
+	tx, err := wizard.CreateSimpleTx(transfer, false, func(string) {})
+	assert.NoError(t, err)
+	return tx
+}
+
+func TestLoadStoredTxs(t *testing.T) {
+
+	initTestMempoolStore(t)
+
+	now := time.Now().Unix()
+	recent, expired := createTestTx(t, 1), createTestTx(t, 2)
+
+	assert.NoError(t, store.StoreMempool.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		for _, it := range []struct {
+			tx    *transaction.Transaction
+			added int64
+		}{{recent, now}, {expired, now - config.MEMPOOL_TX_EXPIRE - 10}} {
+			data, err := msgpack.Marshal(&mempoolStoredTx{it.tx.Bloom.Serialized, it.added, true, 5})
+			assert.NoError(t, err)
+			writer.Put("mempoolTx:"+it.tx.Bloom.HashStr, data)
+		}
+		writer.Put("mempoolTx:corrupted", []byte{1, 2, 3})
+		return nil
+	}))
+
+	txs, storedTxs, removed, err := loadStoredTxs(now - config.MEMPOOL_TX_EXPIRE)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(txs))
+	assert.Equal(t, recent.Bloom.Hash, txs[0].Bloom.Hash)
+	assert.True(t, storedTxs[0].Mine)
+	assert.Equal(t, uint64(5), storedTxs[0].ChainHeight)
+	assert.ElementsMatch(t, []string{expired.Bloom.HashStr, "corrupted"}, removed)
+}
+
+func TestWriteStoreUpdates(t *testing.T) {
+
+	initTestMempoolStore(t)
+
+	txs := &MempoolTxs{storeUpdatesCn: make(chan *mempoolStoreUpdate, 10)}
+
+	first := &mempoolTx{Tx: createTestTx(t, 1), Added: time.Now().Unix()}
+	second := &mempoolTx{Tx: createTestTx(t, 2), Added: time.Now().Unix()}
+
+	txs.storeTx(first)
+	txs.storeTx(second)
+	txs.removeStoredTx(first.Tx.Bloom.HashStr)
+
+	txs.writeStoreUpdates(<-txs.storeUpdatesCn)
+	assert.Equal(t, 0, len(txs.storeUpdatesCn), "the pending updates are written in the same batch")
+
+	assert.NoError(t, store.StoreMempool.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.False(t, reader.Exists("mempoolTx:"+first.Tx.Bloom.HashStr), "the updates are applied in order")
+		assert.True(t, reader.Exists("mempoolTx:"+second.Tx.Bloom.HashStr))
+		return nil
+	}))
+}
+
+func TestMempoolWorkerExpireTxs(t *testing.T) {
+
+	initTestMempoolStore(t)
+
+	txs := createMempoolTxs()
+
+	newWorkCn := make(chan *mempoolWork)
+	suspendProcessingCn := make(chan struct{})
+	insertTransactionsCn := make(chan *MempoolWorkerInsertTxs)
+	pingCn := make(chan *MempoolWorkerPing)
+
+	worker := new(mempoolWorker)
+	go worker.processing(newWorkCn, suspendProcessingCn, make(chan ContinueProcessingType), make(chan *MempoolWorkerAddTx), insertTransactionsCn, make(chan *MempoolWorkerRemoveTxs), pingCn, txs)
+
+	now := time.Now().Unix()
+	recent := &mempoolTx{Tx: createTestTx(t, 1), Added: now, FeePerByte: 10}
+	expired := &mempoolTx{Tx: createTestTx(t, 2), Added: now - config.MEMPOOL_TX_EXPIRE - 10, FeePerByte: 10}
+
+	result := make(chan bool)
+	insertTransactionsCn <- &MempoolWorkerInsertTxs{[]*mempoolTx{recent, expired}, result}
+	assert.True(t, <-result)
+	assert.True(t, txs.Exists(expired.Tx.Bloom.HashStr))
+
+	//the transactions are not processed for the new work while suspended
+	suspendProcessingCn <- struct{}{}
+	newWorkCn <- &mempoolWork{cryptography.RandomHash(), 10, nil}
+
+	pong := make(chan struct{}, 1)
+	pingCn <- &MempoolWorkerPing{pong}
+	<-pong
+
+	assert.True(t, txs.Exists(recent.Tx.Bloom.HashStr))
+	assert.False(t, txs.Exists(expired.Tx.Bloom.HashStr), "expired transactions are removed on a new work")
+}
@@ -6,8 +6,10 @@ import (
 	"pandora-pay/blockchain/data_storage"
 	"pandora-pay/config"
 	"pandora-pay/store"
+	"pandora-pay/store/min_max_heap"
 	"pandora-pay/store/store_db/store_db_interface"
 	"sync/atomic"
+	"time"
 )
 
 type mempoolWork struct {
@@ -35,6 +37,10 @@ type MempoolWorkerInsertTxs struct {
 	Result chan<- bool
 }
 
+type MempoolWorkerPing struct {
+	Result chan<- struct{} //buffered as the caller may stop waiting for the answer
+}
+
 // process the worker for transactions to prepare the transactions to the forger
 func (worker *mempoolWorker) processing(
 	newWorkCn <-chan *mempoolWork,
@@ -43,6 +49,7 @@ func (worker *mempoolWorker) processing(
 	addTransactionCn <-chan *MempoolWorkerAddTx,
 	insertTransactionsCn <-chan *MempoolWorkerInsertTxs,
 	removeTransactionsCn <-chan *MempoolWorkerRemoveTxs,
+	pingCn <-chan *MempoolWorkerPing,
 	txs *MempoolTxs,
 ) {
 
@@ -56,29 +63,132 @@ func (worker *mempoolWorker) processing(
 	includedTotalSize := uint64(0)
 	includedTxs := []*mempoolTx{}
 
-	resetNow := func(newWork *mempoolWork) {
+	//transactions ordered by the fee per byte. The lowest ones are evicted when the mempool is full
+	feeHeap := min_max_heap.NewMinMemoryHeap("mempool")
+	resetIncluded := false
 
-		if newWork.chainHash != nil {
-			dataStorage = nil
-			work = newWork
-			includedTotalSize = uint64(0)
-			includedTxs = []*mempoolTx{}
-			listIndex = 0
-			if len(txsList) > 1 {
-				sortTxs(txsList)
+	//peers are asked for a higher fee when the mempool is almost full
+	updateMinFee := func() {
+		minFee := uint64(0)
+		if uint64(len(txsMap)) >= config.MEMPOOL_MAX_TXS*9/10 || txs.GetSize() >= config.MEMPOOL_MAX_BYTES*9/10 {
+			if top, _ := feeHeap.GetTop(); top != nil {
+				minFee = uint64(top.Score) + 1
 			}
 		}
+		atomic.StoreUint64(&txs.minFeePerByte, minFee)
 	}
 
-	removeTxNow := func(tx *mempoolTx, txWasInserted bool, includedInBlockchainNotification bool) {
+	resetIncludedNow := func() {
+		resetIncluded = false
+		dataStorage = nil
+		listIndex = 0
+		includedTotalSize = uint64(0)
+		includedTxs = []*mempoolTx{}
+		if work != nil {
+			atomic.StoreUint64(&work.result.totalSize, includedTotalSize)
+			work.result.txs.Store(includedTxs)
+		}
+	}
+
+	//transactions spending the same nonce or the same balance
+	conflictsMap := make(map[string]map[string]*mempoolTx)
+
+	removeTxNow := func(tx *mempoolTx, txWasInserted bool, includedInBlockchainNotification bool, replacedBy []byte) {
 
 		delete(txsMap, tx.Tx.Bloom.HashStr)
 
 		if txWasInserted {
+			for _, key := range tx.conflictKeys {
+				if conflicts := conflictsMap[key]; conflicts != nil {
+					delete(conflicts, tx.Tx.Bloom.HashStr)
+					if len(conflicts) == 0 {
+						delete(conflictsMap, key)
+					}
+				}
+			}
+			feeHeap.DeleteByKey([]byte(tx.Tx.Bloom.HashStr))
 			txs.deleteTx(tx.Tx.Bloom.HashStr)
-			txs.deleted(tx, txWasInserted, includedInBlockchainNotification)
+			txs.deleted(tx, txWasInserted, includedInBlockchainNotification, replacedBy)
+			updateMinFee()
+		}
+	}
+
+	insertTxNow := func(tx *mempoolTx) {
+		txsMap[tx.Tx.Bloom.HashStr] = tx
+		if tx.conflictKeys == nil {
+			tx.conflictKeys = getConflictKeys(tx.Tx)
+		}
+		for _, key := range tx.conflictKeys {
+			if conflictsMap[key] == nil {
+				conflictsMap[key] = make(map[string]*mempoolTx)
+			}
+			conflictsMap[key][tx.Tx.Bloom.HashStr] = tx
+		}
+		feeHeap.Insert(float64(tx.FeePerByte), []byte(tx.Tx.Bloom.HashStr))
+		txs.insertTx(tx)
+		txs.inserted(tx)
+		updateMinFee()
+	}
+
+	evictTx := func(tx *mempoolTx, replacedBy []byte) {
+		if index := slices.Index(txsList, tx); index >= 0 {
+			txsList = slices.Delete(txsList, index, index+1)
+			if index < listIndex {
+				listIndex--
+				//the result of the forger must be computed again without it
+				if slices.Index(includedTxs, tx) >= 0 {
+					resetIncluded = true
+				}
+			}
+		}
+		removeTxNow(tx, true, false, replacedBy)
+	}
+
+	getReplacedTxs := func(tx *mempoolTx) (replaced []*mempoolTx) {
+		found := make(map[string]bool)
+		for _, key := range getConflictKeys(tx.Tx) {
+			for hash, conflict := range conflictsMap[key] {
+				if !found[hash] {
+					found[hash] = true
+					replaced = append(replaced, conflict)
+				}
+			}
+		}
+		return
+	}
+
+	//a replacement is checked against the chain state before evicting the txs it conflicts with, as a stale tx would evict them for free
+	validateReplacement := func(tx *mempoolTx, dbTx store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if dbTx.Exists("txHash:" + string(tx.Tx.Bloom.HashStr)) {
+			return errors.New("Tx is already included in blockchain")
+		}
+
+		defer func() {
+			if errReturned := recover(); errReturned != nil {
+				err = errReturned.(error)
+			}
+		}()
+
+		return tx.Tx.IncludeTransaction(work.chainHeight, data_storage.NewDataStorage(dbTx))
+	}
 
+	//checks if the tx fits in the mempool by evicting the transactions with a lower fee per byte
+	makeRoom := func(tx *mempoolTx, evict bool) error {
+		for uint64(len(txsMap)) >= config.MEMPOOL_MAX_TXS || txs.GetSize()+tx.Tx.Bloom.Size > config.MEMPOOL_MAX_BYTES {
+			top, err := feeHeap.GetTop()
+			if err != nil {
+				return err
+			}
+			if top == nil || uint64(top.Score) >= tx.FeePerByte {
+				return errors.New("Mempool is full")
+			}
+			if !evict {
+				return nil
+			}
+			evictTx(txsMap[string(top.Key)], nil)
 		}
+		return nil
 	}
 
 	removeTxs := func(data *MempoolWorkerRemoveTxs) {
@@ -88,7 +198,7 @@ func (worker *mempoolWorker) processing(
 			if hash != "" {
 				if tx := txsMap[hash]; tx != nil {
 					removedTxsMap[hash] = true
-					removeTxNow(tx, true, true)
+					removeTxNow(tx, true, true, nil)
 				}
 			}
 		}
@@ -120,13 +230,43 @@ func (worker *mempoolWorker) processing(
 		data.Result <- len(removedTxsMap) > 0
 	}
 
+	//the transactions older than config.MEMPOOL_TX_EXPIRE are removed, as they would not be reloaded after a restart either
+	expireTxs := func() {
+		expire := time.Now().Unix() - config.MEMPOOL_TX_EXPIRE
+		newList := make([]*mempoolTx, 0, len(txsList))
+		for _, tx := range txsList {
+			if tx.Added < expire {
+				removeTxNow(tx, true, false, nil)
+			} else {
+				newList = append(newList, tx)
+			}
+		}
+		txsList = newList
+	}
+
+	resetNow := func(newWork *mempoolWork) {
+
+		if newWork.chainHash != nil {
+			dataStorage = nil
+			work = newWork
+			includedTotalSize = uint64(0)
+			includedTxs = []*mempoolTx{}
+			listIndex = 0
+			expireTxs()
+			if len(txsList) > 1 {
+				sortTxs(txsList)
+			}
+		}
+	}
+
 	insertTxs := func(data *MempoolWorkerInsertTxs) {
 		result := false
 		for _, tx := range data.Txs {
 			if tx != nil && txsMap[tx.Tx.Bloom.HashStr] == nil {
-				txsMap[tx.Tx.Bloom.HashStr] = tx
-				txs.insertTx(tx)
-				txs.inserted(tx)
+				if makeRoom(tx, true) != nil {
+					continue
+				}
+				insertTxNow(tx)
 				txsList = append(txsList, tx)
 				result = true
 			}
@@ -147,6 +287,8 @@ func (worker *mempoolWorker) processing(
 			removeTxs(data)
 		case data := <-insertTransactionsCn:
 			insertTxs(data)
+		case data := <-pingCn:
+			data.Result <- struct{}{}
 		case continueProcessingType := <-continueProcessingCn:
 
 			suspended = false
@@ -178,6 +320,10 @@ func (worker *mempoolWorker) processing(
 
 			for {
 
+				if resetIncluded {
+					resetIncludedNow()
+				}
+
 				if dataStorage == nil {
 					dataStorage = data_storage.NewDataStorage(dbTx)
 				}
@@ -196,13 +342,42 @@ func (worker *mempoolWorker) processing(
 						removeTxs(data)
 					case data := <-insertTransactionsCn:
 						insertTxs(data)
+					case data := <-pingCn:
+						data.Result <- struct{}{}
 					case newAddTx = <-addTransactionCn:
-						if txsMap[tx.Tx.Bloom.HashStr] != nil {
+						if txsMap[newAddTx.Tx.Tx.Bloom.HashStr] != nil {
 							if newAddTx.Result != nil {
 								newAddTx.Result <- nil //no error, already included in mempool
 							}
 							continue
 						}
+						if replaced := getReplacedTxs(newAddTx.Tx); len(replaced) > 0 {
+							//the replacement is processed again with all the others as the replaced txs could have been already included
+							errReplace := canReplaceTxs(newAddTx.Tx, replaced)
+							if errReplace == nil {
+								errReplace = validateReplacement(newAddTx.Tx, dbTx)
+							}
+							if errReplace == nil {
+								for _, replacedTx := range replaced {
+									evictTx(replacedTx, newAddTx.Tx.Tx.Bloom.Hash)
+								}
+								if errReplace = makeRoom(newAddTx.Tx, true); errReplace == nil {
+									txsList = append(txsList, newAddTx.Tx)
+									insertTxNow(newAddTx.Tx)
+									resetIncluded = true
+								}
+							}
+							if newAddTx.Result != nil {
+								newAddTx.Result <- errReplace
+							}
+							continue
+						}
+						if errFull := makeRoom(newAddTx.Tx, false); errFull != nil {
+							if newAddTx.Result != nil {
+								newAddTx.Result <- errFull
+							}
+							continue
+						}
 						tx = newAddTx.Tx
 					}
 				} else {
@@ -216,6 +391,8 @@ func (worker *mempoolWorker) processing(
 						removeTxs(data)
 					case data := <-insertTransactionsCn:
 						insertTxs(data)
+					case data := <-pingCn:
+						data.Result <- struct{}{}
 					default:
 						tx = txsList[listIndex]
 						listIndex += 1
@@ -223,6 +400,11 @@ func (worker *mempoolWorker) processing(
 
 				}
 
+				//the other channels don't provide a tx to process
+				if tx == nil {
+					continue
+				}
+
 				var finalErr error
 				var exists bool
 
@@ -262,11 +444,14 @@ func (worker *mempoolWorker) processing(
 							}
 
 							if newAddTx != nil {
+								//evicting only after the tx was accepted
+								if err = makeRoom(tx, true); err != nil {
+									resetIncluded = true
+									return
+								}
 								listIndex += 1
 								txsList = append(txsList, newAddTx.Tx)
-								txsMap[tx.Tx.Bloom.HashStr] = newAddTx.Tx
-								txs.insertTx(tx)
-								txs.inserted(tx)
+								insertTxNow(tx)
 							}
 
 						}
@@ -281,7 +466,7 @@ func (worker *mempoolWorker) processing(
 							txsList = slices.Delete(txsList, listIndex-1, listIndex)
 							listIndex--
 						}
-						removeTxNow(tx, newAddTx == nil, exists)
+						removeTxNow(tx, newAddTx == nil, exists, nil)
 					}
 
 				}
@@ -23,21 +23,28 @@ type MempoolAccountTxs struct {
 }
 
 type MempoolTxs struct {
+	size                      uint64 //total size in bytes of the transactions
+	minFeePerByte             uint64 //minimum fee per byte accepted when the mempool is almost full
 	count                     int32
 	txsMap                    *generics.Map[string, *mempoolTx]
 	accountsMapTxs            *generics.Map[string, *MempoolAccountTxs]
 	UpdateMempoolTransactions *multicast.MulticastChannel[*blockchain_types.MempoolTransactionUpdate]
+	storeUpdatesCn            chan *mempoolStoreUpdate
 }
 
 func (self *MempoolTxs) insertTx(tx *mempoolTx) bool {
 	_, loaded := self.txsMap.LoadOrStore(tx.Tx.Bloom.HashStr, tx)
 	if !loaded {
 		atomic.AddInt32(&self.count, 1)
+		atomic.AddUint64(&self.size, tx.Tx.Bloom.Size)
 	}
 	return !loaded
 }
 
 func (self *MempoolTxs) inserted(tx *mempoolTx) {
+
+	self.storeTx(tx)
+
 	if config.SEED_WALLET_NODES_INFO {
 
 		keys := tx.Tx.GetAllKeys()
@@ -65,20 +72,25 @@ func (self *MempoolTxs) inserted(tx *mempoolTx) {
 			tx.Tx,
 			false,
 			keys,
+			nil,
 		})
 
 	}
 }
 
 func (self *MempoolTxs) deleteTx(hashStr string) bool {
-	_, deleted := self.txsMap.LoadAndDelete(hashStr)
+	tx, deleted := self.txsMap.LoadAndDelete(hashStr)
 	if deleted {
 		atomic.AddInt32(&self.count, -1)
+		atomic.AddUint64(&self.size, -tx.Tx.Bloom.Size)
 	}
 	return deleted
 }
 
-func (self *MempoolTxs) deleted(tx *mempoolTx, broadcastNotifications, includedInBlockchainNotification bool) {
+func (self *MempoolTxs) deleted(tx *mempoolTx, broadcastNotifications, includedInBlockchainNotification bool, replacedBy []byte) {
+
+	self.removeStoredTx(tx.Tx.Bloom.HashStr)
+
 	if config.SEED_WALLET_NODES_INFO {
 
 		keys := tx.Tx.GetAllKeys()
@@ -101,6 +113,7 @@ func (self *MempoolTxs) deleted(tx *mempoolTx, broadcastNotifications, includedI
 				tx.Tx,
 				includedInBlockchainNotification,
 				keys,
+				replacedBy,
 			})
 		}
 
@@ -142,6 +155,19 @@ func (self *MempoolTxs) GetTxsOnlyList() []*transaction.Transaction {
 	return out
 }
 
+func (self *MempoolTxs) GetCount() int32 {
+	return atomic.LoadInt32(&self.count)
+}
+
+func (self *MempoolTxs) GetSize() uint64 {
+	return atomic.LoadUint64(&self.size)
+}
+
+// GetMinFeePerByte returns the dynamic minimum fee per byte. 0 means the mempool is not full and the default fees are used
+func (self *MempoolTxs) GetMinFeePerByte() uint64 {
+	return atomic.LoadUint64(&self.minFeePerByte)
+}
+
 func (self *MempoolTxs) Exists(txId string) bool {
 	_, loaded := self.txsMap.Load(txId)
 	return loaded
@@ -181,23 +207,28 @@ func (self *MempoolTxs) GetAccountTxs(publicKey []byte) []*mempoolTx {
 func createMempoolTxs() (txs *MempoolTxs) {
 
 	txs = &MempoolTxs{
+		0,
+		0,
 		0,
 		&generics.Map[string, *mempoolTx]{},
 		&generics.Map[string, *MempoolAccountTxs]{},
 		multicast.NewMulticastChannel[*blockchain_types.MempoolTransactionUpdate](),
+		make(chan *mempoolStoreUpdate, 1000),
 	}
 
+	recovery.SafeGo(txs.processStoreUpdates)
+
 	//printing from time to time the mempool
 	if config.DEBUG {
 		recovery.SafeGo(func() {
 			for {
 				transactions := txs.GetTxsFromMap()
 				if len(transactions) != 0 {
-					gui.GUI.Log("")
+					gui.Mempool().Log("")
 					for _, out := range transactions {
-						gui.GUI.Log(fmt.Sprintf("%12s %7d B %5d %15s", time.Unix(out.Added, 0).UTC().Format(time.RFC822), out.Tx.Bloom.Size, out.ChainHeight, base64.StdEncoding.EncodeToString(out.Tx.Bloom.Hash[0:15])))
+						gui.Mempool().Log(fmt.Sprintf("%12s %7d B %5d %15s", time.Unix(out.Added, 0).UTC().Format(time.RFC822), out.Tx.Bloom.Size, out.ChainHeight, base64.StdEncoding.EncodeToString(out.Tx.Bloom.Hash[0:15])))
 					}
-					gui.GUI.Log("")
+					gui.Mempool().Log("")
 				}
 				time.Sleep(60 * time.Second)
 			}
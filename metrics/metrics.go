@@ -0,0 +1,161 @@
+package metrics
+
+import (
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// series is a single time series of a family. The labels are already formatted like `key="value"`
+type series interface {
+	write(w io.Writer, name string)
+}
+
+type family struct {
+	name   string
+	help   string
+	kind   string
+	series []series
+}
+
+var (
+	families     []*family
+	familiesLock sync.RWMutex
+)
+
+var DefaultBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+func register(name, help, kind string, s series) {
+	familiesLock.Lock()
+	defer familiesLock.Unlock()
+
+	for _, f := range families {
+		if f.name == name {
+			f.series = append(f.series, s)
+			return
+		}
+	}
+	families = append(families, &family{name, help, kind, []series{s}})
+}
+
+func formatName(name, labels string) string {
+	if labels == "" {
+		return name
+	}
+	return name + "{" + labels + "}"
+}
+
+func formatValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+type Gauge struct {
+	labels string
+	bits   uint64 //use atomic
+}
+
+func (g *Gauge) Set(value float64) {
+	atomic.StoreUint64(&g.bits, math.Float64bits(value))
+}
+
+func (g *Gauge) Add(delta float64) {
+	for {
+		old := atomic.LoadUint64(&g.bits)
+		if atomic.CompareAndSwapUint64(&g.bits, old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+func (g *Gauge) Get() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&g.bits))
+}
+
+func (g *Gauge) write(w io.Writer, name string) {
+	io.WriteString(w, formatName(name, g.labels)+" "+formatValue(g.Get())+"\n")
+}
+
+type gaugeFunc struct {
+	labels   string
+	callback func() float64
+}
+
+func (g *gaugeFunc) write(w io.Writer, name string) {
+	io.WriteString(w, formatName(name, g.labels)+" "+formatValue(g.callback())+"\n")
+}
+
+type Histogram struct {
+	labels  string
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+	lock    sync.Mutex
+}
+
+func (h *Histogram) Observe(value float64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for i, bucket := range h.buckets {
+		if value <= bucket {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += value
+}
+
+func (h *Histogram) write(w io.Writer, name string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	prefix := ""
+	if h.labels != "" {
+		prefix = h.labels + ","
+	}
+
+	for i, bucket := range h.buckets {
+		io.WriteString(w, name+"_bucket{"+prefix+"le=\""+formatValue(bucket)+"\"} "+strconv.FormatUint(h.counts[i], 10)+"\n")
+	}
+	io.WriteString(w, name+"_bucket{"+prefix+"le=\"+Inf\"} "+strconv.FormatUint(h.count, 10)+"\n")
+	io.WriteString(w, formatName(name+"_sum", h.labels)+" "+formatValue(h.sum)+"\n")
+	io.WriteString(w, formatName(name+"_count", h.labels)+" "+strconv.FormatUint(h.count, 10)+"\n")
+}
+
+func NewGauge(name, help, labels string) *Gauge {
+	g := &Gauge{labels: labels}
+	register(name, help, "gauge", g)
+	return g
+}
+
+// NewGaugeFunc registers a gauge whose value is read when the metrics are exported
+func NewGaugeFunc(name, help, labels string, callback func() float64) {
+	register(name, help, "gauge", &gaugeFunc{labels, callback})
+}
+
+func NewHistogram(name, help, labels string, buckets []float64) *Histogram {
+	h := &Histogram{labels: labels, buckets: buckets, counts: make([]uint64, len(buckets))}
+	register(name, help, "histogram", h)
+	return h
+}
+
+// Write exports all the metrics using the Prometheus text format
+func Write(w io.Writer) {
+	familiesLock.RLock()
+	defer familiesLock.RUnlock()
+
+	for _, f := range families {
+		io.WriteString(w, "# HELP "+f.name+" "+strings.ReplaceAll(f.help, "\n", " ")+"\n")
+		io.WriteString(w, "# TYPE "+f.name+" "+f.kind+"\n")
+		for _, s := range f.series {
+			s.write(w, f.name)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package metrics
+
+var (
+	TxsValidationDuration  = NewHistogram("pandora_txs_validation_seconds", "Duration of the validation of a transaction", "", DefaultBuckets)
+	ForgingHashesPerSecond = NewGauge("pandora_forging_hashes_per_second", "Kernel hashes computed per second by the forging workers", "")
+	BalanceDecryptorQueue  = NewGauge("pandora_balance_decryptor_queue", "Balances waiting to be decrypted", "")
+	WebsocketSubscriptions = NewGauge("pandora_websocket_subscriptions", "Subscriptions of the websocket connections", "")
+)
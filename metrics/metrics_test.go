@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+
+	gauge := NewGauge("test_gauge", "Test gauge", `type="a"`)
+	gauge.Set(2)
+	gauge.Add(0.5)
+
+	NewGaugeFunc("test_gauge", "Test gauge", `type="b"`, func() float64 { return 7 })
+
+	histogram := NewHistogram("test_histogram", "Test histogram", "", []float64{1, 5})
+	histogram.Observe(0.5)
+	histogram.Observe(3)
+	histogram.Observe(10)
+
+	w := &bytes.Buffer{}
+	Write(w)
+	out := w.String()
+
+	assert.Equal(t, 1, strings.Count(out, "# TYPE test_gauge gauge\n"))
+	assert.Contains(t, out, "test_gauge{type=\"a\"} 2.5\n")
+	assert.Contains(t, out, "test_gauge{type=\"b\"} 7\n")
+	assert.Contains(t, out, "# TYPE test_histogram histogram\n")
+	assert.Contains(t, out, "test_histogram_bucket{le=\"1\"} 1\n")
+	assert.Contains(t, out, "test_histogram_bucket{le=\"5\"} 2\n")
+	assert.Contains(t, out, "test_histogram_bucket{le=\"+Inf\"} 3\n")
+	assert.Contains(t, out, "test_histogram_sum 13.5\n")
+	assert.Contains(t, out, "test_histogram_count 3\n")
+}
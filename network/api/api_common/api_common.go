@@ -7,9 +7,11 @@ import (
 	"pandora-pay/config"
 	"pandora-pay/config/config_nodes"
 	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/rate_limiter"
 	"pandora-pay/mempool"
 	"pandora-pay/network/api/api_common/api_delegator_node"
 	"pandora-pay/network/api/api_common/api_faucet"
+	"pandora-pay/network/banned_nodes"
 	"pandora-pay/network/known_nodes"
 	"pandora-pay/recovery"
 	"pandora-pay/txs_builder"
@@ -31,6 +33,7 @@ type APICommon struct {
 	chain                     *blockchain.Blockchain
 	wallet                    *wallet.Wallet
 	knownNodes                *known_nodes.KnownNodes
+	bannedNodes               *banned_nodes.BannedNodes
 	localChain                *generics.Value[*APIBlockchain]
 	localChainSync            *generics.Value[*blockchain_sync.BlockchainSyncData]
 	Faucet                    *api_faucet.Faucet
@@ -39,6 +42,7 @@ type APICommon struct {
 	mempoolProcessedThisBlock *generics.Value[*generics.Map[string, *mempoolNewTxReply]]
 	temporaryList             *generics.Value[*APINetworkNodesReply]
 	temporaryListCreation     *generics.Value[time.Time]
+	NewTxsRateLimiters        *rate_limiter.RateLimiters //mempool/new-tx limited per remote address, shared by the HTTP and RPC servers
 }
 
 //make sure it is safe to read
@@ -65,7 +69,7 @@ func (api *APICommon) readLocalBlockchainSync(newLocalSync *blockchain_sync.Bloc
 	api.localChainSync.Store(newLocalSync)
 }
 
-func NewAPICommon(knownNodes *known_nodes.KnownNodes, mempool *mempool.Mempool, chain *blockchain.Blockchain, wallet *wallet.Wallet, txsValidator *txs_validator.TxsValidator, txsBuilder *txs_builder.TxsBuilder, apiStore *APIStore) (api *APICommon, err error) {
+func NewAPICommon(knownNodes *known_nodes.KnownNodes, bannedNodes *banned_nodes.BannedNodes, mempool *mempool.Mempool, chain *blockchain.Blockchain, wallet *wallet.Wallet, txsValidator *txs_validator.TxsValidator, txsBuilder *txs_builder.TxsBuilder, apiStore *APIStore) (api *APICommon, err error) {
 
 	var faucet *api_faucet.Faucet
 	if config.NETWORK_SELECTED == config.TEST_NET_NETWORK_BYTE || config.NETWORK_SELECTED == config.DEV_NET_NETWORK_BYTE {
@@ -76,7 +80,9 @@ func NewAPICommon(knownNodes *known_nodes.KnownNodes, mempool *mempool.Mempool,
 
 	var delegatorNode *api_delegator_node.DelegatorNode
 	if config_nodes.DELEGATOR_ENABLED {
-		delegatorNode = api_delegator_node.NewDelegatorNode(chain, wallet)
+		if delegatorNode, err = api_delegator_node.NewDelegatorNode(chain, wallet, mempool, txsBuilder); err != nil {
+			return
+		}
 	}
 
 	api = &APICommon{
@@ -86,6 +92,7 @@ func NewAPICommon(knownNodes *known_nodes.KnownNodes, mempool *mempool.Mempool,
 		chain,
 		wallet,
 		knownNodes,
+		bannedNodes,
 		&generics.Value[*APIBlockchain]{},
 		&generics.Value[*blockchain_sync.BlockchainSyncData]{},
 		faucet,
@@ -94,6 +101,7 @@ func NewAPICommon(knownNodes *known_nodes.KnownNodes, mempool *mempool.Mempool,
 		&generics.Value[*generics.Map[string, *mempoolNewTxReply]]{},
 		&generics.Value[*APINetworkNodesReply]{},
 		&generics.Value[time.Time]{},
+		rate_limiter.NewRateLimiters(config.MEMPOOL_NEW_TXS_RATE_LIMIT, config.MEMPOOL_NEW_TXS_RATE_BURST),
 	}
 
 	api.temporaryListCreation.Store(time.Now())
@@ -0,0 +1,53 @@
+package api_delegator_node
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/addresses"
+	"pandora-pay/helpers"
+)
+
+type ApiDelegatorNodeDelegate struct {
+	Address          string         `json:"address" msgpack:"address"`
+	PublicKey        helpers.Base64 `json:"publicKey" msgpack:"publicKey"`
+	SpendPublicKey   helpers.Base64 `json:"spendPublicKey,omitempty" msgpack:"spendPublicKey,omitempty"`
+	Stake            uint64         `json:"stake" msgpack:"stake"`
+	AddedHeight      uint64         `json:"addedHeight" msgpack:"addedHeight"`
+	LastActiveHeight uint64         `json:"lastActiveHeight" msgpack:"lastActiveHeight"`
+}
+
+type ApiDelegatorNodeDelegatesReply struct {
+	Delegates []*ApiDelegatorNodeDelegate `json:"delegates" msgpack:"delegates"`
+}
+
+func (api *DelegatorNode) GetDelegatorNodeDelegates(r *http.Request, args *struct{}, reply *ApiDelegatorNodeDelegatesReply, authenticated bool) error {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	api.delegates.lock.Lock()
+	defer api.delegates.lock.Unlock()
+
+	sorted := api.delegates.sorted()
+
+	reply.Delegates = make([]*ApiDelegatorNodeDelegate, len(sorted))
+	for i, delegate := range sorted {
+
+		addr, err := addresses.CreateAddr(delegate.PublicKey, true, delegate.SpendPublicKey, nil, nil, 0, nil)
+		if err != nil {
+			return err
+		}
+
+		reply.Delegates[i] = &ApiDelegatorNodeDelegate{
+			addr.EncodeAddr(),
+			delegate.PublicKey,
+			delegate.SpendPublicKey,
+			delegate.Stake,
+			delegate.AddedHeight,
+			delegate.LastActiveHeight,
+		}
+	}
+
+	return nil
+}
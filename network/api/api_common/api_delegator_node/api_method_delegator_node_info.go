@@ -10,11 +10,17 @@ type ApiDelegatorNodeInfoReply struct {
 	MaximumAllowed int    `json:"maximumAllowed" msgpack:"maximumAllowed"`
 	DelegatesCount int    `json:"delegatesCount" msgpack:"delegatesCount"`
 	Blocks         uint64 `json:"blocks" msgpack:"blocks"`
+	Pool           bool   `json:"pool" msgpack:"pool"`
+	PoolFee        uint64 `json:"poolFee,omitempty" msgpack:"poolFee,omitempty"` //basis points
 }
 
 func (api *DelegatorNode) GetDelegatorNodeInfo(r *http.Request, args *struct{}, reply *ApiDelegatorNodeInfoReply) error {
 	reply.MaximumAllowed = config_nodes.DELEGATES_MAXIMUM
 	reply.DelegatesCount = api.wallet.GetDelegatesCount()
 	reply.Blocks = atomic.LoadUint64(&api.chainHeight)
+	if api.pool != nil {
+		reply.Pool = true
+		reply.PoolFee = config_nodes.DELEGATOR_POOL_FEE
+	}
 	return nil
 }
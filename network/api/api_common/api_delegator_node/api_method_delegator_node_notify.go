@@ -15,8 +15,6 @@ import (
 	"pandora-pay/helpers"
 	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
-	"pandora-pay/wallet/wallet_address"
-	"pandora-pay/wallet/wallet_address/shared_staked"
 )
 
 type ApiDelegatorNodeNotifyRequest struct {
@@ -41,12 +39,13 @@ func (api *DelegatorNode) DelegatorNotify(r *http.Request, args *ApiDelegatorNod
 	sharedStakedPublicKey := sharedStakedPrivateKey.GeneratePublicKey()
 
 	addr := api.wallet.GetWalletAddressByPublicKey(sharedStakedPublicKey, true)
-	if addr != nil && addr.PrivateKey == nil {
+	if addr != nil && (addr.PrivateKey == nil || !addr.IsSharedStaked) {
 		reply.Result = true
 		return
 	}
 
 	var acc *account.Account
+	var reg *registration.Registration
 	var chainHeight uint64
 
 	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
@@ -54,7 +53,6 @@ func (api *DelegatorNode) DelegatorNotify(r *http.Request, args *ApiDelegatorNod
 		chainHeight, _ = binary.Uvarint(reader.Get("chainHeight"))
 		dataStorage := data_storage.NewDataStorage(reader)
 
-		var reg *registration.Registration
 		if reg, err = dataStorage.Regs.Get(string(sharedStakedPublicKey)); err != nil {
 			return
 		}
@@ -92,28 +90,7 @@ func (api *DelegatorNode) DelegatorNotify(r *http.Request, args *ApiDelegatorNod
 		return errors.New("Your stake is not accepted because you will need at least the minimum staking amount")
 	}
 
-	if err = api.wallet.AddSharedStakedAddress(&wallet_address.WalletAddress{
-		wallet_address.VERSION_NORMAL,
-		"Delegated Stake",
-		0,
-		false,
-		true,
-		nil,
-		sharedStakedPrivateKey,
-		nil,
-		nil,
-		sharedStakedPublicKey,
-		true,
-		false,
-		nil,
-		true,
-		&shared_staked.WalletAddressSharedStaked{
-			sharedStakedPrivateKey,
-			sharedStakedPublicKey,
-		},
-		"",
-		"",
-	}, true); err != nil {
+	if err = api.addDelegate(sharedStakedPrivateKey, reg, args.SharedStakedBalance, chainHeight); err != nil {
 		return
 	}
 
@@ -0,0 +1,30 @@
+package api_delegator_node
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/network/api/api_common/api_types"
+)
+
+type ApiDelegatorNodeRemoveDelegateRequest struct {
+	api_types.APIAccountBaseRequest
+}
+
+type ApiDelegatorNodeRemoveDelegateReply struct {
+	Result bool `json:"result" msgpack:"result"`
+}
+
+func (api *DelegatorNode) RemoveDelegatorNodeDelegate(r *http.Request, args *ApiDelegatorNodeRemoveDelegateRequest, reply *ApiDelegatorNodeRemoveDelegateReply, authenticated bool) (err error) {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	publicKey, err := args.GetPublicKey(true)
+	if err != nil {
+		return
+	}
+
+	reply.Result, err = api.removeDelegate(publicKey)
+	return
+}
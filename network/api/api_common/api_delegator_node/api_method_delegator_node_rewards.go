@@ -0,0 +1,38 @@
+package api_delegator_node
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api/api_common/api_types"
+)
+
+type ApiDelegatorNodeRewardsRequest struct {
+	api_types.APIAccountBaseRequest
+}
+
+type ApiDelegatorNodeRewardsReply struct {
+	Stake            uint64         `json:"stake" msgpack:"stake"`
+	Accrued          uint64         `json:"accrued" msgpack:"accrued"`
+	Pending          uint64         `json:"pending" msgpack:"pending"`             //share of the rewards which don't have enough confirmations
+	PendingPayout    uint64         `json:"pendingPayout" msgpack:"pendingPayout"` //payout which doesn't have enough confirmations
+	Paid             uint64         `json:"paid" msgpack:"paid"`
+	Blocks           uint64         `json:"blocks" msgpack:"blocks"`
+	LastPayoutHeight uint64         `json:"lastPayoutHeight" msgpack:"lastPayoutHeight"`
+	LastPayoutTx     helpers.Base64 `json:"lastPayoutTx,omitempty" msgpack:"lastPayoutTx,omitempty"`
+	Fee              uint64         `json:"fee" msgpack:"fee"` //basis points
+}
+
+func (api *DelegatorNode) GetDelegatorNodeRewards(r *http.Request, args *ApiDelegatorNodeRewardsRequest, reply *ApiDelegatorNodeRewardsReply) error {
+
+	if api.pool == nil {
+		return errors.New("Delegator pool is not enabled")
+	}
+
+	publicKey, err := args.GetPublicKey(true)
+	if err != nil {
+		return err
+	}
+
+	return api.pool.getRewards(publicKey, reply)
+}
@@ -0,0 +1,331 @@
+package api_delegator_node
+
+import (
+	"context"
+	"errors"
+	"github.com/tevino/abool"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/accounts"
+	"pandora-pay/blockchain/data_storage/accounts/account"
+	"pandora-pay/blockchain/data_storage/registrations/registration"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_nodes"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_interface"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/wallet/wallet_address"
+	"pandora-pay/wallet/wallet_address/shared_staked"
+	"sort"
+	"sync"
+)
+
+// delegatorDelegate is the registration metadata of a delegate. The private key is stored by the wallet
+type delegatorDelegate struct {
+	PublicKey        []byte `msgpack:"publicKey"`
+	SpendPublicKey   []byte `msgpack:"spendPublicKey"`
+	Stake            uint64 `msgpack:"stake"`
+	AddedHeight      uint64 `msgpack:"addedHeight"`
+	LastActiveHeight uint64 `msgpack:"lastActiveHeight"` //last notification or balance change
+}
+
+type delegatorDelegates struct {
+	list     map[string]*delegatorDelegate
+	evicting *abool.AtomicBool
+	lock     *sync.Mutex //locks the list and serializes the additions with the evictions
+	saveLock *sync.Mutex
+}
+
+func newDelegateWalletAddress(privateKey *addresses.PrivateKey, publicKey []byte) *wallet_address.WalletAddress {
+	return &wallet_address.WalletAddress{
+		wallet_address.VERSION_NORMAL,
+		"Delegated Stake",
+		0,
+		false,
+		true,
+		nil,
+		privateKey,
+		nil,
+		nil,
+		publicKey,
+		true,
+		false,
+		nil,
+		true,
+		&shared_staked.WalletAddressSharedStaked{
+			privateKey,
+			publicKey,
+		},
+		"",
+		"",
+	}
+}
+
+// sorted returns the delegates sorted descending by stake
+func (delegates *delegatorDelegates) sorted() []*delegatorDelegate {
+	out := make([]*delegatorDelegate, 0, len(delegates.list))
+	for _, delegate := range delegates.list {
+		out = append(out, delegate)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Stake > out[j].Stake
+	})
+	return out
+}
+
+// evictDelegate removes the delegate from the wallet, the pool and the stored list. Must be called with delegates.lock
+func (api *DelegatorNode) evictDelegate(publicKey []byte, reason string) (err error) {
+
+	if _, err = api.wallet.RemoveAddressByPublicKey(publicKey, true); err != nil {
+		return
+	}
+
+	delete(api.delegates.list, string(publicKey))
+	if api.pool != nil {
+		api.pool.removeDelegate(publicKey)
+	}
+
+	gui.Network().Info("Delegate evicted", gui_interface.Field("publicKey", publicKey), gui_interface.Field("reason", reason))
+	return
+}
+
+// addDelegate adds or refreshes the delegate. When the node is full, the delegate with the lowest stake is evicted if the new stake is bigger
+func (api *DelegatorNode) addDelegate(privateKey *addresses.PrivateKey, reg *registration.Registration, stake, chainHeight uint64) (err error) {
+
+	publicKey := privateKey.GeneratePublicKey()
+
+	api.delegates.lock.Lock()
+	defer api.delegates.lock.Unlock()
+
+	delegate := api.delegates.list[string(publicKey)]
+	if delegate == nil {
+
+		if len(api.delegates.list) >= config_nodes.DELEGATES_MAXIMUM {
+			sorted := api.delegates.sorted()
+			if len(sorted) == 0 || sorted[len(sorted)-1].Stake >= stake {
+				return errors.New("Delegator node is full and your stake is not bigger than the lowest delegated stake")
+			}
+			if err = api.evictDelegate(sorted[len(sorted)-1].PublicKey, "capacity"); err != nil {
+				return
+			}
+		}
+
+		if api.wallet.GetWalletAddressByPublicKey(publicKey, true) == nil {
+			if err = api.wallet.AddSharedStakedAddress(newDelegateWalletAddress(privateKey, publicKey), true); err != nil {
+				return
+			}
+		}
+
+		delegate = &delegatorDelegate{PublicKey: publicKey, AddedHeight: chainHeight}
+		api.delegates.list[string(publicKey)] = delegate
+	}
+
+	delegate.SpendPublicKey = reg.SpendPublicKey
+	delegate.Stake = stake
+	delegate.LastActiveHeight = chainHeight
+
+	if api.pool != nil {
+		api.pool.addDelegate(publicKey, stake, chainHeight)
+	}
+
+	api.delegates.save()
+	return
+}
+
+func (api *DelegatorNode) removeDelegate(publicKey []byte) (bool, error) {
+
+	api.delegates.lock.Lock()
+	defer api.delegates.lock.Unlock()
+
+	if api.delegates.list[string(publicKey)] == nil {
+		return false, nil
+	}
+
+	if err := api.evictDelegate(publicKey, "removed"); err != nil {
+		return false, err
+	}
+
+	api.delegates.save()
+	return true, nil
+}
+
+func (api *DelegatorNode) isWalletLoaded() bool {
+	api.wallet.Lock.RLock()
+	defer api.wallet.Lock.RUnlock()
+	return api.wallet.Loaded
+}
+
+// restoreDelegates keeps the stored delegates and the delegated addresses of the wallet in sync
+func (api *DelegatorNode) restoreDelegates(chainHeight uint64) {
+
+	if !api.isWalletLoaded() { //encrypted wallets are loaded later
+		return
+	}
+
+	api.delegates.lock.Lock()
+	defer api.delegates.lock.Unlock()
+
+	sharedStaked := make(map[string]bool)
+	for _, addr := range api.wallet.GetSharedStakedAddresses() {
+		sharedStaked[string(addr.PublicKey)] = true
+		if api.delegates.list[string(addr.PublicKey)] == nil {
+			api.delegates.list[string(addr.PublicKey)] = &delegatorDelegate{PublicKey: addr.PublicKey, AddedHeight: chainHeight, LastActiveHeight: chainHeight}
+		}
+	}
+
+	for key, delegate := range api.delegates.list {
+		if !sharedStaked[key] {
+			gui.Network().Warning("Delegate is missing from the wallet", gui_interface.Field("publicKey", delegate.PublicKey))
+			delete(api.delegates.list, key)
+		}
+	}
+
+	api.delegates.save()
+}
+
+// delegateBalance is the encrypted balance of a delegate which changed since the last check
+type delegateBalance struct {
+	delegate *delegatorDelegate
+	addr     *wallet_address.WalletAddress
+	balance  []byte
+	stake    uint64 //the previous stake, used to speed up the decryption
+}
+
+// getDelegatesBalances returns the delegates which must be evicted and the balances which changed. Must be called with delegates.lock
+func (api *DelegatorNode) getDelegatesBalances() (evict map[string]string, changed []*delegateBalance, err error) {
+
+	evict = make(map[string]string)
+
+	err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(reader)
+
+		var accs *accounts.Accounts
+		if accs, err = dataStorage.AccsCollection.GetMap(config_coins.NATIVE_ASSET_FULL); err != nil {
+			return
+		}
+
+		for key, delegate := range api.delegates.list {
+
+			var reg *registration.Registration
+			if reg, err = dataStorage.Regs.Get(key); err != nil {
+				return
+			}
+			if reg == nil || !reg.Staked {
+				evict[key] = "not staked"
+				continue
+			}
+
+			var acc *account.Account
+			if acc, err = accs.Get(key); err != nil {
+				return
+			}
+			if acc == nil {
+				evict[key] = "account missing"
+				continue
+			}
+
+			addr := api.wallet.GetWalletAddressByPublicKey(delegate.PublicKey, true)
+			if addr == nil || addr.PrivateKey == nil {
+				evict[key] = "missing from the wallet"
+				continue
+			}
+
+			if !addr.PrivateKey.TryDecryptBalance(acc.Balance.Amount, delegate.Stake) {
+				changed = append(changed, &delegateBalance{delegate, addr, acc.Balance.Amount.Serialize(), delegate.Stake})
+			}
+		}
+
+		return
+	})
+	return
+}
+
+// checkDelegates evicts the delegates which are not staked anymore, whose stake fell below the required stake or which are inactive. The changed balances are decrypted without holding delegates.lock, as it can take long
+func (api *DelegatorNode) checkDelegates(chainHeight uint64) {
+
+	if !api.isWalletLoaded() || !api.delegates.evicting.SetToIf(false, true) {
+		return
+	}
+	defer api.delegates.evicting.UnSet()
+
+	api.restoreDelegates(chainHeight)
+
+	api.delegates.lock.Lock()
+	evict, changed, err := api.getDelegatesBalances()
+	api.delegates.lock.Unlock()
+
+	if err != nil {
+		gui.Network().Error("Error checking the delegates", err)
+		return
+	}
+
+	stakes := make([]uint64, len(changed))
+	decrypted := make([]bool, len(changed))
+	for i, it := range changed {
+		if stakes[i], err = api.wallet.DecryptBalance(it.addr, it.balance, config_coins.NATIVE_ASSET_FULL, true, it.stake, false, context.Background(), func(string) {}); err == nil {
+			decrypted[i] = true
+		} //the delegate is not evicted when the balance can't be decrypted
+	}
+
+	api.delegates.lock.Lock()
+	defer api.delegates.lock.Unlock()
+
+	undecrypted := make(map[string]bool)
+	for i, it := range changed {
+		if !decrypted[i] {
+			undecrypted[string(it.delegate.PublicKey)] = true
+			continue
+		}
+		//the delegate was removed or notified the node meanwhile
+		if api.delegates.list[string(it.delegate.PublicKey)] != it.delegate || it.delegate.Stake != it.stake {
+			continue
+		}
+		it.delegate.Stake = stakes[i]
+		it.delegate.LastActiveHeight = chainHeight
+		if api.pool != nil {
+			api.pool.addDelegate(it.delegate.PublicKey, stakes[i], chainHeight)
+		}
+	}
+
+	requiredStake := config_stake.GetRequiredStake(chainHeight)
+	for key, delegate := range api.delegates.list {
+		if evict[key] != "" || undecrypted[key] {
+			continue
+		}
+		if delegate.Stake < requiredStake {
+			evict[key] = "stake below the required stake"
+		} else if config_nodes.DELEGATES_INACTIVE_BLOCKS > 0 && delegate.LastActiveHeight+config_nodes.DELEGATES_INACTIVE_BLOCKS < chainHeight {
+			evict[key] = "inactive"
+		}
+	}
+
+	for key, reason := range evict {
+		if api.delegates.list[key] == nil {
+			continue
+		}
+		if err := api.evictDelegate([]byte(key), reason); err != nil {
+			gui.Network().Error("Error evicting the delegate", err)
+		}
+	}
+
+	api.delegates.save()
+}
+
+func newDelegatorDelegates() (*delegatorDelegates, error) {
+
+	delegates := &delegatorDelegates{
+		make(map[string]*delegatorDelegate),
+		abool.New(),
+		&sync.Mutex{},
+		&sync.Mutex{},
+	}
+
+	if err := delegates.load(); err != nil {
+		return nil, err
+	}
+
+	return delegates, nil
+}
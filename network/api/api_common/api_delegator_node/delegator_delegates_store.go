@@ -0,0 +1,54 @@
+package api_delegator_node
+
+import (
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/gui"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+// save stores the delegates into the settings store. Must be called with delegates.lock
+func (delegates *delegatorDelegates) save() {
+
+	delegates.saveLock.Lock()
+	defer delegates.saveLock.Unlock()
+
+	stored := make([]*delegatorDelegate, 0, len(delegates.list))
+	for _, delegate := range delegates.list {
+		stored = append(stored, delegate)
+	}
+
+	data, err := msgpack.Marshal(stored)
+	if err == nil {
+		err = store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			writer.Put("delegatorDelegates", data)
+			return nil
+		})
+	}
+
+	if err != nil {
+		gui.Network().Error("Error storing delegates", err)
+	}
+}
+
+// load restores the stored delegates
+func (delegates *delegatorDelegates) load() error {
+
+	var stored []*delegatorDelegate
+
+	if err := store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		data := reader.Get("delegatorDelegates")
+		if data == nil {
+			return nil
+		}
+		return msgpack.Unmarshal(data, &stored)
+	}); err != nil {
+		return err
+	}
+
+	for _, delegate := range stored {
+		delegates.list[string(delegate.PublicKey)] = delegate
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package api_delegator_node
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage/registrations/registration"
+	"pandora-pay/blockchain/forging"
+	"pandora-pay/config/config_nodes"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/wallet"
+	"testing"
+)
+
+func createTestDelegatorNode(t *testing.T) *DelegatorNode {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(false)
+	assert.NoError(t, err)
+
+	for _, it := range []**store.Store{&store.StoreBlockchain, &store.StoreWallet, &store.StoreSettings} {
+		db, err := store_db_memory.CreateStoreDBMemory("test")
+		assert.NoError(t, err)
+		*it = &store.Store{Name: "test", Opened: true, DB: db}
+	}
+
+	forging, err := forging.CreateForging(nil, nil)
+	assert.NoError(t, err)
+
+	api := &DelegatorNode{}
+	if api.wallet, err = wallet.CreateWallet(forging, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if api.delegates, err = newDelegatorDelegates(); err != nil {
+		t.Fatal(err)
+	}
+
+	return api
+}
+
+func TestDelegatorNodeAddDelegate_Capacity(t *testing.T) {
+
+	api := createTestDelegatorNode(t)
+
+	maximum := config_nodes.DELEGATES_MAXIMUM
+	defer func() {
+		config_nodes.DELEGATES_MAXIMUM = maximum
+	}()
+	config_nodes.DELEGATES_MAXIMUM = 2
+
+	privateKeys := []*addresses.PrivateKey{addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey()}
+	publicKey := func(i int) []byte {
+		return privateKeys[i].GeneratePublicKey()
+	}
+
+	assert.NoError(t, api.addDelegate(privateKeys[0], &registration.Registration{}, 200, 10))
+	assert.NoError(t, api.addDelegate(privateKeys[1], &registration.Registration{}, 100, 10))
+
+	assert.EqualError(t, api.addDelegate(privateKeys[2], &registration.Registration{}, 100, 11), "Delegator node is full and your stake is not bigger than the lowest delegated stake")
+	assert.Nil(t, api.wallet.GetWalletAddressByPublicKey(publicKey(2), true))
+
+	assert.NoError(t, api.addDelegate(privateKeys[1], &registration.Registration{}, 300, 12), "a delegate is refreshed when the node is full")
+	assert.Equal(t, uint64(300), api.delegates.list[string(publicKey(1))].Stake)
+	assert.Equal(t, uint64(12), api.delegates.list[string(publicKey(1))].LastActiveHeight)
+
+	assert.NoError(t, api.addDelegate(privateKeys[3], &registration.Registration{}, 250, 13))
+	assert.Equal(t, 2, len(api.delegates.list))
+	assert.Nil(t, api.delegates.list[string(publicKey(0))], "the lowest stake is evicted")
+	assert.Nil(t, api.wallet.GetWalletAddressByPublicKey(publicKey(0), true))
+	assert.NotNil(t, api.wallet.GetWalletAddressByPublicKey(publicKey(3), true))
+	assert.Equal(t, 2, api.wallet.GetDelegatesCount())
+}
+
+func TestDelegatorNodeRestoreDelegates(t *testing.T) {
+
+	api := createTestDelegatorNode(t)
+
+	stored, missing, restored := addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey()
+
+	assert.NoError(t, api.addDelegate(stored, &registration.Registration{}, 100, 10))
+	assert.NoError(t, api.wallet.AddSharedStakedAddress(newDelegateWalletAddress(restored, restored.GeneratePublicKey()), true))
+	api.delegates.list[string(missing.GeneratePublicKey())] = &delegatorDelegate{PublicKey: missing.GeneratePublicKey()}
+
+	api.restoreDelegates(20)
+
+	assert.Equal(t, 2, len(api.delegates.list))
+	assert.Nil(t, api.delegates.list[string(missing.GeneratePublicKey())], "the delegates missing from the wallet are removed")
+	assert.Equal(t, uint64(100), api.delegates.list[string(stored.GeneratePublicKey())].Stake)
+	assert.Equal(t, uint64(20), api.delegates.list[string(restored.GeneratePublicKey())].AddedHeight, "the delegated addresses of the wallet are restored")
+
+	//the delegates are loaded from the store after a restart
+	delegates, err := newDelegatorDelegates()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(delegates.list))
+	assert.Equal(t, uint64(100), delegates.list[string(stored.GeneratePublicKey())].Stake)
+}
+
+func TestDelegatorNodeCheckDelegates(t *testing.T) {
+
+	api := createTestDelegatorNode(t)
+
+	privateKey := addresses.GenerateNewPrivateKey()
+	assert.NoError(t, api.addDelegate(privateKey, &registration.Registration{}, 100, 10))
+
+	api.checkDelegates(20)
+
+	assert.Equal(t, 0, len(api.delegates.list), "the delegates which are not registered are evicted")
+	assert.Nil(t, api.wallet.GetWalletAddressByPublicKey(privateKey.GeneratePublicKey(), true))
+}
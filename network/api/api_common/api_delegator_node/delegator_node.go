@@ -2,22 +2,65 @@ package api_delegator_node
 
 import (
 	"pandora-pay/blockchain"
+	"pandora-pay/config/config_nodes"
+	"pandora-pay/mempool"
+	"pandora-pay/recovery"
+	"pandora-pay/txs_builder"
 	"pandora-pay/wallet"
+	"sync/atomic"
 )
 
 type DelegatorNode struct {
 	chainHeight uint64 //use atomic
 	wallet      *wallet.Wallet
 	chain       *blockchain.Blockchain
+	delegates   *delegatorDelegates
+	pool        *delegatorPool //nil when the pool is disabled
 }
 
-func NewDelegatorNode(chain *blockchain.Blockchain, wallet *wallet.Wallet) (delegator *DelegatorNode) {
+func NewDelegatorNode(chain *blockchain.Blockchain, wallet *wallet.Wallet, mempool *mempool.Mempool, txsBuilder *txs_builder.TxsBuilder) (delegator *DelegatorNode, err error) {
 
 	delegator = &DelegatorNode{
-		0,
+		chain.GetChainData().Height,
 		wallet,
 		chain,
+		nil,
+		nil,
 	}
 
+	if delegator.delegates, err = newDelegatorDelegates(); err != nil {
+		return nil, err
+	}
+
+	if config_nodes.DELEGATOR_POOL_ENABLED {
+		if delegator.pool, err = newDelegatorPool(chain, wallet, mempool, txsBuilder); err != nil {
+			return nil, err
+		}
+	}
+
+	delegator.restoreDelegates(delegator.chainHeight)
+
+	recovery.SafeGo(func() {
+
+		updateNewChainDataUpdateListener := chain.UpdateNewChainDataUpdate.AddListener()
+		defer chain.UpdateNewChainDataUpdate.RemoveChannel(updateNewChainDataUpdateListener)
+
+		for {
+			newChainDataUpdate, ok := <-updateNewChainDataUpdateListener
+			if !ok {
+				return
+			}
+
+			chainHeight := newChainDataUpdate.Update.Height
+			atomic.StoreUint64(&delegator.chainHeight, chainHeight)
+
+			if chainHeight%config_nodes.DELEGATES_EVICTION_INTERVAL == 0 {
+				recovery.SafeGo(func() {
+					delegator.checkDelegates(chainHeight)
+				})
+			}
+		}
+	})
+
 	return
 }
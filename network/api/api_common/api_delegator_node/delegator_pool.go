@@ -0,0 +1,450 @@
+package api_delegator_node
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"github.com/tevino/abool"
+	"math/big"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_nodes"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_interface"
+	"pandora-pay/mempool"
+	"pandora-pay/recovery"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/txs_builder"
+	"pandora-pay/txs_builder/wizard"
+	"pandora-pay/wallet"
+	"sort"
+	"sync"
+)
+
+type delegatorPoolDelegate struct {
+	Stake            uint64 `msgpack:"stake"`
+	Accrued          uint64 `msgpack:"accrued"` //settled rewards which were not paid yet
+	Paid             uint64 `msgpack:"paid"`
+	Blocks           uint64 `msgpack:"blocks"` //blocks forged by the delegate
+	LastPayoutHeight uint64 `msgpack:"lastPayoutHeight"`
+	LastPayoutTx     []byte `msgpack:"lastPayoutTx"`
+}
+
+type delegatorPoolShare struct {
+	PublicKey []byte `msgpack:"publicKey"`
+	Stake     uint64 `msgpack:"stake"`
+}
+
+// delegatorPoolReward is a staking reward sent to the pool which is shared after it gets enough confirmations
+type delegatorPoolReward struct {
+	TxHash  []byte                `msgpack:"txHash"`
+	Forger  []byte                `msgpack:"forger"`
+	Reward  uint64                `msgpack:"reward"`
+	Created uint64                `msgpack:"created"`
+	Shares  []*delegatorPoolShare `msgpack:"shares"` //stakes of the delegates when the block was forged
+}
+
+// delegatorPoolPayout is the payment of a delegate which was not confirmed yet. A payout transaction pays multiple delegates
+type delegatorPoolPayout struct {
+	TxHash    []byte `msgpack:"txHash"`
+	PublicKey []byte `msgpack:"publicKey"`
+	Amount    uint64 `msgpack:"amount"`
+	Created   uint64 `msgpack:"created"`
+	Included  bool   `msgpack:"included"`
+}
+
+type delegatorPoolState struct {
+	Delegates    map[string]*delegatorPoolDelegate `msgpack:"delegates"`
+	Rewards      []*delegatorPoolReward            `msgpack:"rewards"`
+	Payouts      []*delegatorPoolPayout            `msgpack:"payouts"`
+	OperatorFees uint64                            `msgpack:"operatorFees"`
+}
+
+type delegatorPool struct {
+	rewardAddress string
+	state         *delegatorPoolState
+	chain         *blockchain.Blockchain
+	mempool       *mempool.Mempool
+	txsBuilder    *txs_builder.TxsBuilder
+	processing    *abool.AtomicBool
+	lock          *sync.Mutex
+	saveLock      *sync.Mutex
+}
+
+// splitReward returns the operator fee and the amount of every share proportional to its stake. The remainder of the divisions goes to the operator
+func splitReward(reward, fee uint64, shares []*delegatorPoolShare) (operator uint64, amounts []uint64) {
+
+	amounts = make([]uint64, len(shares))
+
+	total := new(big.Int)
+	for _, share := range shares {
+		total.Add(total, new(big.Int).SetUint64(share.Stake))
+	}
+
+	operator = new(big.Int).Div(new(big.Int).Mul(new(big.Int).SetUint64(reward), new(big.Int).SetUint64(fee)), big.NewInt(10000)).Uint64()
+	if total.Sign() == 0 {
+		return reward, amounts
+	}
+
+	remaining := reward - operator
+	distributed := uint64(0)
+	for i, share := range shares {
+		amount := new(big.Int).Mul(new(big.Int).SetUint64(remaining), new(big.Int).SetUint64(share.Stake))
+		amounts[i] = amount.Div(amount, total).Uint64()
+		distributed += amounts[i]
+	}
+
+	operator += remaining - distributed
+	return
+}
+
+func (pool *delegatorPool) addDelegate(publicKey []byte, stake, chainHeight uint64) {
+
+	pool.lock.Lock()
+	if delegate := pool.state.Delegates[string(publicKey)]; delegate != nil {
+		delegate.Stake = stake
+	} else {
+		pool.state.Delegates[string(publicKey)] = &delegatorPoolDelegate{Stake: stake, LastPayoutHeight: chainHeight}
+	}
+	pool.lock.Unlock()
+
+	pool.save()
+}
+
+// removeDelegate stops sharing the rewards with the delegate. The accrued rewards are still paid
+func (pool *delegatorPool) removeDelegate(publicKey []byte) {
+
+	pool.lock.Lock()
+	if delegate := pool.state.Delegates[string(publicKey)]; delegate != nil {
+		delegate.Stake = 0
+	}
+	pool.lock.Unlock()
+
+	pool.save()
+}
+
+// GetForgingRewardRecipient sends the rewards of the delegates to the pool
+func (pool *delegatorPool) GetForgingRewardRecipient(forgerPublicKey []byte) string {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	if pool.state.Delegates[string(forgerPublicKey)] == nil {
+		return ""
+	}
+	return pool.rewardAddress
+}
+
+func (pool *delegatorPool) ForgingRewardCreated(blockHeight uint64, forgerPublicKey []byte, stakingAmount, reward uint64, tx *transaction.Transaction) {
+
+	pool.lock.Lock()
+
+	forger := pool.state.Delegates[string(forgerPublicKey)]
+	if forger == nil {
+		pool.lock.Unlock()
+		return
+	}
+	forger.Stake = stakingAmount
+
+	shares := make([]*delegatorPoolShare, 0, len(pool.state.Delegates))
+	for publicKey, delegate := range pool.state.Delegates {
+		if delegate.Stake > 0 {
+			shares = append(shares, &delegatorPoolShare{[]byte(publicKey), delegate.Stake})
+		}
+	}
+
+	pool.state.Rewards = append(pool.state.Rewards, &delegatorPoolReward{tx.Bloom.Hash, forgerPublicKey, reward, blockHeight, shares})
+	pool.lock.Unlock()
+
+	pool.save()
+}
+
+// getTxBlockHeight returns the height of the block which included the transaction
+func getTxBlockHeight(reader store_db_interface.StoreDBTransactionInterface, txHash []byte) (uint64, bool) {
+	data := reader.Get("txBlock:" + string(txHash))
+	if data == nil {
+		return 0, false
+	}
+	height, _ := binary.Uvarint(data)
+	return height, true
+}
+
+// settle shares the confirmed rewards, finalizes the confirmed payouts and drops the expired ones. The chain height is read in the same view as the transactions, because the update which triggered it can be older
+func (pool *delegatorPool) settle() (chainHeight uint64, err error) {
+
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+
+		chainHeight, _ = binary.Uvarint(reader.Get("chainHeight"))
+
+		rewards := make([]*delegatorPoolReward, 0, len(pool.state.Rewards))
+		for _, reward := range pool.state.Rewards {
+
+			blockHeight, included := getTxBlockHeight(reader, reward.TxHash)
+			if !included {
+				if reward.Created+config_nodes.DELEGATOR_POOL_PENDING_EXPIRE_BLOCKS >= chainHeight {
+					rewards = append(rewards, reward)
+				}
+				continue
+			}
+			if chainHeight-blockHeight < config_nodes.DELEGATOR_POOL_REWARD_CONFIRMATIONS {
+				rewards = append(rewards, reward)
+				continue
+			}
+
+			operator, amounts := splitReward(reward.Reward, config_nodes.DELEGATOR_POOL_FEE, reward.Shares)
+			pool.state.OperatorFees += operator
+			for i, share := range reward.Shares {
+				delegate := pool.state.Delegates[string(share.PublicKey)]
+				if delegate == nil {
+					delegate = &delegatorPoolDelegate{LastPayoutHeight: chainHeight}
+					pool.state.Delegates[string(share.PublicKey)] = delegate
+				}
+				delegate.Accrued += amounts[i]
+			}
+			if forger := pool.state.Delegates[string(reward.Forger)]; forger != nil {
+				forger.Blocks += 1
+			}
+
+			gui.Forging().Info("Delegator pool reward shared", gui_interface.Field("height", blockHeight), gui_interface.Field("reward", config_coins.ConvertToBase(reward.Reward)), gui_interface.Field("delegates", len(reward.Shares)))
+		}
+		pool.state.Rewards = rewards
+
+		payouts := make([]*delegatorPoolPayout, 0, len(pool.state.Payouts))
+		for _, payout := range pool.state.Payouts {
+
+			delegate := pool.state.Delegates[string(payout.PublicKey)]
+
+			blockHeight, included := getTxBlockHeight(reader, payout.TxHash)
+			payout.Included = included
+			if !included {
+				if payout.Created+config_nodes.DELEGATOR_POOL_PENDING_EXPIRE_BLOCKS >= chainHeight || pool.mempool.Txs.Exists(string(payout.TxHash)) {
+					payouts = append(payouts, payout)
+				} else if delegate != nil {
+					delegate.Accrued += payout.Amount
+				}
+				continue
+			}
+			if chainHeight-blockHeight < config_nodes.DELEGATOR_POOL_REWARD_CONFIRMATIONS {
+				payouts = append(payouts, payout)
+				continue
+			}
+
+			if delegate != nil {
+				delegate.Paid += payout.Amount
+				delegate.LastPayoutTx = payout.TxHash
+			}
+		}
+		pool.state.Payouts = payouts
+
+		return nil
+	})
+	return
+}
+
+// nextPayout returns the delegates with the largest accrued amounts which can be paid, at most DELEGATOR_POOL_PAYOUT_BATCH. A new payout waits until the previous one is included, because the pool balance changes with every transfer
+func (pool *delegatorPool) nextPayout(chainHeight uint64) []*delegatorPoolPayout {
+
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	for _, payout := range pool.state.Payouts {
+		if !payout.Included {
+			return nil
+		}
+	}
+
+	payouts := make([]*delegatorPoolPayout, 0)
+	for key, delegate := range pool.state.Delegates {
+		if delegate.Accrued >= config_nodes.DELEGATOR_POOL_PAYOUT_MINIMUM && delegate.LastPayoutHeight+config_nodes.DELEGATOR_POOL_PAYOUT_INTERVAL <= chainHeight {
+			payouts = append(payouts, &delegatorPoolPayout{PublicKey: []byte(key), Amount: delegate.Accrued, Created: chainHeight})
+		}
+	}
+
+	sort.Slice(payouts, func(i, j int) bool {
+		return payouts[i].Amount > payouts[j].Amount
+	})
+	if len(payouts) > config_nodes.DELEGATOR_POOL_PAYOUT_BATCH {
+		payouts = payouts[:config_nodes.DELEGATOR_POOL_PAYOUT_BATCH]
+	}
+
+	return payouts
+}
+
+// payout pays the delegates using a single transaction with a payload for every delegate
+func (pool *delegatorPool) payout(payouts []*delegatorPoolPayout) error {
+
+	txData := &txs_builder.TxBuilderCreateZetherTxData{
+		Payloads: make([]*txs_builder.TxBuilderCreateZetherTxPayload, len(payouts)),
+	}
+
+	for i, payout := range payouts {
+
+		recipient, err := addresses.CreateAddr(payout.PublicKey, false, nil, nil, nil, 0, nil)
+		if err != nil {
+			return err
+		}
+
+		txData.Payloads[i] = &txs_builder.TxBuilderCreateZetherTxPayload{
+			Sender:            pool.rewardAddress,
+			Asset:             config_coins.NATIVE_ASSET_FULL,
+			Recipient:         recipient.EncodeAddr(),
+			Data:              &wizard.WizardTransactionData{[]byte("Delegator Pool Payout"), true},
+			Fee:               &wizard.WizardZetherTransactionFee{&wizard.WizardTransactionFee{0, 0, 0, true}, false, 0, 0},
+			Amount:            payout.Amount,
+			RingConfiguration: &txs_builder.ZetherRingConfiguration{128, &txs_builder.ZetherSenderRingType{}, &txs_builder.ZetherRecipientRingType{}},
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tx, err := pool.txsBuilder.CreateZetherTx(txData, nil, true, true, true, false, ctx, func(status string) {})
+	if err != nil {
+		return err
+	}
+
+	pool.addPayouts(tx.Bloom.Hash, payouts)
+
+	gui.Forging().Info("Delegator pool payout", gui_interface.Field("delegates", len(payouts)), gui_interface.Field("tx", tx.Bloom.Hash))
+	return nil
+}
+
+// addPayouts moves the accrued amounts of the delegates to the pending payouts of the transaction
+func (pool *delegatorPool) addPayouts(txHash []byte, payouts []*delegatorPoolPayout) {
+
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	for _, payout := range payouts {
+		if delegate := pool.state.Delegates[string(payout.PublicKey)]; delegate != nil {
+			delegate.Accrued -= payout.Amount
+			delegate.LastPayoutHeight = payout.Created
+		}
+		payout.TxHash = txHash
+		pool.state.Payouts = append(pool.state.Payouts, payout)
+	}
+}
+
+func (pool *delegatorPool) process() {
+
+	if !pool.processing.SetToIf(false, true) {
+		return
+	}
+	defer pool.processing.UnSet()
+
+	chainHeight, err := pool.settle()
+	if err != nil {
+		gui.Forging().Error("Delegator pool settling failed", err)
+		return
+	}
+
+	if payouts := pool.nextPayout(chainHeight); len(payouts) > 0 {
+		if err = pool.payout(payouts); err != nil {
+			gui.Forging().Error("Delegator pool payout failed", err)
+		}
+	}
+
+	pool.save()
+}
+
+func (pool *delegatorPool) start() {
+	recovery.SafeGo(func() {
+
+		updateNewChainDataUpdateListener := pool.chain.UpdateNewChainDataUpdate.AddListener()
+		defer pool.chain.UpdateNewChainDataUpdate.RemoveChannel(updateNewChainDataUpdateListener)
+
+		for {
+			if _, ok := <-updateNewChainDataUpdateListener; !ok {
+				return
+			}
+			recovery.SafeGo(pool.process)
+		}
+	})
+}
+
+func newDelegatorPool(chain *blockchain.Blockchain, wallet *wallet.Wallet, mempool *mempool.Mempool, txsBuilder *txs_builder.TxsBuilder) (*delegatorPool, error) {
+
+	rewardAddress := config_nodes.DELEGATOR_POOL_REWARD_ADDRESS
+	if rewardAddress == "" {
+		addr, err := wallet.GetWalletAddress(0, true)
+		if err != nil {
+			return nil, err
+		}
+		rewardAddress = addr.AddressEncoded
+	}
+
+	addr, err := wallet.GetWalletAddressByEncodedAddress(rewardAddress, true)
+	if err != nil {
+		return nil, err
+	}
+	if addr.PrivateKey == nil {
+		return nil, errors.New("The delegator pool reward address must have the private key in the wallet")
+	}
+
+	reward, err := addresses.CreateAddr(addr.PublicKey, false, nil, nil, nil, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := &delegatorPool{
+		reward.EncodeAddr(),
+		&delegatorPoolState{Delegates: make(map[string]*delegatorPoolDelegate)},
+		chain,
+		mempool,
+		txsBuilder,
+		abool.New(),
+		&sync.Mutex{},
+		&sync.Mutex{},
+	}
+
+	if err = pool.load(); err != nil {
+		return nil, err
+	}
+
+	txsBuilder.SetForgingRewardHandler(pool)
+	pool.start()
+
+	return pool, nil
+}
+
+func (pool *delegatorPool) getRewards(publicKey []byte, reply *ApiDelegatorNodeRewardsReply) error {
+
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	delegate := pool.state.Delegates[string(publicKey)]
+	if delegate == nil {
+		return errors.New("Delegate was not found")
+	}
+
+	reply.Stake = delegate.Stake
+	reply.Accrued = delegate.Accrued
+	reply.Paid = delegate.Paid
+	reply.Blocks = delegate.Blocks
+	reply.LastPayoutHeight = delegate.LastPayoutHeight
+	reply.LastPayoutTx = delegate.LastPayoutTx
+	reply.Fee = config_nodes.DELEGATOR_POOL_FEE
+
+	for _, reward := range pool.state.Rewards {
+		_, amounts := splitReward(reward.Reward, config_nodes.DELEGATOR_POOL_FEE, reward.Shares)
+		for i, share := range reward.Shares {
+			if bytes.Equal(share.PublicKey, publicKey) {
+				reply.Pending += amounts[i]
+			}
+		}
+	}
+
+	for _, payout := range pool.state.Payouts {
+		if bytes.Equal(payout.PublicKey, publicKey) {
+			reply.PendingPayout += payout.Amount
+		}
+	}
+
+	return nil
+}
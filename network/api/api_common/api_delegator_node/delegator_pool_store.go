@@ -0,0 +1,47 @@
+package api_delegator_node
+
+import (
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/gui"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+// save stores the state of the pool into the settings store
+func (pool *delegatorPool) save() {
+
+	pool.saveLock.Lock()
+	defer pool.saveLock.Unlock()
+
+	pool.lock.Lock()
+	data, err := msgpack.Marshal(pool.state)
+	pool.lock.Unlock()
+
+	if err == nil {
+		err = store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			writer.Put("delegatorPool", data)
+			return nil
+		})
+	}
+
+	if err != nil {
+		gui.Forging().Error("Error storing delegator pool", err)
+	}
+}
+
+// load restores the state of the pool
+func (pool *delegatorPool) load() error {
+	return store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		data := reader.Get("delegatorPool")
+		if data == nil {
+			return nil
+		}
+		if err := msgpack.Unmarshal(data, pool.state); err != nil {
+			return err
+		}
+		if pool.state.Delegates == nil {
+			pool.state.Delegates = make(map[string]*delegatorPoolDelegate)
+		}
+		return nil
+	})
+}
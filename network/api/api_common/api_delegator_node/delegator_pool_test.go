@@ -0,0 +1,181 @@
+package api_delegator_node
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/config/config_nodes"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/mempool"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"sync"
+	"testing"
+)
+
+func TestSplitReward(t *testing.T) {
+
+	shares := []*delegatorPoolShare{
+		{[]byte{1}, 100},
+		{[]byte{2}, 300},
+		{[]byte{3}, 200},
+	}
+
+	operator, amounts := splitReward(1000, 500, shares)
+	assert.Equal(t, []uint64{158, 475, 316}, amounts)
+	assert.Equal(t, uint64(51), operator, "the remainder goes to the operator")
+
+	operator, amounts = splitReward(1000, 0, []*delegatorPoolShare{{[]byte{1}, 1 << 62}, {[]byte{2}, 1 << 62}})
+	assert.Equal(t, []uint64{500, 500}, amounts, "large stakes should not overflow")
+	assert.Equal(t, uint64(0), operator)
+
+	operator, amounts = splitReward(1000, 10000, shares)
+	assert.Equal(t, []uint64{0, 0, 0}, amounts)
+	assert.Equal(t, uint64(1000), operator)
+
+	operator, _ = splitReward(1000, 500, nil)
+	assert.Equal(t, uint64(1000), operator)
+}
+
+func createTestDelegatorPool(t *testing.T) *delegatorPool {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(false)
+	assert.NoError(t, err)
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{Name: "blockchain", Opened: true, DB: db}
+
+	mempool, err := mempool.CreateMempool(nil)
+	assert.NoError(t, err)
+
+	return &delegatorPool{
+		state:    &delegatorPoolState{Delegates: make(map[string]*delegatorPoolDelegate)},
+		mempool:  mempool,
+		lock:     &sync.Mutex{},
+		saveLock: &sync.Mutex{},
+	}
+}
+
+// storeTestChain stores the chain height and the heights of the blocks which included the transactions
+func storeTestChain(t *testing.T, chainHeight uint64, txs map[string]uint64) {
+	assert.NoError(t, store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		buf := make([]byte, binary.MaxVarintLen64)
+		writer.Put("chainHeight", buf[:binary.PutUvarint(buf, chainHeight)])
+		for txHash, blockHeight := range txs {
+			buf = make([]byte, binary.MaxVarintLen64)
+			writer.Put("txBlock:"+txHash, buf[:binary.PutUvarint(buf, blockHeight)])
+		}
+		return nil
+	}))
+}
+
+func TestDelegatorPoolSettle(t *testing.T) {
+
+	pool := createTestDelegatorPool(t)
+
+	first, second := []byte{1}, []byte{2}
+	pool.state.Delegates[string(first)] = &delegatorPoolDelegate{Stake: 100}
+	pool.state.Delegates[string(second)] = &delegatorPoolDelegate{Stake: 300}
+	shares := []*delegatorPoolShare{{first, 100}, {second, 300}}
+
+	confirmed, unconfirmed, dropped := cryptography.RandomHash(), cryptography.RandomHash(), cryptography.RandomHash()
+	paid, unpaid, pending := cryptography.RandomHash(), cryptography.RandomHash(), cryptography.RandomHash()
+
+	pool.state.Rewards = []*delegatorPoolReward{
+		{confirmed, first, 1000, 90, shares},
+		{unconfirmed, first, 1000, 100, shares},
+		{dropped, second, 1000, 0, shares},
+	}
+	pool.state.Payouts = []*delegatorPoolPayout{
+		{paid, first, 50, 80, false},
+		{unpaid, second, 20, 0, false},
+		{pending, second, 30, 100, false},
+	}
+
+	chainHeight := 90 + config_nodes.DELEGATOR_POOL_REWARD_CONFIRMATIONS + config_nodes.DELEGATOR_POOL_PENDING_EXPIRE_BLOCKS/2
+	storeTestChain(t, chainHeight, map[string]uint64{string(confirmed): 90, string(unconfirmed): chainHeight - 1, string(paid): 90})
+
+	height, err := pool.settle()
+	assert.NoError(t, err)
+	assert.Equal(t, chainHeight, height, "the chain height is read from the store")
+
+	assert.Equal(t, 1, len(pool.state.Rewards), "the confirmed reward is shared and the dropped one expired")
+	assert.Equal(t, unconfirmed, pool.state.Rewards[0].TxHash)
+
+	operator, amounts := splitReward(1000, config_nodes.DELEGATOR_POOL_FEE, shares)
+	assert.Equal(t, operator, pool.state.OperatorFees)
+	assert.Equal(t, amounts[0], pool.state.Delegates[string(first)].Accrued)
+	assert.Equal(t, uint64(1), pool.state.Delegates[string(first)].Blocks)
+	assert.Equal(t, amounts[1]+20, pool.state.Delegates[string(second)].Accrued, "the amount of the dropped payout is accrued again")
+
+	assert.Equal(t, uint64(50), pool.state.Delegates[string(first)].Paid)
+	assert.Equal(t, paid, pool.state.Delegates[string(first)].LastPayoutTx)
+	assert.Equal(t, uint64(0), pool.state.Delegates[string(second)].Paid)
+
+	assert.Equal(t, 1, len(pool.state.Payouts))
+	assert.Equal(t, pending, pool.state.Payouts[0].TxHash)
+	assert.False(t, pool.state.Payouts[0].Included)
+
+	//the transactions included in the last block are not confirmed yet
+	storeTestChain(t, chainHeight+1, map[string]uint64{string(unconfirmed): chainHeight + 1, string(pending): chainHeight + 1})
+
+	_, err = pool.settle()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pool.state.Rewards))
+	assert.Equal(t, 1, len(pool.state.Payouts))
+	assert.True(t, pool.state.Payouts[0].Included)
+	assert.Equal(t, uint64(0), pool.state.Delegates[string(second)].Paid)
+}
+
+func TestDelegatorPoolNextPayout(t *testing.T) {
+
+	pool := createTestDelegatorPool(t)
+
+	batch := config_nodes.DELEGATOR_POOL_PAYOUT_BATCH
+	defer func() {
+		config_nodes.DELEGATOR_POOL_PAYOUT_BATCH = batch
+	}()
+	config_nodes.DELEGATOR_POOL_PAYOUT_BATCH = 2
+
+	minimum, interval := config_nodes.DELEGATOR_POOL_PAYOUT_MINIMUM, config_nodes.DELEGATOR_POOL_PAYOUT_INTERVAL
+	chainHeight := 2 * interval
+
+	pool.state.Delegates[string([]byte{1})] = &delegatorPoolDelegate{Accrued: minimum}
+	pool.state.Delegates[string([]byte{2})] = &delegatorPoolDelegate{Accrued: 3 * minimum}
+	pool.state.Delegates[string([]byte{3})] = &delegatorPoolDelegate{Accrued: 2 * minimum}
+	pool.state.Delegates[string([]byte{4})] = &delegatorPoolDelegate{Accrued: minimum - 1}
+	pool.state.Delegates[string([]byte{5})] = &delegatorPoolDelegate{Accrued: 5 * minimum, LastPayoutHeight: chainHeight - interval + 1}
+
+	payouts := pool.nextPayout(chainHeight)
+	assert.Equal(t, 2, len(payouts), "the payouts are batched")
+	assert.Equal(t, []byte{2}, payouts[0].PublicKey)
+	assert.Equal(t, 3*minimum, payouts[0].Amount)
+	assert.Equal(t, []byte{3}, payouts[1].PublicKey)
+
+	txHash := cryptography.RandomHash()
+	pool.addPayouts(txHash, payouts)
+
+	assert.Equal(t, uint64(0), pool.state.Delegates[string([]byte{2})].Accrued)
+	assert.Equal(t, chainHeight, pool.state.Delegates[string([]byte{2})].LastPayoutHeight)
+	assert.Equal(t, 2, len(pool.state.Payouts))
+	for _, payout := range pool.state.Payouts {
+		assert.Equal(t, txHash, payout.TxHash)
+	}
+
+	assert.Nil(t, pool.nextPayout(chainHeight), "the next payout waits until the previous one is included")
+
+	for _, payout := range pool.state.Payouts {
+		payout.Included = true
+	}
+	payouts = pool.nextPayout(chainHeight)
+	assert.Equal(t, 1, len(payouts))
+	assert.Equal(t, []byte{1}, payouts[0].PublicKey)
+
+	payouts = pool.nextPayout(chainHeight + 1)
+	assert.Equal(t, 2, len(payouts))
+	assert.Equal(t, []byte{5}, payouts[0].PublicKey, "the payout interval of the delegate passed")
+}
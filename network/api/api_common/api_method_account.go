@@ -11,12 +11,14 @@ import (
 	"pandora-pay/helpers"
 	"pandora-pay/network/api/api_common/api_types"
 	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
 type APIAccountRequest struct {
 	api_types.APIAccountBaseRequest
 	ReturnType api_types.APIReturnType `json:"returnType,omitempty"  msgpack:"returnType,omitempty" `
+	Proof      bool                    `json:"proof,omitempty"  msgpack:"proof,omitempty" ` //the proofs are computed for the serialized elements
 }
 
 type APIAccountReply struct {
@@ -29,6 +31,10 @@ type APIAccountReply struct {
 	Reg                *registration.Registration                              `json:"registration,omitempty" msgpack:"registration,omitempty"`
 	RegSerialized      []byte                                                  `json:"registrationSerialized,omitempty" msgpack:"registrationSerialized,omitempty"`
 	RegExtra           *api_types.APISubscriptionNotificationRegistrationExtra `json:"registrationExtra,omitempty" msgpack:"registrationExtra,omitempty"`
+	StateRoot          *api_types.APIStateRoot                                 `json:"stateRoot,omitempty" msgpack:"stateRoot,omitempty"`
+	AccsProofs         []*state_tree.StateProof                                `json:"accountsProofs,omitempty" msgpack:"accountsProofs,omitempty"`
+	PlainAccProof      *state_tree.StateProof                                  `json:"plainAccountProof,omitempty" msgpack:"plainAccountProof,omitempty"`
+	RegProof           *state_tree.StateProof                                  `json:"registrationProof,omitempty" msgpack:"registrationProof,omitempty"`
 }
 
 func (api *APICommon) GetAccount(r *http.Request, args *APIAccountRequest, reply *APIAccountReply) (err error) {
@@ -51,6 +57,12 @@ func (api *APICommon) GetAccount(r *http.Request, args *APIAccountRequest, reply
 
 		reply.Accs = make([]*account.Account, len(assetsList))
 		reply.AccsExtra = make([]*api_types.APISubscriptionNotificationAccountExtra, len(assetsList))
+		if args.Proof {
+			if reply.StateRoot, err = api.ApiStore.loadStateRoot(reader); err != nil {
+				return
+			}
+			reply.AccsProofs = make([]*state_tree.StateProof, len(assetsList))
+		}
 
 		for i, assetId := range assetsList {
 
@@ -71,6 +83,9 @@ func (api *APICommon) GetAccount(r *http.Request, args *APIAccountRequest, reply
 					acc.Index,
 				}
 			}
+			if args.Proof {
+				reply.AccsProofs[i] = state_tree.GetProof(reader, "accounts_"+string(assetId), publicKey)
+			}
 		}
 
 		if reply.PlainAcc, err = plainAccs.Get(string(publicKey)); err != nil {
@@ -91,6 +106,11 @@ func (api *APICommon) GetAccount(r *http.Request, args *APIAccountRequest, reply
 			}
 		}
 
+		if args.Proof {
+			reply.PlainAccProof = state_tree.GetProof(reader, "plainAccs", publicKey)
+			reply.RegProof = state_tree.GetProof(reader, "registrations", publicKey)
+		}
+
 		return
 	}); err != nil {
 		return err
@@ -0,0 +1,177 @@
+package api_common
+
+import (
+	"errors"
+	"github.com/vmihailenco/msgpack/v5"
+	"net/http"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/config"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+type APIAccountTxsHistoryRequest struct {
+	api_types.APIAccountBaseRequest
+	Cursor      uint64                               `json:"cursor,omitempty" msgpack:"cursor,omitempty"` //use the nextCursor returned previously
+	Dsc         bool                                 `json:"dsc,omitempty" msgpack:"dsc,omitempty"`
+	Limit       uint64                               `json:"limit,omitempty" msgpack:"limit,omitempty"`
+	Asset       helpers.Base64                       `json:"asset,omitempty" msgpack:"asset,omitempty"`
+	StartHeight uint64                               `json:"startHeight,omitempty" msgpack:"startHeight,omitempty"`
+	EndHeight   uint64                               `json:"endHeight,omitempty" msgpack:"endHeight,omitempty"` //inclusive
+	Direction   *info.AccountTxDirection             `json:"direction,omitempty" msgpack:"direction,omitempty"`
+	Version     *transaction_type.TransactionVersion `json:"version,omitempty" msgpack:"version,omitempty"`
+	Script      *uint64                              `json:"script,omitempty" msgpack:"script,omitempty"`
+}
+
+type APIAccountTxsHistoryReply struct {
+	Count      uint64                `json:"count,omitempty" msgpack:"count,omitempty"`
+	Txs        []*info.AccountTxInfo `json:"txs,omitempty" msgpack:"txs,omitempty"`
+	HasMore    bool                  `json:"hasMore,omitempty" msgpack:"hasMore,omitempty"`
+	NextCursor uint64                `json:"nextCursor,omitempty" msgpack:"nextCursor,omitempty"`
+}
+
+func (args *APIAccountTxsHistoryRequest) matches(accTxInfo *info.AccountTxInfo) bool {
+	if args.Direction != nil && *args.Direction != accTxInfo.Direction {
+		return false
+	}
+	if args.Version != nil && *args.Version != accTxInfo.Version {
+		return false
+	}
+	if args.Script != nil && !accTxInfo.HasScript(*args.Script) {
+		return false
+	}
+	return true
+}
+
+func (api *APICommon) GetAccountTxsHistory(r *http.Request, args *APIAccountTxsHistoryRequest, reply *APIAccountTxsHistoryReply) (err error) {
+
+	publicKey, err := args.GetPublicKey(true)
+	if err != nil {
+		return
+	}
+
+	publicKeyStr := string(publicKey)
+
+	limit := args.Limit
+	if limit == 0 || limit > config.API_ACCOUNT_TXS_HISTORY_MAX_RESULTS {
+		limit = config.API_ACCOUNT_TXS_HISTORY_MAX_RESULTS
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		countKey := "addrTxsCount:" + publicKeyStr
+		if len(args.Asset) > 0 {
+			countKey = "addrAssetTxsCount:" + publicKeyStr + string(args.Asset)
+		}
+
+		data := reader.Get(countKey)
+		if data == nil {
+			return nil
+		}
+
+		if reply.Count, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+			return
+		}
+
+		load := func(i uint64) (*info.AccountTxInfo, error) {
+
+			indexStr := strconv.FormatUint(i, 10)
+			if len(args.Asset) > 0 {
+				if indexStr = string(reader.Get("addrAssetTx:" + publicKeyStr + string(args.Asset) + ":" + indexStr)); indexStr == "" {
+					return nil, errors.New("Error reading address asset transaction")
+				}
+			}
+
+			data := reader.Get("addrTxInfo:" + publicKeyStr + ":" + indexStr)
+			if data == nil {
+				return nil, errors.New("Error reading address transaction info")
+			}
+
+			accTxInfo := &info.AccountTxInfo{}
+			if err := msgpack.Unmarshal(data, accTxInfo); err != nil {
+				return nil, err
+			}
+			return accTxInfo, nil
+		}
+
+		//transactions are sorted by height, returns the first index with a greater or equal height
+		search := func(height uint64) (uint64, error) {
+			lo, hi := uint64(0), reply.Count
+			for lo < hi {
+				mid := (lo + hi) / 2
+				accTxInfo, err := load(mid)
+				if err != nil {
+					return 0, err
+				}
+				if accTxInfo.BlkHeight < height {
+					lo = mid + 1
+				} else {
+					hi = mid
+				}
+			}
+			return lo, nil
+		}
+
+		start, end := uint64(0), reply.Count
+		if args.StartHeight > 0 {
+			if start, err = search(args.StartHeight); err != nil {
+				return
+			}
+		}
+		if args.EndHeight > 0 {
+			if end, err = search(args.EndHeight + 1); err != nil {
+				return
+			}
+		}
+
+		var accTxInfo *info.AccountTxInfo
+		scanned := uint64(0)
+
+		if !args.Dsc {
+
+			i := generics.Max(args.Cursor, start)
+			for ; i < end && uint64(len(reply.Txs)) < limit && scanned < config.API_ACCOUNT_TXS_HISTORY_MAX_SCAN; i++ {
+				if accTxInfo, err = load(i); err != nil {
+					return
+				}
+				if args.matches(accTxInfo) {
+					reply.Txs = append(reply.Txs, accTxInfo)
+				}
+				scanned++
+			}
+
+			if i < end {
+				reply.HasMore = true
+				reply.NextCursor = i
+			}
+
+		} else {
+
+			i := end
+			if args.Cursor > 0 && args.Cursor < end {
+				i = args.Cursor
+			}
+			for ; i > start && uint64(len(reply.Txs)) < limit && scanned < config.API_ACCOUNT_TXS_HISTORY_MAX_SCAN; i-- {
+				if accTxInfo, err = load(i - 1); err != nil {
+					return
+				}
+				if args.matches(accTxInfo) {
+					reply.Txs = append(reply.Txs, accTxInfo)
+				}
+				scanned++
+			}
+
+			if i > start {
+				reply.HasMore = true
+				reply.NextCursor = i
+			}
+		}
+
+		return
+	})
+}
@@ -0,0 +1,133 @@
+package api_common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/cryptography"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"strconv"
+	"testing"
+)
+
+// createTestAccountTxsHistory stores 6 txs at the heights 1...6. The odd txs are zether txs of the asset with the script 1 received by the account
+func createTestAccountTxsHistory(t *testing.T, publicKey, asset []byte) []*info.AccountTxInfo {
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{Name: "blockchain", Opened: true, DB: db}
+
+	keyStr := string(publicKey)
+
+	list := make([]*info.AccountTxInfo, 6)
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		assetCount := uint64(0)
+		for i := range list {
+
+			indexStr := strconv.Itoa(i)
+
+			list[i] = &info.AccountTxInfo{cryptography.RandomHash(), uint64(i + 1), transaction_type.TX_SIMPLE, []uint64{0}, nil, info.ACCOUNT_TX_DIRECTION_OUT}
+			if i%2 == 1 {
+				list[i].Version = transaction_type.TX_ZETHER
+				list[i].Scripts = []uint64{1}
+				list[i].Assets = [][]byte{asset}
+				list[i].Direction = info.ACCOUNT_TX_DIRECTION_IN
+
+				writer.Put("addrAssetTx:"+keyStr+string(asset)+":"+strconv.FormatUint(assetCount, 10), []byte(indexStr))
+				assetCount++
+			}
+
+			var data []byte
+			if data, err = msgpack.Marshal(list[i]); err != nil {
+				return
+			}
+			writer.Put("addrTx:"+keyStr+":"+indexStr, list[i].TxHash)
+			writer.Put("addrTxInfo:"+keyStr+":"+indexStr, data)
+		}
+
+		writer.Put("addrTxsCount:"+keyStr, []byte(strconv.Itoa(len(list))))
+		writer.Put("addrAssetTxsCount:"+keyStr+string(asset), []byte(strconv.FormatUint(assetCount, 10)))
+		return
+	}))
+
+	return list
+}
+
+func TestGetAccountTxsHistoryCursor(t *testing.T) {
+
+	publicKey := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+	list := createTestAccountTxsHistory(t, publicKey, cryptography.RandomHash())
+
+	api := &APICommon{}
+
+	for _, test := range []struct {
+		dsc     bool
+		cursors []uint64
+		pages   [][]*info.AccountTxInfo
+	}{
+		{false, []uint64{0, 2, 4}, [][]*info.AccountTxInfo{{list[0], list[1]}, {list[2], list[3]}, {list[4], list[5]}}},
+		{true, []uint64{0, 4, 2}, [][]*info.AccountTxInfo{{list[5], list[4]}, {list[3], list[2]}, {list[1], list[0]}}},
+	} {
+
+		cursor := uint64(0)
+		for i, page := range test.pages {
+
+			assert.Equal(t, test.cursors[i], cursor)
+
+			reply := &APIAccountTxsHistoryReply{}
+			assert.NoError(t, api.GetAccountTxsHistory(nil, &APIAccountTxsHistoryRequest{APIAccountBaseRequest: api_types.APIAccountBaseRequest{PublicKey: publicKey}, Cursor: cursor, Dsc: test.dsc, Limit: 2}, reply))
+
+			assert.Equal(t, uint64(len(list)), reply.Count)
+			assert.Equal(t, page, reply.Txs)
+			assert.Equal(t, i < len(test.pages)-1, reply.HasMore)
+			cursor = reply.NextCursor
+		}
+	}
+
+}
+
+func TestGetAccountTxsHistoryFilters(t *testing.T) {
+
+	publicKey := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+	asset := cryptography.RandomHash()
+	list := createTestAccountTxsHistory(t, publicKey, asset)
+
+	api := &APICommon{}
+
+	directionIn := info.ACCOUNT_TX_DIRECTION_IN
+	versionSimple := transaction_type.TX_SIMPLE
+	script := uint64(1)
+
+	for _, test := range []struct {
+		name     string
+		args     *APIAccountTxsHistoryRequest
+		count    uint64
+		expected []*info.AccountTxInfo
+	}{
+		{"all", &APIAccountTxsHistoryRequest{}, 6, list},
+		{"height range", &APIAccountTxsHistoryRequest{StartHeight: 2, EndHeight: 4}, 6, list[1:4]},
+		{"height range dsc", &APIAccountTxsHistoryRequest{StartHeight: 2, EndHeight: 4, Dsc: true}, 6, []*info.AccountTxInfo{list[3], list[2], list[1]}},
+		{"height range empty", &APIAccountTxsHistoryRequest{StartHeight: 7}, 6, nil},
+		{"direction", &APIAccountTxsHistoryRequest{Direction: &directionIn}, 6, []*info.AccountTxInfo{list[1], list[3], list[5]}},
+		{"version", &APIAccountTxsHistoryRequest{Version: &versionSimple}, 6, []*info.AccountTxInfo{list[0], list[2], list[4]}},
+		{"script", &APIAccountTxsHistoryRequest{Script: &script}, 6, []*info.AccountTxInfo{list[1], list[3], list[5]}},
+		{"asset", &APIAccountTxsHistoryRequest{Asset: asset}, 3, []*info.AccountTxInfo{list[1], list[3], list[5]}},
+		{"asset height range", &APIAccountTxsHistoryRequest{Asset: asset, StartHeight: 3, EndHeight: 6}, 3, []*info.AccountTxInfo{list[3], list[5]}},
+		{"asset missing", &APIAccountTxsHistoryRequest{Asset: cryptography.RandomHash()}, 0, nil},
+	} {
+		test.args.PublicKey = publicKey
+
+		reply := &APIAccountTxsHistoryReply{}
+		assert.NoError(t, api.GetAccountTxsHistory(nil, test.args, reply), test.name)
+		assert.Equal(t, test.count, reply.Count, test.name)
+		assert.Equal(t, test.expected, reply.Txs, test.name)
+		assert.False(t, reply.HasMore, test.name)
+	}
+
+}
@@ -7,6 +7,7 @@ import (
 	"pandora-pay/helpers"
 	"pandora-pay/network/api/api_common/api_types"
 	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
@@ -14,11 +15,14 @@ type APIAssetRequest struct {
 	Height     uint64                  `json:"height,omitempty" msgpack:"height,omitempty"`
 	Hash       helpers.Base64          `json:"hash,omitempty" msgpack:"hash,omitempty"`
 	ReturnType api_types.APIReturnType `json:"returnType,omitempty" msgpack:"returnType,omitempty"`
+	Proof      bool                    `json:"proof,omitempty" msgpack:"proof,omitempty"` //the proof is computed for the serialized asset
 }
 
 type APIAssetReply struct {
-	Asset      *asset.Asset `json:"asset,omitempty" msgpack:"asset,omitempty"`
-	Serialized []byte       `json:"serialized,omitempty" msgpack:"serialized,omitempty"`
+	Asset      *asset.Asset            `json:"asset,omitempty" msgpack:"asset,omitempty"`
+	Serialized []byte                  `json:"serialized,omitempty" msgpack:"serialized,omitempty"`
+	StateRoot  *api_types.APIStateRoot `json:"stateRoot,omitempty" msgpack:"stateRoot,omitempty"`
+	Proof      *state_tree.StateProof  `json:"proof,omitempty" msgpack:"proof,omitempty"`
 }
 
 func (api *APICommon) GetAsset(r *http.Request, args *APIAssetRequest, reply *APIAssetReply) (err error) {
@@ -30,7 +34,16 @@ func (api *APICommon) GetAsset(r *http.Request, args *APIAssetRequest, reply *AP
 			}
 		}
 
-		reply.Asset, err = assets.NewAssets(reader).Get(string(args.Hash))
+		if reply.Asset, err = assets.NewAssets(reader).Get(string(args.Hash)); err != nil {
+			return
+		}
+
+		if args.Proof {
+			if reply.StateRoot, err = api.ApiStore.loadStateRoot(reader); err != nil {
+				return
+			}
+			reply.Proof = state_tree.GetProof(reader, "assets", args.Hash)
+		}
 		return
 	}); err != nil || reply.Asset == nil {
 		return helpers.ReturnErrorIfNot(err, "Asset was not found")
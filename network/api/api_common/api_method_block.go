@@ -1,6 +1,7 @@
 package api_common
 
 import (
+	"errors"
 	"github.com/vmihailenco/msgpack/v5"
 	"net/http"
 	"pandora-pay/blockchain/blocks/block"
@@ -37,6 +38,10 @@ func (api *APICommon) GetBlock(r *http.Request, args *APIBlockRequest, reply *AP
 			return helpers.ReturnErrorIfNot(err, "Block was not found")
 		}
 
+		if reply.Block.Height < api.ApiStore.chain.GetPrunedHeight() {
+			return errors.New("Block was pruned")
+		}
+
 		txHashes := [][]byte{}
 		data := reader.Get("blockTxs" + strconv.FormatUint(reply.Block.Height, 10))
 		if err = msgpack.Unmarshal(data, &txHashes); err != nil {
@@ -38,6 +38,10 @@ func (api *APICommon) GetBlockComplete(r *http.Request, args *APIBlockCompleteRe
 			return helpers.ReturnErrorIfNot(err, "Block was not found")
 		}
 
+		if reply.BlockComplete.Block.Height < api.ApiStore.chain.GetPrunedHeight() {
+			return errors.New("Block was pruned")
+		}
+
 		data := reader.Get("blockTxs" + strconv.FormatUint(reply.BlockComplete.Block.Height, 10))
 		if data == nil {
 			return errors.New("Strange. blockTxs was not found")
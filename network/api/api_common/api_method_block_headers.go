@@ -0,0 +1,61 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/config"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIBlockHeadersRequest struct {
+	Height uint64 `json:"height,omitempty" msgpack:"height,omitempty"`
+	Count  uint64 `json:"count,omitempty" msgpack:"count,omitempty"`
+}
+
+type APIBlockHeadersReply struct {
+	Headers [][]byte `json:"headers,omitempty" msgpack:"headers,omitempty"`
+}
+
+// GetBlockHeaders returns the serialized headers of consecutive blocks. The headers of pruned blocks are also available
+func (api *APICommon) GetBlockHeaders(r *http.Request, args *APIBlockHeadersRequest, reply *APIBlockHeadersReply) error {
+
+	if args.Count == 0 {
+		args.Count = 1
+	}
+	if args.Count > config.API_BLOCK_HEADERS_MAX_RESULTS {
+		return errors.New("Too many headers requested")
+	}
+
+	chainHeight := api.chain.GetChainData().Height
+	if args.Height >= chainHeight {
+		return errors.New("Height is invalid")
+	}
+
+	end := args.Height + args.Count
+	if end > chainHeight {
+		end = chainHeight
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		reply.Headers = make([][]byte, 0, end-args.Height)
+		for height := args.Height; height < end; height++ {
+
+			var hash []byte
+			if hash, err = api.ApiStore.chain.LoadBlockHash(reader, height); err != nil {
+				return
+			}
+
+			data := reader.Get("block_ByHash" + string(hash))
+			if data == nil {
+				return errors.New("Block was not found")
+			}
+
+			reply.Headers = append(reply.Headers, helpers.CloneBytes(data))
+		}
+
+		return
+	})
+}
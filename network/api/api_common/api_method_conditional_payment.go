@@ -0,0 +1,40 @@
+package api_common
+
+import (
+	"net/http"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIConditionalPaymentRequest struct {
+	TxId         helpers.Base64          `json:"txId" msgpack:"txId"`
+	PayloadIndex byte                    `json:"payloadIndex,omitempty" msgpack:"payloadIndex,omitempty"`
+	ReturnType   api_types.APIReturnType `json:"returnType,omitempty" msgpack:"returnType,omitempty"`
+}
+
+type APIConditionalPaymentReply struct {
+	ExpiryHeight       uint64                                  `json:"expiryHeight" msgpack:"expiryHeight"`
+	ConditionalPayment *conditional_payment.ConditionalPayment `json:"conditionalPayment,omitempty" msgpack:"conditionalPayment,omitempty"`
+	Serialized         []byte                                  `json:"serialized,omitempty" msgpack:"serialized,omitempty"`
+}
+
+func (api *APICommon) GetConditionalPayment(r *http.Request, args *APIConditionalPaymentRequest, reply *APIConditionalPaymentReply) (err error) {
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		reply.ConditionalPayment, err = data_storage.NewDataStorage(reader).GetConditionalPayment(args.TxId, args.PayloadIndex)
+		return
+	}); err != nil || reply.ConditionalPayment == nil {
+		return helpers.ReturnErrorIfNot(err, "Conditional Payment was not found")
+	}
+
+	reply.ExpiryHeight = reply.ConditionalPayment.BlockHeight
+
+	if args.ReturnType == api_types.RETURN_SERIALIZED {
+		reply.Serialized = helpers.SerializeToBytes(reply.ConditionalPayment)
+		reply.ConditionalPayment = nil
+	}
+	return
+}
@@ -0,0 +1,78 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+type APIConditionalPaymentsByMultisigKeyRequest struct {
+	api_types.APIAccountBaseRequest
+	Start uint64 `json:"start,omitempty" msgpack:"start,omitempty"`
+	Dsc   bool   `json:"dsc,omitempty" msgpack:"dsc,omitempty"`
+}
+
+type APIConditionalPaymentsByMultisigKeyItem struct {
+	TxId         []byte `json:"txId" msgpack:"txId"`
+	PayloadIndex byte   `json:"payloadIndex" msgpack:"payloadIndex"`
+}
+
+type APIConditionalPaymentsByMultisigKeyReply struct {
+	Count               uint64                                     `json:"count,omitempty" msgpack:"count,omitempty"`
+	ConditionalPayments []*APIConditionalPaymentsByMultisigKeyItem `json:"conditionalPayments,omitempty" msgpack:"conditionalPayments,omitempty"`
+}
+
+func (api *APICommon) GetConditionalPaymentsByMultisigKey(r *http.Request, args *APIConditionalPaymentsByMultisigKeyRequest, reply *APIConditionalPaymentsByMultisigKeyReply) (err error) {
+
+	publicKey, err := args.GetPublicKey(true)
+	if err != nil {
+		return
+	}
+
+	publicKeyStr := string(publicKey)
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		data := reader.Get("condPaymentsMultisigCount:" + publicKeyStr)
+		if data == nil {
+			return nil
+		}
+
+		if reply.Count, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+			return
+		}
+
+		s := generics.Min(generics.Max(args.Start, 0), reply.Count)
+		if args.Dsc {
+			if s < config.API_CONDITIONAL_PAYMENTS_MAX_RESULTS {
+				s = 0
+			} else {
+				s -= config.API_CONDITIONAL_PAYMENTS_MAX_RESULTS
+			}
+		}
+		n := generics.Min(s+config.API_CONDITIONAL_PAYMENTS_MAX_RESULTS, reply.Count)
+
+		reply.ConditionalPayments = make([]*APIConditionalPaymentsByMultisigKeyItem, n-s)
+		for i := 0; i < len(reply.ConditionalPayments); i++ {
+			data = reader.Get("condPaymentMultisig:" + publicKeyStr + ":" + strconv.FormatUint(s+uint64(i), 10))
+			if len(data) != cryptography.HashSize+1 {
+				return errors.New("Error reading multisig conditional payment")
+			}
+
+			item := &APIConditionalPaymentsByMultisigKeyItem{data[:cryptography.HashSize], data[cryptography.HashSize]}
+			if args.Dsc {
+				reply.ConditionalPayments[len(reply.ConditionalPayments)-i-1] = item
+			} else {
+				reply.ConditionalPayments[i] = item
+			}
+		}
+
+		return
+	})
+}
@@ -0,0 +1,77 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/config"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIConditionalPaymentsExpiringRequest struct {
+	Start      uint64                  `json:"start,omitempty" msgpack:"start,omitempty"`
+	Blocks     uint64                  `json:"blocks,omitempty" msgpack:"blocks,omitempty"`
+	ReturnType api_types.APIReturnType `json:"returnType,omitempty" msgpack:"returnType,omitempty"`
+}
+
+type APIConditionalPaymentsExpiringReply struct {
+	ConditionalPayments []*APIConditionalPaymentReply `json:"conditionalPayments,omitempty" msgpack:"conditionalPayments,omitempty"`
+}
+
+func (api *APICommon) GetConditionalPaymentsExpiring(r *http.Request, args *APIConditionalPaymentsExpiringRequest, reply *APIConditionalPaymentsExpiringReply) (err error) {
+
+	if args.Blocks == 0 {
+		args.Blocks = 1
+	}
+	if args.Blocks > config.API_CONDITIONAL_PAYMENTS_EXPIRING_MAX_BLOCKS {
+		return errors.New("Too many blocks requested")
+	}
+
+	if args.Start == 0 {
+		args.Start = api.chain.GetChainData().Height
+	}
+
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		collection := conditional_payments_list.NewConditionalPaymentsCollection(reader)
+
+		for height := args.Start; height < args.Start+args.Blocks; height++ {
+
+			var conditionalPaymentsMap *conditional_payments_list.ConditionalPaymentsHashMap
+			if conditionalPaymentsMap, err = collection.GetMap(height); err != nil {
+				return
+			}
+
+			for i := uint64(0); i < conditionalPaymentsMap.Count; i++ {
+
+				if len(reply.ConditionalPayments) >= config.API_CONDITIONAL_PAYMENTS_EXPIRING_MAX_RESULTS {
+					return
+				}
+
+				var condPayment *conditional_payment.ConditionalPayment
+				if condPayment, err = conditionalPaymentsMap.GetByIndex(i); err != nil {
+					return
+				}
+
+				reply.ConditionalPayments = append(reply.ConditionalPayments, &APIConditionalPaymentReply{height, condPayment, nil})
+			}
+		}
+
+		return
+	}); err != nil {
+		return
+	}
+
+	if args.ReturnType == api_types.RETURN_SERIALIZED {
+		for _, it := range reply.ConditionalPayments {
+			it.Serialized = helpers.SerializeToBytes(it.ConditionalPayment)
+			it.ConditionalPayment = nil
+		}
+	}
+
+	return
+}
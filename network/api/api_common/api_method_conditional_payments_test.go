@@ -0,0 +1,158 @@
+package api_common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"strconv"
+	"testing"
+)
+
+// createTestConditionalPayments stores processed conditional payments expiring at the given heights
+func createTestConditionalPayments(t *testing.T, multisigPublicKey []byte, expiryHeights []uint64) []*conditional_payment.ConditionalPayment {
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{Name: "blockchain", Opened: true, DB: db}
+
+	list := make([]*conditional_payment.ConditionalPayment, len(expiryHeights))
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(writer)
+
+		for i, expiryHeight := range expiryHeights {
+
+			txId := cryptography.RandomHash()
+			key := string(txId) + "_0"
+
+			list[i] = conditional_payment.NewConditionalPayment([]byte(key), 0, expiryHeight)
+			list[i].TxId = txId
+			list[i].Processed = true
+			list[i].MultisigThreshold = 1
+			list[i].MultisigPublicKeys = [][]byte{multisigPublicKey}
+
+			conditionalPaymentsMap, err := dataStorage.ConditionalPaymentsCollection.GetMap(expiryHeight)
+			assert.NoError(t, err)
+			assert.NoError(t, conditionalPaymentsMap.Update(key, list[i]))
+
+			//index stored by the seed wallet nodes
+			writer.Put("condPaymentMultisig:"+string(multisigPublicKey)+":"+strconv.Itoa(i), append(helpers.CloneBytes(txId), 0))
+		}
+		writer.Put("condPaymentsMultisigCount:"+string(multisigPublicKey), []byte(strconv.Itoa(len(expiryHeights))))
+
+		return dataStorage.CommitChanges()
+	}))
+
+	return list
+}
+
+func TestGetConditionalPayment(t *testing.T) {
+
+	list := createTestConditionalPayments(t, addresses.GenerateNewPrivateKey().GeneratePublicKey(), []uint64{100})
+
+	api := &APICommon{}
+
+	reply := &APIConditionalPaymentReply{}
+	assert.NoError(t, api.GetConditionalPayment(nil, &APIConditionalPaymentRequest{list[0].TxId, 0, api_types.RETURN_JSON}, reply))
+	assert.Equal(t, uint64(100), reply.ExpiryHeight)
+	assert.Equal(t, list[0].TxId, reply.ConditionalPayment.TxId)
+	assert.True(t, reply.ConditionalPayment.Processed)
+
+	reply = &APIConditionalPaymentReply{}
+	assert.NoError(t, api.GetConditionalPayment(nil, &APIConditionalPaymentRequest{list[0].TxId, 0, api_types.RETURN_SERIALIZED}, reply))
+	assert.Nil(t, reply.ConditionalPayment)
+	assert.Equal(t, helpers.SerializeToBytes(list[0]), reply.Serialized)
+
+	assert.EqualError(t, api.GetConditionalPayment(nil, &APIConditionalPaymentRequest{list[0].TxId, 1, api_types.RETURN_JSON}, &APIConditionalPaymentReply{}), "Conditional Payment was not found")
+	assert.EqualError(t, api.GetConditionalPayment(nil, &APIConditionalPaymentRequest{cryptography.RandomHash(), 0, api_types.RETURN_JSON}, &APIConditionalPaymentReply{}), "Conditional Payment was not found")
+}
+
+func TestGetConditionalPaymentsExpiring(t *testing.T) {
+
+	list := createTestConditionalPayments(t, addresses.GenerateNewPrivateKey().GeneratePublicKey(), []uint64{100, 100, 101, 103})
+
+	api := &APICommon{}
+
+	for _, test := range []struct {
+		start    uint64
+		blocks   uint64
+		expected []*conditional_payment.ConditionalPayment
+	}{
+		{100, 0, list[:2]},
+		{100, 2, list[:3]},
+		{101, 3, list[2:]},
+		{104, 10, nil},
+	} {
+		reply := &APIConditionalPaymentsExpiringReply{}
+		assert.NoError(t, api.GetConditionalPaymentsExpiring(nil, &APIConditionalPaymentsExpiringRequest{test.start, test.blocks, api_types.RETURN_JSON}, reply))
+
+		assert.Equal(t, len(test.expected), len(reply.ConditionalPayments))
+		for i, it := range reply.ConditionalPayments {
+			assert.Equal(t, test.expected[i].BlockHeight, it.ExpiryHeight)
+			assert.Equal(t, test.expected[i].TxId, it.ConditionalPayment.TxId)
+		}
+	}
+
+	reply := &APIConditionalPaymentsExpiringReply{}
+	assert.NoError(t, api.GetConditionalPaymentsExpiring(nil, &APIConditionalPaymentsExpiringRequest{101, 1, api_types.RETURN_SERIALIZED}, reply))
+	assert.Equal(t, 1, len(reply.ConditionalPayments))
+	assert.Nil(t, reply.ConditionalPayments[0].ConditionalPayment)
+	assert.Equal(t, helpers.SerializeToBytes(list[2]), reply.ConditionalPayments[0].Serialized)
+
+	assert.EqualError(t, api.GetConditionalPaymentsExpiring(nil, &APIConditionalPaymentsExpiringRequest{100, config.API_CONDITIONAL_PAYMENTS_EXPIRING_MAX_BLOCKS + 1, api_types.RETURN_JSON}, &APIConditionalPaymentsExpiringReply{}), "Too many blocks requested")
+}
+
+func TestGetConditionalPaymentsByMultisigKey(t *testing.T) {
+
+	multisigPublicKey := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+
+	expiryHeights := make([]uint64, config.API_CONDITIONAL_PAYMENTS_MAX_RESULTS+2)
+	for i := range expiryHeights {
+		expiryHeights[i] = 100
+	}
+	list := createTestConditionalPayments(t, multisigPublicKey, expiryHeights)
+
+	api := &APICommon{}
+
+	txIds := func(from, to int, dsc bool) (out []*APIConditionalPaymentsByMultisigKeyItem) {
+		for i := from; i < to; i++ {
+			item := &APIConditionalPaymentsByMultisigKeyItem{list[i].TxId, 0}
+			if dsc {
+				out = append([]*APIConditionalPaymentsByMultisigKeyItem{item}, out...)
+			} else {
+				out = append(out, item)
+			}
+		}
+		return
+	}
+
+	count := len(list)
+	for _, test := range []struct {
+		start    uint64
+		dsc      bool
+		expected []*APIConditionalPaymentsByMultisigKeyItem
+	}{
+		{0, false, txIds(0, count-2, false)},
+		{uint64(count - 2), false, txIds(count-2, count, false)},
+		{uint64(count), true, txIds(2, count, true)},
+		{2, true, txIds(0, count-2, true)}, //like account/txs, the first page is returned
+	} {
+		reply := &APIConditionalPaymentsByMultisigKeyReply{}
+		assert.NoError(t, api.GetConditionalPaymentsByMultisigKey(nil, &APIConditionalPaymentsByMultisigKeyRequest{api_types.APIAccountBaseRequest{PublicKey: multisigPublicKey}, test.start, test.dsc}, reply))
+		assert.Equal(t, uint64(count), reply.Count)
+		assert.Equal(t, test.expected, reply.ConditionalPayments)
+	}
+
+	reply := &APIConditionalPaymentsByMultisigKeyReply{}
+	assert.NoError(t, api.GetConditionalPaymentsByMultisigKey(nil, &APIConditionalPaymentsByMultisigKeyRequest{api_types.APIAccountBaseRequest{PublicKey: addresses.GenerateNewPrivateKey().GeneratePublicKey()}, 0, false}, reply))
+	assert.Equal(t, uint64(0), reply.Count)
+	assert.Empty(t, reply.ConditionalPayments)
+}
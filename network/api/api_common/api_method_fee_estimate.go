@@ -0,0 +1,78 @@
+package api_common
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account/asset_fee_liquidity"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_fees"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/mempool"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+type APIFeeEstimateRequest struct {
+	Target uint64         `json:"target,omitempty" msgpack:"target,omitempty"` //number of blocks, at most mempool.FEE_ESTIMATOR_BLOCKS
+	Asset  helpers.Base64 `json:"asset,omitempty" msgpack:"asset,omitempty"`
+}
+
+type APIFeeEstimateReply struct {
+	Target               uint64 `json:"target" msgpack:"target"`
+	FeePerByte           uint64 `json:"feePerByte" msgpack:"feePerByte"`
+	FeePerByteZether     uint64 `json:"feePerByteZether" msgpack:"feePerByteZether"`
+	FeePerByteExtraSpace uint64 `json:"feePerByteExtraSpace" msgpack:"feePerByteExtraSpace"`
+	Asset                []byte `json:"asset,omitempty" msgpack:"asset,omitempty"`
+	AssetRate            uint64 `json:"assetRate,omitempty" msgpack:"assetRate,omitempty"`
+	AssetLeadingZeros    byte   `json:"assetLeadingZeros,omitempty" msgpack:"assetLeadingZeros,omitempty"`
+	AssetFeePerByte      uint64 `json:"assetFeePerByte,omitempty" msgpack:"assetFeePerByte,omitempty"` //fee per byte of zether payloads paid in the asset
+}
+
+func (api *APICommon) GetFeeEstimate(r *http.Request, args *APIFeeEstimateRequest, reply *APIFeeEstimateReply) (err error) {
+
+	if args.Target == 0 {
+		args.Target = 1
+	}
+	if args.Target > mempool.FEE_ESTIMATOR_BLOCKS {
+		return errors.New("Target can not be greater than " + strconv.Itoa(mempool.FEE_ESTIMATOR_BLOCKS))
+	}
+
+	feePerByte := api.mempool.EstimateFeePerByte(args.Target)
+
+	reply.Target = args.Target
+	reply.FeePerByte = generics.Max(feePerByte, config_fees.FEE_PER_BYTE)
+	reply.FeePerByteZether = generics.Max(feePerByte, config_fees.FEE_PER_BYTE_ZETHER)
+	reply.FeePerByteExtraSpace = config_fees.FEE_PER_BYTE_EXTRA_SPACE
+
+	if len(args.Asset) == 0 || bytes.Equal(args.Asset, config_coins.NATIVE_ASSET_FULL) {
+		return
+	}
+
+	if len(args.Asset) != config_coins.ASSET_LENGTH {
+		return errors.New("Asset is invalid")
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(reader)
+
+		var liquidity *asset_fee_liquidity.AssetFeeLiquidity
+		if liquidity, err = dataStorage.GetAssetFeeLiquidityTop(args.Asset); err != nil {
+			return
+		}
+		if liquidity == nil {
+			return errors.New("There is no Asset Fee Liquidity for this asset")
+		}
+
+		reply.Asset = args.Asset
+		reply.AssetRate = liquidity.Rate
+		reply.AssetLeadingZeros = liquidity.LeadingZeros
+		reply.AssetFeePerByte, err = liquidity.ConvertNativeFee(reply.FeePerByteZether)
+
+		return
+	})
+}
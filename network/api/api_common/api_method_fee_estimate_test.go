@@ -0,0 +1,46 @@
+package api_common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/config/config_fees"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/mempool"
+	"testing"
+)
+
+func TestGetFeeEstimate(t *testing.T) {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(false)
+	assert.NoError(t, err)
+
+	workingMempool, err := mempool.CreateMempool(nil)
+	assert.NoError(t, err)
+
+	api := &APICommon{mempool: workingMempool}
+
+	for _, test := range []struct {
+		name   string
+		target uint64
+		reply  uint64
+		err    string
+	}{
+		{"default target", 0, 1, ""},
+		{"target", 5, 5, ""},
+		{"maximum target", mempool.FEE_ESTIMATOR_BLOCKS, mempool.FEE_ESTIMATOR_BLOCKS, ""},
+		{"target too big", mempool.FEE_ESTIMATOR_BLOCKS + 1, 0, "Target can not be greater than 20"},
+		{"target overflowing", 1 << 62, 0, "Target can not be greater than 20"},
+	} {
+
+		reply := &APIFeeEstimateReply{}
+		err := api.GetFeeEstimate(nil, &APIFeeEstimateRequest{Target: test.target}, reply)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.name)
+			continue
+		}
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.reply, reply.Target, test.name)
+		assert.Equal(t, config_fees.FEE_PER_BYTE, reply.FeePerByte, test.name)
+	}
+}
@@ -0,0 +1,23 @@
+package api_common
+
+import (
+	"net/http"
+	"pandora-pay/config"
+)
+
+type APIMempoolInfoReply struct {
+	Count         int32  `json:"count" msgpack:"count"`
+	Size          uint64 `json:"size" msgpack:"size"`
+	MaxTxs        uint64 `json:"maxTxs" msgpack:"maxTxs"`
+	MaxBytes      uint64 `json:"maxBytes" msgpack:"maxBytes"`
+	MinFeePerByte uint64 `json:"minFeePerByte" msgpack:"minFeePerByte"` //0 means the default fee per byte is accepted
+}
+
+func (api *APICommon) GetMempoolInfo(r *http.Request, args *struct{}, reply *APIMempoolInfoReply) error {
+	reply.Count = api.mempool.Txs.GetCount()
+	reply.Size = api.mempool.Txs.GetSize()
+	reply.MaxTxs = config.MEMPOOL_MAX_TXS
+	reply.MaxBytes = config.MEMPOOL_MAX_BYTES
+	reply.MinFeePerByte = api.mempool.Txs.GetMinFeePerByte()
+	return nil
+}
@@ -2,6 +2,7 @@ package api_common
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/cryptography"
@@ -66,5 +67,9 @@ func (api *APICommon) mempoolNewTx(args *APIMempoolNewTxRequest, reply *APIMempo
 }
 
 func (api *APICommon) MempoolNewTx(r *http.Request, args *APIMempoolNewTxRequest, reply *APIMempoolNewTxReply) error {
+	//the websockets are limited per connection and the HTTP GET handlers have no request
+	if r != nil && !api.NewTxsRateLimiters.AllowRemoteAddr(r.RemoteAddr) {
+		return errors.New("Too many requests")
+	}
 	return api.mempoolNewTx(args, reply, advanced_connection_types.UUID_ALL)
 }
@@ -0,0 +1,32 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+type APINetworkAddKnownNodeRequest struct {
+	URL string `json:"url" msgpack:"url"`
+}
+
+type APINetworkAddKnownNodeReply struct {
+	Status bool `json:"status" msgpack:"status"`
+}
+
+func (api *APICommon) NetworkAddKnownNode(r *http.Request, args *APINetworkAddKnownNodeRequest, reply *APINetworkAddKnownNodeReply, authenticated bool) error {
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	if _, err := url.ParseRequestURI(args.URL); err != nil {
+		return err
+	}
+
+	if _, err := api.knownNodes.AddKnownNode(args.URL, false); err != nil {
+		return err
+	}
+
+	reply.Status = true
+	return api.knownNodes.SaveKnownNodes()
+}
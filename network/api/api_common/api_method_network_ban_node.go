@@ -0,0 +1,38 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type APINetworkBanNodeRequest struct {
+	URL      string `json:"url" msgpack:"url"`
+	Message  string `json:"message" msgpack:"message"`
+	Duration uint64 `json:"duration" msgpack:"duration"` //seconds
+}
+
+type APINetworkBanNodeReply struct {
+	Status bool `json:"status" msgpack:"status"`
+}
+
+func (api *APICommon) NetworkBanNode(r *http.Request, args *APINetworkBanNodeRequest, reply *APINetworkBanNodeReply, authenticated bool) error {
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	if args.Duration == 0 {
+		return errors.New("Duration must be greater than zero")
+	}
+
+	u, err := url.ParseRequestURI(args.URL)
+	if err != nil {
+		return err
+	}
+
+	api.knownNodes.BanNode(u, args.Message, time.Duration(args.Duration)*time.Second)
+
+	reply.Status = true
+	return api.knownNodes.SaveKnownNodes()
+}
@@ -0,0 +1,37 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+)
+
+type APINetworkBannedNode struct {
+	URL        string `json:"url" msgpack:"url"`
+	Timestamp  int64  `json:"timestamp" msgpack:"timestamp"`
+	Expiration int64  `json:"expiration" msgpack:"expiration"`
+	Message    string `json:"message" msgpack:"message"`
+}
+
+type APINetworkBannedNodesReply struct {
+	Nodes []*APINetworkBannedNode `json:"nodes" msgpack:"nodes"`
+}
+
+func (api *APICommon) GetNetworkBannedNodes(r *http.Request, args *struct{}, reply *APINetworkBannedNodesReply, authenticated bool) error {
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	list := api.bannedNodes.GetList()
+
+	reply.Nodes = make([]*APINetworkBannedNode, len(list))
+	for i, bannedNode := range list {
+		reply.Nodes[i] = &APINetworkBannedNode{
+			bannedNode.URL.String(),
+			bannedNode.Timestamp.Unix(),
+			bannedNode.Expiration.Unix(),
+			bannedNode.Message,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+)
+
+type APINetworkKnownNode struct {
+	URL       string `json:"url" msgpack:"url"`
+	IsSeed    bool   `json:"isSeed" msgpack:"isSeed"`
+	Score     int32  `json:"score" msgpack:"score"`
+	LastSeen  int64  `json:"lastSeen" msgpack:"lastSeen"`
+	Successes int32  `json:"successes" msgpack:"successes"`
+	Failures  int32  `json:"failures" msgpack:"failures"`
+}
+
+type APINetworkKnownNodesReply struct {
+	Nodes []*APINetworkKnownNode `json:"nodes" msgpack:"nodes"`
+}
+
+func (api *APICommon) GetNetworkKnownNodes(r *http.Request, args *struct{}, reply *APINetworkKnownNodesReply, authenticated bool) error {
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	list := api.knownNodes.GetList()
+
+	reply.Nodes = make([]*APINetworkKnownNode, len(list))
+	for i, knownNode := range list {
+		reply.Nodes[i] = &APINetworkKnownNode{
+			knownNode.URL,
+			knownNode.IsSeed,
+			atomic.LoadInt32(&knownNode.Score),
+			atomic.LoadInt64(&knownNode.LastSeen),
+			atomic.LoadInt32(&knownNode.Successes),
+			atomic.LoadInt32(&knownNode.Failures),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+)
+
+type APINetworkRemoveKnownNodeRequest struct {
+	URL string `json:"url" msgpack:"url"`
+}
+
+type APINetworkRemoveKnownNodeReply struct {
+	Status bool `json:"status" msgpack:"status"`
+}
+
+func (api *APICommon) NetworkRemoveKnownNode(r *http.Request, args *APINetworkRemoveKnownNodeRequest, reply *APINetworkRemoveKnownNodeReply, authenticated bool) error {
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	knownNode := api.knownNodes.GetKnownNode(args.URL)
+	if knownNode == nil {
+		return errors.New("Known node was not found")
+	}
+
+	api.knownNodes.RemoveKnownNode(knownNode)
+
+	reply.Status = true
+	return api.knownNodes.SaveKnownNodes()
+}
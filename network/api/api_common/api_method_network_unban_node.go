@@ -0,0 +1,23 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+)
+
+type APINetworkUnbanNodeRequest struct {
+	URL string `json:"url" msgpack:"url"`
+}
+
+type APINetworkUnbanNodeReply struct {
+	Status bool `json:"status" msgpack:"status"`
+}
+
+func (api *APICommon) NetworkUnbanNode(r *http.Request, args *APINetworkUnbanNodeRequest, reply *APINetworkUnbanNodeReply, authenticated bool) error {
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	reply.Status = api.bannedNodes.Unban(args.URL)
+	return nil
+}
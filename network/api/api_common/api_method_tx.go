@@ -42,8 +42,8 @@ func (api *APICommon) openLoadTx(args *APITxRequest, reply *APITxReply) error {
 		hashStr := string(args.Hash)
 		var data []byte
 
-		if data = reader.Get("tx:" + hashStr); data == nil {
-			return errors.New("Tx not found")
+		if data, err = api.ApiStore.loadTxSerialized(reader, args.Hash); err != nil {
+			return
 		}
 
 		if args.ReturnType == api_types.RETURN_SERIALIZED {
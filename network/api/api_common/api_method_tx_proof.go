@@ -0,0 +1,79 @@
+package api_common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/vmihailenco/msgpack/v5"
+	"net/http"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/cryptography/merkle_tree"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+type APITxProofRequest struct {
+	Hash helpers.Base64 `json:"hash" msgpack:"hash"`
+}
+
+type APITxProofReply struct {
+	Proof           *merkle_tree.MerkleProof `json:"proof" msgpack:"proof"` //the tx hash is included in the block MerkleHash. The blocks of BLOCK_VERSION_STATE_HASH use domain separation
+	Block           *block.Block             `json:"block" msgpack:"block"`
+	BlockSerialized []byte                   `json:"serialized" msgpack:"serialized"` //the block hash is the SHA3 of it
+	TxsCount        uint64                   `json:"txsCount" msgpack:"txsCount"`     //the proof index must be lower than it
+	Confirmations   uint64                   `json:"confirmations" msgpack:"confirmations"`
+}
+
+func (api *APICommon) GetTxProof(r *http.Request, args *APITxProofRequest, reply *APITxProofReply) error {
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		data := reader.Get("txBlock:" + string(args.Hash))
+		if data == nil {
+			return errors.New("Tx not found")
+		}
+
+		blockHeight, _ := binary.Uvarint(data)
+		if blockHeight < api.ApiStore.chain.GetPrunedHeight() {
+			return errors.New("Block was pruned")
+		}
+
+		txHashes := [][]byte{}
+		if err = msgpack.Unmarshal(reader.Get("blockTxs"+strconv.FormatUint(blockHeight, 10)), &txHashes); err != nil {
+			return
+		}
+
+		index := -1
+		for i, txHash := range txHashes {
+			if bytes.Equal(txHash, args.Hash) {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			return errors.New("Tx not found in the block")
+		}
+
+		var blockHash []byte
+		if blockHash, err = api.ApiStore.chain.LoadBlockHash(reader, blockHeight); err != nil {
+			return
+		}
+		if reply.Block, err = api.ApiStore.loadBlock(reader, blockHash); err != nil || reply.Block == nil {
+			return helpers.ReturnErrorIfNot(err, "Block was not found")
+		}
+
+		//MerkleProofGenerate requires the index to be lower than the txs count
+		if reply.Proof, err = merkle_tree.MerkleProofGenerate(txHashes, index, reply.Block.Version == block.BLOCK_VERSION_STATE_HASH); err != nil {
+			return
+		}
+
+		reply.BlockSerialized = helpers.SerializeToBytes(reply.Block)
+		reply.TxsCount = uint64(len(txHashes))
+
+		chainHeight, _ := binary.Uvarint(reader.Get("chainHeight"))
+		reply.Confirmations = chainHeight - blockHeight
+
+		return
+	})
+}
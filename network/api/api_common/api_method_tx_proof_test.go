@@ -0,0 +1,83 @@
+package api_common
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/merkle_tree"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestGetTxProof(t *testing.T) {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(false)
+	assert.NoError(t, err)
+
+	chain, err := blockchain.CreateBlockchain(nil, nil)
+	assert.NoError(t, err)
+	api := &APICommon{ApiStore: NewAPIStore(chain)}
+
+	for _, version := range []uint64{block.BLOCK_VERSION, block.BLOCK_VERSION_STATE_HASH} {
+
+		db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+		assert.NoError(t, err)
+		store.StoreBlockchain = &store.Store{Name: "blockchain", Opened: true, DB: db}
+
+		txHashes := [][]byte{cryptography.RandomHash(), cryptography.RandomHash(), cryptography.RandomHash()}
+
+		blk := &block.Block{
+			BlockHeader:    &block.BlockHeader{Version: version, Height: 5},
+			MerkleHash:     merkle_tree.MerkleRoot(txHashes, version == block.BLOCK_VERSION_STATE_HASH),
+			PrevHash:       cryptography.RandomHash(),
+			PrevKernelHash: cryptography.RandomHash(),
+			StakingAmount:  1,
+			StakingNonce:   helpers.RandomBytes(32),
+		}
+		if version == block.BLOCK_VERSION_STATE_HASH {
+			blk.StateHash = cryptography.RandomHash()
+		}
+		assert.NoError(t, blk.BloomNow())
+
+		assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+			data, err := msgpack.Marshal(txHashes)
+			assert.NoError(t, err)
+			writer.Put("blockTxs5", data)
+			writer.Put("blockHash_ByHeight5", blk.Bloom.Hash)
+			writer.Put("block_ByHash"+string(blk.Bloom.Hash), blk.Bloom.Serialized)
+			writer.Put("chainHeight", binary.AppendUvarint(nil, 8))
+			for _, txHash := range txHashes {
+				writer.Put("txBlock:"+string(txHash), binary.AppendUvarint(nil, 5))
+			}
+			return
+		}))
+
+		for i, txHash := range txHashes {
+			reply := &APITxProofReply{}
+			assert.NoError(t, api.GetTxProof(nil, &APITxProofRequest{txHash}, reply))
+
+			assert.Equal(t, uint64(i), reply.Proof.Index)
+			assert.Equal(t, uint64(3), reply.TxsCount)
+			assert.Equal(t, uint64(3), reply.Confirmations)
+			assert.Equal(t, blk.Bloom.Serialized, reply.BlockSerialized)
+			assert.True(t, reply.Proof.Verify(reply.Block.MerkleHash, txHash, reply.TxsCount, reply.Block.Version == block.BLOCK_VERSION_STATE_HASH))
+
+			//the last tx is duplicated in the tree, but the index can't be after the txs count
+			if i == len(txHashes)-1 {
+				reply.Proof.Index += 1
+				assert.False(t, reply.Proof.Verify(reply.Block.MerkleHash, txHash, reply.TxsCount, reply.Block.Version == block.BLOCK_VERSION_STATE_HASH))
+			}
+		}
+
+		assert.EqualError(t, api.GetTxProof(nil, &APITxProofRequest{cryptography.RandomHash()}, &APITxProofReply{}), "Tx not found")
+	}
+}
@@ -1,7 +1,6 @@
 package api_common
 
 import (
-	"errors"
 	"net/http"
 	"pandora-pay/cryptography"
 	"pandora-pay/helpers"
@@ -27,12 +26,7 @@ func (api *APICommon) openLoadTxOnly(args *APITxRawRequest, reply *APITxRawReply
 			}
 		}
 
-		hashStr := string(args.Hash)
-
-		if reply.Tx = reader.Get("tx:" + hashStr); reply.Tx == nil {
-			return errors.New("Tx not found")
-		}
-
+		reply.Tx, err = api.ApiStore.loadTxSerialized(reader, args.Hash)
 		return
 	})
 }
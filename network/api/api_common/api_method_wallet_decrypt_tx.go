@@ -37,7 +37,9 @@ func (api *APICommon) GetWalletDecryptTx(r *http.Request, args *APIWalletDecrypt
 	var txSerialized []byte
 	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
-		txSerialized = reader.Get("tx:" + string(args.Hash))
+		if txSerialized, err = api.ApiStore.loadTxSerialized(reader, args.Hash); err != nil {
+			return
+		}
 
 		if data := reader.Get("txBlock:" + string(args.Hash)); data != nil {
 			var blockHeight, chainHeight uint64
@@ -55,10 +57,6 @@ func (api *APICommon) GetWalletDecryptTx(r *http.Request, args *APIWalletDecrypt
 		return
 	}
 
-	if len(txSerialized) == 0 {
-		return errors.New("Tx was not found in the storage")
-	}
-
 	tx := &transaction.Transaction{}
 	if err = tx.Deserialize(advanced_buffers.NewBufferReader(txSerialized)); err != nil {
 		return
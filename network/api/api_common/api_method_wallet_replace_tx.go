@@ -0,0 +1,35 @@
+package api_common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/helpers"
+)
+
+type APIWalletReplaceTxRequest struct {
+	Hash      helpers.Base64 `json:"hash" msgpack:"hash"`
+	Cancel    bool           `json:"cancel" msgpack:"cancel"`
+	Propagate bool           `json:"propagate" msgpack:"propagate"`
+}
+
+type APIWalletReplaceTxReply struct {
+	Result bool                     `json:"result" msgpack:"result"`
+	Tx     *transaction.Transaction `json:"tx" msgpack:"tx"`
+}
+
+func (api *APICommon) WalletReplaceTx(r *http.Request, args *APIWalletReplaceTxRequest, reply *APIWalletReplaceTxReply, authenticated bool) (err error) {
+
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	if reply.Tx, err = api.txsBuilder.ReplaceTx(args.Hash, args.Cancel, args.Propagate, true, true, context.Background(), func(string) {}); err != nil {
+		return
+	}
+
+	reply.Result = true
+
+	return
+}
@@ -7,6 +7,7 @@ import (
 	"pandora-pay/blockchain/blocks/block"
 	"pandora-pay/blockchain/info"
 	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/network/api/api_common/api_types"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
 )
@@ -31,6 +32,18 @@ func (apiStore *APIStore) loadTxPreview(reader store_db_interface.StoreDBTransac
 	return msgpack.Unmarshal(data, reply)
 }
 
+// the pruned nodes keep txHash: and txBlock: of the pruned txs
+func (apiStore *APIStore) loadTxSerialized(reader store_db_interface.StoreDBTransactionInterface, hash []byte) ([]byte, error) {
+	data := reader.Get("tx:" + string(hash))
+	if data == nil {
+		if reader.Exists("txBlock:" + string(hash)) {
+			return nil, errors.New("Tx was pruned")
+		}
+		return nil, errors.New("Tx not found")
+	}
+	return data, nil
+}
+
 func (apiStore *APIStore) loadAssetHash(reader store_db_interface.StoreDBTransactionInterface, height uint64) ([]byte, error) {
 	if height < 0 {
 		return nil, errors.New("Height is invalid")
@@ -54,6 +67,30 @@ func (chain *APIStore) loadBlock(reader store_db_interface.StoreDBTransactionInt
 	return blk, blk.Deserialize(advanced_buffers.NewBufferReader(blockData))
 }
 
+// the root committed in the header of the last block. The proofs are computed on the state of the same view
+func (apiStore *APIStore) loadStateRoot(reader store_db_interface.StoreDBTransactionInterface) (*api_types.APIStateRoot, error) {
+
+	hash := reader.Get("chainHash")
+	if hash == nil {
+		return nil, errors.New("Chain was not found")
+	}
+
+	blk, err := apiStore.loadBlock(reader, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if blk.Version != block.BLOCK_VERSION_STATE_HASH {
+		return nil, errors.New("The state root is not committed yet")
+	}
+
+	return &api_types.APIStateRoot{
+		blk.StateHash,
+		blk.Height,
+		hash,
+	}, nil
+}
+
 func NewAPIStore(chain *blockchain.Blockchain) *APIStore {
 	return &APIStore{
 		chain: chain,
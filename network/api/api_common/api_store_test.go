@@ -0,0 +1,43 @@
+package api_common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/cryptography"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestLoadTxSerialized(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+
+	apiStore := &APIStore{}
+	txHash, prunedTxHash := cryptography.RandomHash(), cryptography.RandomHash()
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("tx:"+string(txHash), []byte{1, 2})
+		writer.Put("txHash:"+string(txHash), []byte{1})
+		writer.Put("txBlock:"+string(txHash), []byte{10})
+
+		//pruneBlocksComplete keeps txHash: and txBlock:
+		writer.Put("txHash:"+string(prunedTxHash), []byte{1})
+		writer.Put("txBlock:"+string(prunedTxHash), []byte{1})
+		return nil
+	}))
+
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+
+		data, err := apiStore.loadTxSerialized(reader, txHash)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{1, 2}, data)
+
+		_, err = apiStore.loadTxSerialized(reader, prunedTxHash)
+		assert.EqualError(t, err, "Tx was pruned")
+
+		_, err = apiStore.loadTxSerialized(reader, cryptography.RandomHash())
+		assert.EqualError(t, err, "Tx not found")
+		return nil
+	}))
+}
@@ -18,6 +18,7 @@ const (
 	SUBSCRIPTION_ASSET
 	SUBSCRIPTION_REGISTRATION
 	SUBSCRIPTION_TRANSACTION
+	SUBSCRIPTION_CONDITIONAL_PAYMENTS
 )
 
 type APIReturnType uint8
@@ -23,8 +23,9 @@ type APISubscriptionNotificationAccountTxExtraBlockchain struct {
 }
 
 type APISubscriptionNotificationAccountTxExtraMempool struct {
-	Inserted bool `json:"inserted,omitempty" msgpack:"inserted,omitempty"`
-	Included bool `json:"included,omitempty" msgpack:"included,omitempty"`
+	Inserted   bool   `json:"inserted,omitempty" msgpack:"inserted,omitempty"`
+	Included   bool   `json:"included,omitempty" msgpack:"included,omitempty"`
+	ReplacedBy []byte `json:"replacedBy,omitempty" msgpack:"replacedBy,omitempty"`
 }
 
 type APISubscriptionNotificationAccountExtra struct {
@@ -44,17 +45,40 @@ type APISubscriptionNotificationAssetExtra struct {
 	Index uint64 `json:"index" msgpack:"index"`
 }
 
+type ConditionalPaymentNotificationStatus uint8
+
+const (
+	CONDITIONAL_PAYMENT_CREATED ConditionalPaymentNotificationStatus = iota
+	CONDITIONAL_PAYMENT_RESOLVED
+	CONDITIONAL_PAYMENT_EXPIRED
+	CONDITIONAL_PAYMENT_REMOVED
+)
+
+type APISubscriptionNotificationConditionalPaymentExtra struct {
+	TxId         []byte                               `json:"txId" msgpack:"txId"`
+	PayloadIndex byte                                 `json:"payloadIndex" msgpack:"payloadIndex"`
+	ExpiryHeight uint64                               `json:"expiryHeight" msgpack:"expiryHeight"`
+	Status       ConditionalPaymentNotificationStatus `json:"status" msgpack:"status"`
+}
+
 type APISubscriptionNotificationAccountTxExtra struct {
 	Blockchain *APISubscriptionNotificationAccountTxExtraBlockchain `json:"blockchain,omitempty" msgpack:"blockchain,omitempty"`
 	Mempool    *APISubscriptionNotificationAccountTxExtraMempool    `json:"mempool,omitempty" msgpack:"mempool,omitempty"`
 }
 
 type APISubscriptionNotificationTxExtraMempool struct {
-	Inserted bool `json:"inserted,omitempty" msgpack:"inserted,omitempty"`
-	Included bool `json:"included,omitempty" msgpack:"included,omitempty"`
+	Inserted   bool   `json:"inserted,omitempty" msgpack:"inserted,omitempty"`
+	Included   bool   `json:"included,omitempty" msgpack:"included,omitempty"`
+	ReplacedBy []byte `json:"replacedBy,omitempty" msgpack:"replacedBy,omitempty"`
 }
 
 type APISubscriptionNotificationTxExtra struct {
 	Blockchain *APISubscriptionNotificationTxExtraBlockchain `json:"blockchain,omitempty" msgpack:"blockchain,omitempty"`
 	Mempool    *APISubscriptionNotificationTxExtraMempool    `json:"mempool,omitempty" msgpack:"mempool,omitempty"`
 }
+
+type APIStateRoot struct {
+	Root        []byte `json:"root" msgpack:"root"`
+	BlockHeight uint64 `json:"blockHeight" msgpack:"blockHeight"`
+	BlockHash   []byte `json:"blockHash" msgpack:"blockHash"` //the root is committed in the header of this block
+}
@@ -9,6 +9,7 @@ import (
 	"pandora-pay/blockchain/blockchain_sync"
 	"pandora-pay/blockchain/info"
 	"pandora-pay/config"
+	"pandora-pay/helpers/rate_limiter"
 	"pandora-pay/helpers/urldecoder"
 	"pandora-pay/network/api/api_common"
 	"pandora-pay/network/api/api_common/api_delegator_node"
@@ -17,11 +18,12 @@ import (
 )
 
 type API struct {
-	GetMap    map[string]func(values url.Values) (interface{}, error)
-	PostMap   map[string]func(values io.ReadCloser) (interface{}, error)
-	chain     *blockchain.Blockchain
-	apiCommon *api_common.APICommon
-	apiStore  *api_common.APIStore
+	GetMap       map[string]func(values url.Values) (interface{}, error)
+	PostMap      map[string]func(values io.ReadCloser) (interface{}, error)
+	RateLimiters map[string]*rate_limiter.RateLimiters //requests limited per remote address
+	chain        *blockchain.Blockchain
+	apiCommon    *api_common.APICommon
+	apiStore     *api_common.APIStore
 }
 
 func handleAuthenticated[T any, B any](callback func(r *http.Request, args *T, reply *B, authenticated bool) error) func(values url.Values) (interface{}, error) {
@@ -88,40 +90,57 @@ func NewAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon, chai
 	}
 
 	api.GetMap = map[string]func(values url.Values) (interface{}, error){
-		"ping":                    handle[struct{}, api_common.APIPingReply](api.apiCommon.GetPing),
-		"":                        handle[struct{}, api_common.APIInfoReply](api.apiCommon.GetInfo),
-		"chain":                   handle[struct{}, api_common.APIBlockchain](api.apiCommon.GetBlockchain),
-		"blockchain":              handle[struct{}, api_common.APIBlockchain](api.apiCommon.GetBlockchain),
-		"blockchain/staking-info": handle[api_common.APIStakingInfoRequest, api_common.APIStakingInfoReply](api.apiCommon.GetStakingInfo),
-		"blockchain/genesis-info": handle[api_common.APIGenesisInfoRequest, api_common.APIGenesisInfoReply](api.apiCommon.GetGenesisInfo),
-		"blockchain/supply":       handle[struct{}, api_common.APISupply](api.apiCommon.GetSupply),
-		"blockchain/supply-only":  handle[struct{}, uint64](api.apiCommon.GetSupplyOnly),
-		"sync":                    handle[struct{}, blockchain_sync.BlockchainSyncData](api.apiCommon.GetBlockchainSync),
-		"block-hash":              handle[api_common.APIBlockHashRequest, api_common.APIBlockHashReply](api.apiCommon.GetBlockHash),
-		"block/exists":            handle[api_common.APIBlockExistsRequest, api_common.APIBlockExistsReply](api.apiCommon.GetBlockExists),
-		"block":                   handle[api_common.APIBlockRequest, api_common.APIBlockReply](api.apiCommon.GetBlock),
-		"block-complete":          handle[api_common.APIBlockCompleteRequest, api_common.APIBlockCompleteReply](api.apiCommon.GetBlockComplete),
-		"tx-hash":                 handle[api_common.APITxHashRequest, api_common.APITxHashReply](api.apiCommon.GetTxHash),
-		"tx":                      handle[api_common.APITxRequest, api_common.APITxReply](api.apiCommon.GetTx),
-		"tx/exists":               handle[api_common.APITxExistsRequest, api_common.APITxExistsReply](api.apiCommon.GetTxExists),
-		"tx-raw":                  handle[api_common.APITxRawRequest, api_common.APITxRawReply](api.apiCommon.GetTxRaw),
-		"account":                 handle[api_common.APIAccountRequest, api_common.APIAccountReply](api.apiCommon.GetAccount),
-		"accounts/count":          handle[api_common.APIAccountsCountRequest, api_common.APIAccountsCountReply](api.apiCommon.GetAccountsCount),
-		"accounts/keys-by-index":  handle[api_common.APIAccountsKeysByIndexRequest, api_common.APIAccountsKeysByIndexReply](api.apiCommon.GetAccountsKeysByIndex),
-		"accounts/by-keys":        handle[api_common.APIAccountsByKeysRequest, api_common.APIAccountsByKeysReply](api.apiCommon.GetAccountsByKeys),
-		"asset":                   handle[api_common.APIAssetRequest, api_common.APIAssetReply](api.apiCommon.GetAsset),
-		"asset/exists":            handle[api_common.APIAssetExistsRequest, api_common.APIAssetExistsReply](api.apiCommon.GetAssetExists),
-		"asset/fee-liquidity":     handle[api_common.APIAssetFeeLiquidityFeeRequest, api_common.APIAssetFeeLiquidityFeeReply](api.apiCommon.GetAssetFeeLiquidity),
-		"mempool":                 handle[api_common.APIMempoolRequest, api_common.APIMempoolReply](api.apiCommon.GetMempool),
-		"mempool/tx-exists":       handle[api_common.APIMempoolExistsRequest, api_common.APIMempoolExistsReply](api.apiCommon.GetMempoolExists),
-		"mempool/new-tx":          handle[api_common.APIMempoolNewTxRequest, api_common.APIMempoolNewTxReply](api.apiCommon.MempoolNewTx),
-		"network/nodes":           handle[struct{}, api_common.APINetworkNodesReply](api.apiCommon.GetNetworkNodes),
-		"wallet/get-addresses":    handleAuthenticated[struct{}, api_common.APIWalletGetAccountsReply](api.apiCommon.GetWalletAddresses),
-		"wallet/generate-address": handleAuthenticated[api_common.APIWalletGenerateAddressRequest, api_common.APIWalletGenerateAddressReply](api.apiCommon.GetWalletGenerateAddress),
-		"wallet/create-address":   handleAuthenticated[api_common.APIWalletCreateAddressRequest, api_common.APIWalletCreateAddressReply](api.apiCommon.GetWalletCreateAddress),
-		"wallet/delete-address":   handleAuthenticated[api_common.APIWalletDeleteAddressRequest, api_common.APIWalletDeleteAddressReply](api.apiCommon.GetWalletDeleteAddress),
-		"wallet/get-balances":     handleAuthenticated[api_common.APIWalletGetBalanceRequest, api_common.APIWalletGetBalancesReply](api.apiCommon.GetWalletBalances),
-		"wallet/decrypt-tx":       handleAuthenticated[api_common.APIWalletDecryptTxRequest, api_common.APIWalletDecryptTxReply](api.apiCommon.GetWalletDecryptTx),
+		"ping":                          handle[struct{}, api_common.APIPingReply](api.apiCommon.GetPing),
+		"":                              handle[struct{}, api_common.APIInfoReply](api.apiCommon.GetInfo),
+		"chain":                         handle[struct{}, api_common.APIBlockchain](api.apiCommon.GetBlockchain),
+		"blockchain":                    handle[struct{}, api_common.APIBlockchain](api.apiCommon.GetBlockchain),
+		"blockchain/staking-info":       handle[api_common.APIStakingInfoRequest, api_common.APIStakingInfoReply](api.apiCommon.GetStakingInfo),
+		"blockchain/genesis-info":       handle[api_common.APIGenesisInfoRequest, api_common.APIGenesisInfoReply](api.apiCommon.GetGenesisInfo),
+		"blockchain/supply":             handle[struct{}, api_common.APISupply](api.apiCommon.GetSupply),
+		"blockchain/supply-only":        handle[struct{}, uint64](api.apiCommon.GetSupplyOnly),
+		"sync":                          handle[struct{}, blockchain_sync.BlockchainSyncData](api.apiCommon.GetBlockchainSync),
+		"block-hash":                    handle[api_common.APIBlockHashRequest, api_common.APIBlockHashReply](api.apiCommon.GetBlockHash),
+		"block-headers":                 handle[api_common.APIBlockHeadersRequest, api_common.APIBlockHeadersReply](api.apiCommon.GetBlockHeaders),
+		"block/exists":                  handle[api_common.APIBlockExistsRequest, api_common.APIBlockExistsReply](api.apiCommon.GetBlockExists),
+		"block":                         handle[api_common.APIBlockRequest, api_common.APIBlockReply](api.apiCommon.GetBlock),
+		"block-complete":                handle[api_common.APIBlockCompleteRequest, api_common.APIBlockCompleteReply](api.apiCommon.GetBlockComplete),
+		"tx-hash":                       handle[api_common.APITxHashRequest, api_common.APITxHashReply](api.apiCommon.GetTxHash),
+		"tx":                            handle[api_common.APITxRequest, api_common.APITxReply](api.apiCommon.GetTx),
+		"tx-proof":                      handle[api_common.APITxProofRequest, api_common.APITxProofReply](api.apiCommon.GetTxProof),
+		"tx/exists":                     handle[api_common.APITxExistsRequest, api_common.APITxExistsReply](api.apiCommon.GetTxExists),
+		"tx-raw":                        handle[api_common.APITxRawRequest, api_common.APITxRawReply](api.apiCommon.GetTxRaw),
+		"account":                       handle[api_common.APIAccountRequest, api_common.APIAccountReply](api.apiCommon.GetAccount),
+		"accounts/count":                handle[api_common.APIAccountsCountRequest, api_common.APIAccountsCountReply](api.apiCommon.GetAccountsCount),
+		"accounts/keys-by-index":        handle[api_common.APIAccountsKeysByIndexRequest, api_common.APIAccountsKeysByIndexReply](api.apiCommon.GetAccountsKeysByIndex),
+		"accounts/by-keys":              handle[api_common.APIAccountsByKeysRequest, api_common.APIAccountsByKeysReply](api.apiCommon.GetAccountsByKeys),
+		"asset":                         handle[api_common.APIAssetRequest, api_common.APIAssetReply](api.apiCommon.GetAsset),
+		"asset/exists":                  handle[api_common.APIAssetExistsRequest, api_common.APIAssetExistsReply](api.apiCommon.GetAssetExists),
+		"asset/fee-liquidity":           handle[api_common.APIAssetFeeLiquidityFeeRequest, api_common.APIAssetFeeLiquidityFeeReply](api.apiCommon.GetAssetFeeLiquidity),
+		"conditional-payment":           handle[api_common.APIConditionalPaymentRequest, api_common.APIConditionalPaymentReply](api.apiCommon.GetConditionalPayment),
+		"conditional-payments-expiring": handle[api_common.APIConditionalPaymentsExpiringRequest, api_common.APIConditionalPaymentsExpiringReply](api.apiCommon.GetConditionalPaymentsExpiring),
+		"fee-estimate":                  handle[api_common.APIFeeEstimateRequest, api_common.APIFeeEstimateReply](api.apiCommon.GetFeeEstimate),
+		"mempool":                       handle[api_common.APIMempoolRequest, api_common.APIMempoolReply](api.apiCommon.GetMempool),
+		"mempool/info":                  handle[struct{}, api_common.APIMempoolInfoReply](api.apiCommon.GetMempoolInfo),
+		"mempool/tx-exists":             handle[api_common.APIMempoolExistsRequest, api_common.APIMempoolExistsReply](api.apiCommon.GetMempoolExists),
+		"mempool/new-tx":                handle[api_common.APIMempoolNewTxRequest, api_common.APIMempoolNewTxReply](api.apiCommon.MempoolNewTx),
+		"network/nodes":                 handle[struct{}, api_common.APINetworkNodesReply](api.apiCommon.GetNetworkNodes),
+		"network/known-nodes":           handleAuthenticated[struct{}, api_common.APINetworkKnownNodesReply](api.apiCommon.GetNetworkKnownNodes),
+		"network/add-known-node":        handleAuthenticated[api_common.APINetworkAddKnownNodeRequest, api_common.APINetworkAddKnownNodeReply](api.apiCommon.NetworkAddKnownNode),
+		"network/remove-known-node":     handleAuthenticated[api_common.APINetworkRemoveKnownNodeRequest, api_common.APINetworkRemoveKnownNodeReply](api.apiCommon.NetworkRemoveKnownNode),
+		"network/banned-nodes":          handleAuthenticated[struct{}, api_common.APINetworkBannedNodesReply](api.apiCommon.GetNetworkBannedNodes),
+		"network/ban-node":              handleAuthenticated[api_common.APINetworkBanNodeRequest, api_common.APINetworkBanNodeReply](api.apiCommon.NetworkBanNode),
+		"network/unban-node":            handleAuthenticated[api_common.APINetworkUnbanNodeRequest, api_common.APINetworkUnbanNodeReply](api.apiCommon.NetworkUnbanNode),
+		"wallet/get-addresses":          handleAuthenticated[struct{}, api_common.APIWalletGetAccountsReply](api.apiCommon.GetWalletAddresses),
+		"wallet/generate-address":       handleAuthenticated[api_common.APIWalletGenerateAddressRequest, api_common.APIWalletGenerateAddressReply](api.apiCommon.GetWalletGenerateAddress),
+		"wallet/create-address":         handleAuthenticated[api_common.APIWalletCreateAddressRequest, api_common.APIWalletCreateAddressReply](api.apiCommon.GetWalletCreateAddress),
+		"wallet/delete-address":         handleAuthenticated[api_common.APIWalletDeleteAddressRequest, api_common.APIWalletDeleteAddressReply](api.apiCommon.GetWalletDeleteAddress),
+		"wallet/get-balances":           handleAuthenticated[api_common.APIWalletGetBalanceRequest, api_common.APIWalletGetBalancesReply](api.apiCommon.GetWalletBalances),
+		"wallet/decrypt-tx":             handleAuthenticated[api_common.APIWalletDecryptTxRequest, api_common.APIWalletDecryptTxReply](api.apiCommon.GetWalletDecryptTx),
+		"wallet/replace-tx":             handleAuthenticated[api_common.APIWalletReplaceTxRequest, api_common.APIWalletReplaceTxReply](api.apiCommon.WalletReplaceTx),
+	}
+
+	api.RateLimiters = map[string]*rate_limiter.RateLimiters{
+		"mempool/new-tx": api.apiCommon.NewTxsRateLimiters,
 	}
 
 	api.PostMap = map[string]func(values io.ReadCloser) (interface{}, error){
@@ -134,8 +153,10 @@ func NewAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon, chai
 		api.GetMap["tx-info"] = handle[api_common.APITransactionInfoRequest, info.TxInfo](api.apiCommon.GetTxInfo)
 		api.GetMap["tx-preview"] = handle[api_common.APITransactionPreviewRequest, api_common.APITransactionPreviewReply](api.apiCommon.GetTxPreview)
 		api.GetMap["account/txs"] = handle[api_common.APIAccountTxsRequest, api_common.APIAccountTxsReply](api.apiCommon.GetAccountTxs)
+		api.GetMap["account/txs-history"] = handle[api_common.APIAccountTxsHistoryRequest, api_common.APIAccountTxsHistoryReply](api.apiCommon.GetAccountTxsHistory)
 		api.GetMap["account/mempool"] = handle[api_common.APIAccountMempoolRequest, api_common.APIAccountMempoolReply](api.apiCommon.GetAccountMempool)
 		api.GetMap["account/mempool-nonce"] = handle[api_common.APIAccountMempoolNonceRequest, api_common.APIAccountMempoolNonceReply](api.apiCommon.GetAccountMempoolNonce)
+		api.GetMap["conditional-payments-by-multisig-key"] = handle[api_common.APIConditionalPaymentsByMultisigKeyRequest, api_common.APIConditionalPaymentsByMultisigKeyReply](api.apiCommon.GetConditionalPaymentsByMultisigKey)
 	}
 
 	if api.apiCommon.Faucet != nil {
@@ -148,6 +169,9 @@ func NewAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon, chai
 	if api.apiCommon.DelegatorNode != nil {
 		api.GetMap["delegator-node/info"] = handle[struct{}, api_delegator_node.ApiDelegatorNodeInfoReply](api.apiCommon.DelegatorNode.GetDelegatorNodeInfo)
 		api.GetMap["delegator-node/notify"] = handleAuthenticated[api_delegator_node.ApiDelegatorNodeNotifyRequest, api_delegator_node.ApiDelegatorNodeNotifyReply](api.apiCommon.DelegatorNode.DelegatorNotify)
+		api.GetMap["delegator-node/rewards"] = handle[api_delegator_node.ApiDelegatorNodeRewardsRequest, api_delegator_node.ApiDelegatorNodeRewardsReply](api.apiCommon.DelegatorNode.GetDelegatorNodeRewards)
+		api.GetMap["delegator-node/delegates"] = handleAuthenticated[struct{}, api_delegator_node.ApiDelegatorNodeDelegatesReply](api.apiCommon.DelegatorNode.GetDelegatorNodeDelegates)
+		api.GetMap["delegator-node/remove-delegate"] = handleAuthenticated[api_delegator_node.ApiDelegatorNodeRemoveDelegateRequest, api_delegator_node.ApiDelegatorNodeRemoveDelegateReply](api.apiCommon.DelegatorNode.RemoveDelegatorNodeDelegate)
 	}
 
 	return &api
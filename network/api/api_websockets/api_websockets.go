@@ -1,6 +1,7 @@
 package api_websockets
 
 import (
+	"errors"
 	"github.com/vmihailenco/msgpack/v5"
 	"net/http"
 	"pandora-pay/blockchain"
@@ -54,6 +55,21 @@ func handle[T any, B any](callback func(r *http.Request, args *T, reply *B) erro
 	}
 }
 
+// handleNewTxs limits the transactions pushed by a single connection
+func handleNewTxs(callback func(conn *connection.AdvancedConnection, values []byte) (interface{}, error)) func(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
+	return func(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
+		if !conn.NewTxsRateLimiter.Allow() {
+			return nil, errors.New("Too many transactions")
+		}
+		return callback(conn, values)
+	}
+}
+
+// DownloadMempoolTxId processes a transaction requested by this node, so it is not rate limited
+func (api *APIWebsockets) DownloadMempoolTxId(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
+	return api.apiCommon.MempoolNewTxId(conn, values)
+}
+
 func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon, chain *blockchain.Blockchain, settings *settings.Settings, mempool *mempool.Mempool, txsValidator *txs_validator.TxsValidator) *APIWebsockets {
 
 	api := &APIWebsockets{
@@ -68,45 +84,58 @@ func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICo
 	}
 
 	api.GetMap = map[string]func(conn *connection.AdvancedConnection, values []byte) (interface{}, error){
-		"ping":                    handle[struct{}, api_common.APIPingReply](api.apiCommon.GetPing),
-		"":                        handle[struct{}, api_common.APIInfoReply](api.apiCommon.GetInfo),
-		"chain":                   handle[struct{}, api_common.APIBlockchain](api.apiCommon.GetBlockchain),
-		"blockchain":              handle[struct{}, api_common.APIBlockchain](api.apiCommon.GetBlockchain),
-		"blockchain/staking-info": handle[api_common.APIStakingInfoRequest, api_common.APIStakingInfoReply](api.apiCommon.GetStakingInfo),
-		"blockchain/genesis-info": handle[api_common.APIGenesisInfoRequest, api_common.APIGenesisInfoReply](api.apiCommon.GetGenesisInfo),
-		"blockchain/supply":       handle[struct{}, api_common.APISupply](api.apiCommon.GetSupply),
-		"blockchain/supply-only":  handle[struct{}, uint64](api.apiCommon.GetSupplyOnly),
-		"sync":                    handle[struct{}, blockchain_sync.BlockchainSyncData](api.apiCommon.GetBlockchainSync),
-		"block-hash":              handle[api_common.APIBlockHashRequest, api_common.APIBlockHashReply](api.apiCommon.GetBlockHash),
-		"block":                   handle[api_common.APIBlockRequest, api_common.APIBlockReply](api.apiCommon.GetBlock),
-		"block/exists":            handle[api_common.APIBlockExistsRequest, api_common.APIBlockExistsReply](api.apiCommon.GetBlockExists),
-		"block-complete":          handle[api_common.APIBlockCompleteRequest, api_common.APIBlockCompleteReply](api.apiCommon.GetBlockComplete),
-		"tx-hash":                 handle[api_common.APITxHashRequest, api_common.APITxHashReply](api.apiCommon.GetTxHash),
-		"tx":                      handle[api_common.APITxRequest, api_common.APITxReply](api.apiCommon.GetTx),
-		"tx/exists":               handle[api_common.APITxExistsRequest, api_common.APITxExistsReply](api.apiCommon.GetTxExists),
-		"tx-raw":                  handle[api_common.APITxRawRequest, api_common.APITxRawReply](api.apiCommon.GetTxRaw),
-		"account":                 handle[api_common.APIAccountRequest, api_common.APIAccountReply](api.apiCommon.GetAccount),
-		"accounts/count":          handle[api_common.APIAccountsCountRequest, api_common.APIAccountsCountReply](api.apiCommon.GetAccountsCount),
-		"accounts/keys-by-index":  handle[api_common.APIAccountsKeysByIndexRequest, api_common.APIAccountsKeysByIndexReply](api.apiCommon.GetAccountsKeysByIndex),
-		"accounts/by-keys":        handle[api_common.APIAccountsByKeysRequest, api_common.APIAccountsByKeysReply](api.apiCommon.GetAccountsByKeys),
-		"asset":                   handle[api_common.APIAssetRequest, api_common.APIAssetReply](api.apiCommon.GetAsset),
-		"asset/exists":            handle[api_common.APIAssetRequest, api_common.APIAssetReply](api.apiCommon.GetAsset),
-		"asset/fee-liquidity":     handle[api_common.APIAssetFeeLiquidityFeeRequest, api_common.APIAssetFeeLiquidityFeeReply](api.apiCommon.GetAssetFeeLiquidity),
-		"mempool":                 handle[api_common.APIMempoolRequest, api_common.APIMempoolReply](api.apiCommon.GetMempool),
-		"mempool/tx-exists":       handle[api_common.APIMempoolExistsRequest, api_common.APIMempoolExistsReply](api.apiCommon.GetMempoolExists),
-		"mempool/new-tx":          handle[api_common.APIMempoolNewTxRequest, api_common.APIMempoolNewTxReply](api.apiCommon.MempoolNewTx),
-		"network/nodes":           handle[struct{}, api_common.APINetworkNodesReply](api.apiCommon.GetNetworkNodes),
-		"wallet/get-addresses":    handleAuthenticated[struct{}, api_common.APIWalletGetAccountsReply](api.apiCommon.GetWalletAddresses),
-		"wallet/generate-address": handleAuthenticated[api_common.APIWalletGenerateAddressRequest, api_common.APIWalletGenerateAddressReply](api.apiCommon.GetWalletGenerateAddress),
-		"wallet/create-address":   handleAuthenticated[api_common.APIWalletCreateAddressRequest, api_common.APIWalletCreateAddressReply](api.apiCommon.GetWalletCreateAddress),
-		"wallet/delete-address":   handleAuthenticated[api_common.APIWalletDeleteAddressRequest, api_common.APIWalletDeleteAddressReply](api.apiCommon.GetWalletDeleteAddress),
-		"wallet/get-balances":     handleAuthenticated[api_common.APIWalletGetBalanceRequest, api_common.APIWalletGetBalancesReply](api.apiCommon.GetWalletBalances),
-		"wallet/decrypt-tx":       handleAuthenticated[api_common.APIWalletDecryptTxRequest, api_common.APIWalletDecryptTxReply](api.apiCommon.GetWalletDecryptTx),
-		"wallet/private-transfer": handleAuthenticated[api_common.APIWalletPrivateTransferRequest, api_common.APIWalletPrivateTransferReply](api.apiCommon.WalletPrivateTransfer),
+		"ping":                          handle[struct{}, api_common.APIPingReply](api.apiCommon.GetPing),
+		"":                              handle[struct{}, api_common.APIInfoReply](api.apiCommon.GetInfo),
+		"chain":                         handle[struct{}, api_common.APIBlockchain](api.apiCommon.GetBlockchain),
+		"blockchain":                    handle[struct{}, api_common.APIBlockchain](api.apiCommon.GetBlockchain),
+		"blockchain/staking-info":       handle[api_common.APIStakingInfoRequest, api_common.APIStakingInfoReply](api.apiCommon.GetStakingInfo),
+		"blockchain/genesis-info":       handle[api_common.APIGenesisInfoRequest, api_common.APIGenesisInfoReply](api.apiCommon.GetGenesisInfo),
+		"blockchain/supply":             handle[struct{}, api_common.APISupply](api.apiCommon.GetSupply),
+		"blockchain/supply-only":        handle[struct{}, uint64](api.apiCommon.GetSupplyOnly),
+		"sync":                          handle[struct{}, blockchain_sync.BlockchainSyncData](api.apiCommon.GetBlockchainSync),
+		"block-hash":                    handle[api_common.APIBlockHashRequest, api_common.APIBlockHashReply](api.apiCommon.GetBlockHash),
+		"block-headers":                 handle[api_common.APIBlockHeadersRequest, api_common.APIBlockHeadersReply](api.apiCommon.GetBlockHeaders),
+		"block":                         handle[api_common.APIBlockRequest, api_common.APIBlockReply](api.apiCommon.GetBlock),
+		"block/exists":                  handle[api_common.APIBlockExistsRequest, api_common.APIBlockExistsReply](api.apiCommon.GetBlockExists),
+		"block-complete":                handle[api_common.APIBlockCompleteRequest, api_common.APIBlockCompleteReply](api.apiCommon.GetBlockComplete),
+		"tx-hash":                       handle[api_common.APITxHashRequest, api_common.APITxHashReply](api.apiCommon.GetTxHash),
+		"tx":                            handle[api_common.APITxRequest, api_common.APITxReply](api.apiCommon.GetTx),
+		"tx-proof":                      handle[api_common.APITxProofRequest, api_common.APITxProofReply](api.apiCommon.GetTxProof),
+		"tx/exists":                     handle[api_common.APITxExistsRequest, api_common.APITxExistsReply](api.apiCommon.GetTxExists),
+		"tx-raw":                        handle[api_common.APITxRawRequest, api_common.APITxRawReply](api.apiCommon.GetTxRaw),
+		"account":                       handle[api_common.APIAccountRequest, api_common.APIAccountReply](api.apiCommon.GetAccount),
+		"accounts/count":                handle[api_common.APIAccountsCountRequest, api_common.APIAccountsCountReply](api.apiCommon.GetAccountsCount),
+		"accounts/keys-by-index":        handle[api_common.APIAccountsKeysByIndexRequest, api_common.APIAccountsKeysByIndexReply](api.apiCommon.GetAccountsKeysByIndex),
+		"accounts/by-keys":              handle[api_common.APIAccountsByKeysRequest, api_common.APIAccountsByKeysReply](api.apiCommon.GetAccountsByKeys),
+		"asset":                         handle[api_common.APIAssetRequest, api_common.APIAssetReply](api.apiCommon.GetAsset),
+		"asset/exists":                  handle[api_common.APIAssetRequest, api_common.APIAssetReply](api.apiCommon.GetAsset),
+		"asset/fee-liquidity":           handle[api_common.APIAssetFeeLiquidityFeeRequest, api_common.APIAssetFeeLiquidityFeeReply](api.apiCommon.GetAssetFeeLiquidity),
+		"conditional-payment":           handle[api_common.APIConditionalPaymentRequest, api_common.APIConditionalPaymentReply](api.apiCommon.GetConditionalPayment),
+		"conditional-payments-expiring": handle[api_common.APIConditionalPaymentsExpiringRequest, api_common.APIConditionalPaymentsExpiringReply](api.apiCommon.GetConditionalPaymentsExpiring),
+		"fee-estimate":                  handle[api_common.APIFeeEstimateRequest, api_common.APIFeeEstimateReply](api.apiCommon.GetFeeEstimate),
+		"mempool":                       handle[api_common.APIMempoolRequest, api_common.APIMempoolReply](api.apiCommon.GetMempool),
+		"mempool/info":                  handle[struct{}, api_common.APIMempoolInfoReply](api.apiCommon.GetMempoolInfo),
+		"mempool/tx-exists":             handle[api_common.APIMempoolExistsRequest, api_common.APIMempoolExistsReply](api.apiCommon.GetMempoolExists),
+		"mempool/new-tx":                handleNewTxs(handle[api_common.APIMempoolNewTxRequest, api_common.APIMempoolNewTxReply](api.apiCommon.MempoolNewTx)),
+		"network/nodes":                 handle[struct{}, api_common.APINetworkNodesReply](api.apiCommon.GetNetworkNodes),
+		"network/known-nodes":           handleAuthenticated[struct{}, api_common.APINetworkKnownNodesReply](api.apiCommon.GetNetworkKnownNodes),
+		"network/add-known-node":        handleAuthenticated[api_common.APINetworkAddKnownNodeRequest, api_common.APINetworkAddKnownNodeReply](api.apiCommon.NetworkAddKnownNode),
+		"network/remove-known-node":     handleAuthenticated[api_common.APINetworkRemoveKnownNodeRequest, api_common.APINetworkRemoveKnownNodeReply](api.apiCommon.NetworkRemoveKnownNode),
+		"network/banned-nodes":          handleAuthenticated[struct{}, api_common.APINetworkBannedNodesReply](api.apiCommon.GetNetworkBannedNodes),
+		"network/ban-node":              handleAuthenticated[api_common.APINetworkBanNodeRequest, api_common.APINetworkBanNodeReply](api.apiCommon.NetworkBanNode),
+		"network/unban-node":            handleAuthenticated[api_common.APINetworkUnbanNodeRequest, api_common.APINetworkUnbanNodeReply](api.apiCommon.NetworkUnbanNode),
+		"wallet/get-addresses":          handleAuthenticated[struct{}, api_common.APIWalletGetAccountsReply](api.apiCommon.GetWalletAddresses),
+		"wallet/generate-address":       handleAuthenticated[api_common.APIWalletGenerateAddressRequest, api_common.APIWalletGenerateAddressReply](api.apiCommon.GetWalletGenerateAddress),
+		"wallet/create-address":         handleAuthenticated[api_common.APIWalletCreateAddressRequest, api_common.APIWalletCreateAddressReply](api.apiCommon.GetWalletCreateAddress),
+		"wallet/delete-address":         handleAuthenticated[api_common.APIWalletDeleteAddressRequest, api_common.APIWalletDeleteAddressReply](api.apiCommon.GetWalletDeleteAddress),
+		"wallet/get-balances":           handleAuthenticated[api_common.APIWalletGetBalanceRequest, api_common.APIWalletGetBalancesReply](api.apiCommon.GetWalletBalances),
+		"wallet/decrypt-tx":             handleAuthenticated[api_common.APIWalletDecryptTxRequest, api_common.APIWalletDecryptTxReply](api.apiCommon.GetWalletDecryptTx),
+		"wallet/private-transfer":       handleAuthenticated[api_common.APIWalletPrivateTransferRequest, api_common.APIWalletPrivateTransferReply](api.apiCommon.WalletPrivateTransfer),
+		"wallet/replace-tx":             handleAuthenticated[api_common.APIWalletReplaceTxRequest, api_common.APIWalletReplaceTxReply](api.apiCommon.WalletReplaceTx),
 		//below are ONLY websockets API
 		"block-miss-txs":    handle[consensus.APIBlockCompleteMissingTxsRequest, consensus.APIBlockCompleteMissingTxsReply](api.Consensus.GetBlockCompleteMissingTxs),
 		"handshake":         api.handshake,
-		"mempool/new-tx-id": api.apiCommon.MempoolNewTxId,
+		"mempool/new-tx-id": handleNewTxs(api.apiCommon.MempoolNewTxId),
 		"get-chain":         api.Consensus.GetChain,
 		"chain-update":      api.Consensus.ChainUpdate,
 		"login":             api.login,
@@ -121,8 +150,10 @@ func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICo
 		api.GetMap["tx-info"] = handle[api_common.APITransactionInfoRequest, info.TxInfo](api.apiCommon.GetTxInfo)
 		api.GetMap["tx-preview"] = handle[api_common.APITransactionPreviewRequest, api_common.APITransactionPreviewReply](api.apiCommon.GetTxPreview)
 		api.GetMap["account/txs"] = handle[api_common.APIAccountTxsRequest, api_common.APIAccountTxsReply](api.apiCommon.GetAccountTxs)
+		api.GetMap["account/txs-history"] = handle[api_common.APIAccountTxsHistoryRequest, api_common.APIAccountTxsHistoryReply](api.apiCommon.GetAccountTxsHistory)
 		api.GetMap["account/mempool"] = handle[api_common.APIAccountMempoolRequest, api_common.APIAccountMempoolReply](api.apiCommon.GetAccountMempool)
 		api.GetMap["account/mempool-nonce"] = handle[api_common.APIAccountMempoolNonceRequest, api_common.APIAccountMempoolNonceReply](api.apiCommon.GetAccountMempoolNonce)
+		api.GetMap["conditional-payments-by-multisig-key"] = handle[api_common.APIConditionalPaymentsByMultisigKeyRequest, api_common.APIConditionalPaymentsByMultisigKeyReply](api.apiCommon.GetConditionalPaymentsByMultisigKey)
 	}
 
 	if config.CONSENSUS == config.CONSENSUS_TYPE_WALLET {
@@ -139,6 +170,9 @@ func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICo
 	if api.apiCommon.DelegatorNode != nil {
 		api.GetMap["delegator-node/info"] = handle[struct{}, api_delegator_node.ApiDelegatorNodeInfoReply](api.apiCommon.DelegatorNode.GetDelegatorNodeInfo)
 		api.GetMap["delegator-node/notify"] = handleAuthenticated[api_delegator_node.ApiDelegatorNodeNotifyRequest, api_delegator_node.ApiDelegatorNodeNotifyReply](api.apiCommon.DelegatorNode.DelegatorNotify)
+		api.GetMap["delegator-node/rewards"] = handle[api_delegator_node.ApiDelegatorNodeRewardsRequest, api_delegator_node.ApiDelegatorNodeRewardsReply](api.apiCommon.DelegatorNode.GetDelegatorNodeRewards)
+		api.GetMap["delegator-node/delegates"] = handleAuthenticated[struct{}, api_delegator_node.ApiDelegatorNodeDelegatesReply](api.apiCommon.DelegatorNode.GetDelegatorNodeDelegates)
+		api.GetMap["delegator-node/remove-delegate"] = handleAuthenticated[api_delegator_node.ApiDelegatorNodeRemoveDelegateRequest, api_delegator_node.ApiDelegatorNodeRemoveDelegateReply](api.apiCommon.DelegatorNode.RemoveDelegatorNodeDelegate)
 	}
 
 	return api
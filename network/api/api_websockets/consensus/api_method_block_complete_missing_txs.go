@@ -32,6 +32,10 @@ func (api *Consensus) GetBlockCompleteMissingTxs(r *http.Request, args *APIBlock
 			return
 		}
 
+		if height < api.chain.GetPrunedHeight() {
+			return errors.New("Block was pruned")
+		}
+
 		data := reader.Get("blockTxs" + strconv.FormatUint(height, 10))
 		if data == nil {
 			return errors.New("Block not found")
@@ -29,6 +29,11 @@ func (consensus *Consensus) ChainUpdateProcess(conn *connection.AdvancedConnecti
 		return nil, nil
 	}
 
+	//the node pruned the blocks we are missing
+	if chainUpdateNotification.Start > chainLastUpdate.Height {
+		return nil, nil
+	}
+
 	compare := chainLastUpdate.BigTotalDifficulty.Cmp(chainUpdateNotification.BigTotalDifficulty)
 
 	if compare == 0 {
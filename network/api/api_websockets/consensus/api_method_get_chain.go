@@ -12,6 +12,7 @@ func (consensus *Consensus) GetUpdateNotification(newChainData *blockchain.Block
 	}
 
 	return &ChainUpdateNotification{
+		Start:              consensus.chain.GetPrunedHeight(),
 		End:                newChainData.Height,
 		Hash:               newChainData.Hash,
 		PrevHash:           newChainData.PrevHash,
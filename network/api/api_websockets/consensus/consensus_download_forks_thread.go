@@ -172,6 +172,11 @@ func (thread *ConsensusProcessForksThread) downloadFork(fork *Fork) bool {
 			break
 		}
 
+		//the block is different, but it was pruned and it can not be removed
+		if start-1 < thread.chain.GetPrunedHeight() {
+			return false
+		}
+
 		blkComplete, err := thread.downloadBlockComplete(conn, fork, start-1)
 		if err != nil {
 			fork.errors += 1
@@ -249,7 +254,12 @@ func (thread *ConsensusProcessForksThread) execute() {
 
 					globals.MainEvents.BroadcastEvent("consensus/update", fork)
 
-					if thread.downloadRemainingBlocks(fork) {
+					downloadRemainingBlocks := thread.downloadRemainingBlocks
+					if config.SYNC_HEADERS_FIRST {
+						downloadRemainingBlocks = thread.downloadRemainingBlocksHeadersFirst
+					}
+
+					if downloadRemainingBlocks(fork) {
 
 						blocks := make([]*block_complete.BlockComplete, fork.Blocks.Length)
 						it := fork.Blocks.Head
@@ -262,7 +272,7 @@ func (thread *ConsensusProcessForksThread) execute() {
 
 						if _, err := thread.chain.AddBlocks(blocks, false, advanced_connection_types.UUID_ALL); err != nil {
 							if config.DEBUG {
-								gui.GUI.Error("Invalid Fork", err)
+								gui.Consensus().Error("Invalid Fork", err)
 							}
 						} else {
 							fork.Lock()
@@ -279,7 +289,7 @@ func (thread *ConsensusProcessForksThread) execute() {
 
 			} else {
 				globals.MainEvents.BroadcastEvent("consensus/update", fork)
-				gui.GUI.Log("Status. AddBlocks fork - Simulating block")
+				gui.Consensus().Log("Status. AddBlocks fork - Simulating block")
 
 				newChainData := &blockchain.BlockchainData{
 					Height:             fork.End,
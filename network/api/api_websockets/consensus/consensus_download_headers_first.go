@@ -0,0 +1,235 @@
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/config"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/network/api/api_common"
+	"pandora-pay/network/websocks/connection"
+	"pandora-pay/recovery"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// forkConnDownload measures the throughput of a connection used to download blocks
+type forkConnDownload struct {
+	conn     *connection.AdvancedConnection
+	size     uint64
+	duration time.Duration
+	failed   bool
+}
+
+func (thread *ConsensusProcessForksThread) downloadBlockHeaders(conn *connection.AdvancedConnection, height, count uint64) ([]*block.Block, error) {
+
+	answer, err := connection.SendJSONAwaitAnswer[api_common.APIBlockHeadersReply](conn, []byte("block-headers"), &api_common.APIBlockHeadersRequest{height, count}, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(answer.Headers) == 0 || uint64(len(answer.Headers)) > count {
+		return nil, errors.New("Headers length is invalid")
+	}
+
+	headers := make([]*block.Block, len(answer.Headers))
+	for i, data := range answer.Headers {
+		headers[i] = block.CreateEmptyBlock()
+		if err = headers[i].Deserialize(advanced_buffers.NewBufferReader(data)); err != nil {
+			return nil, err
+		}
+	}
+
+	return headers, nil
+}
+
+// validateBlockHeaders validates the headers following the blocks already downloaded for the fork
+func (thread *ConsensusProcessForksThread) validateBlockHeaders(fork *Fork, headers []*block.Block) error {
+
+	all := make([]*block.Block, 0, fork.Blocks.Length+len(headers))
+	for it := fork.Blocks.Head; it != nil; it = it.Next {
+		all = append(all, it.Data.Block)
+	}
+	all = append(all, headers...)
+
+	bigTotalDifficulty, err := thread.chain.ValidateBlockHeaders(all)
+	if err != nil {
+		return err
+	}
+
+	if fork.Current+uint64(len(headers)) == fork.End {
+		if !bytes.Equal(headers[len(headers)-1].Bloom.Hash, fork.Hash) {
+			return errors.New("Last header is not matching the fork hash")
+		}
+		if bigTotalDifficulty.Cmp(fork.BigTotalDifficulty) != 0 {
+			return errors.New("Headers total difficulty is not matching the fork")
+		}
+	}
+
+	return nil
+}
+
+// downloadBlocksParallel downloads the blocks of the headers using multiple connections of the fork. It returns the blocks downloaded, nil for the missing ones.
+// The headers were validated using the staking amounts they claim, so a block not proving its staking amount invalidates the headers
+func (thread *ConsensusProcessForksThread) downloadBlocksParallel(fork *Fork, headers []*block.Block) ([]*block_complete.BlockComplete, error) {
+
+	downloads := make([]*forkConnDownload, 0, config.FORK_MAX_PARALLEL_CONNS)
+	for _, conn := range fork.conns {
+		if len(downloads) == config.FORK_MAX_PARALLEL_CONNS {
+			break
+		}
+		if !conn.IsClosed.IsSet() {
+			downloads = append(downloads, &forkConnDownload{conn: conn})
+		}
+	}
+
+	blocks := make([]*block_complete.BlockComplete, len(headers))
+
+	jobs := make(chan int, len(headers))
+	for i := range headers {
+		jobs <- i
+	}
+	remaining := int32(len(headers))
+
+	var invalid error
+	invalidLock := sync.Mutex{}
+	isInvalid := func() bool {
+		invalidLock.Lock()
+		defer invalidLock.Unlock()
+		return invalid != nil
+	}
+
+	wg := sync.WaitGroup{}
+	for _, download := range downloads {
+		wg.Add(1)
+		download := download
+		recovery.SafeGo(func() {
+			defer wg.Done()
+
+			errs := 0
+			for atomic.LoadInt32(&remaining) > 0 && !isInvalid() {
+
+				if errs > 2 || download.conn.IsClosed.IsSet() {
+					download.failed = true
+					return
+				}
+
+				var index int
+				select {
+				case index = <-jobs:
+				default: //the remaining blocks are being downloaded by other connections
+					time.Sleep(10 * time.Millisecond)
+					continue
+				}
+
+				start := time.Now()
+				blkComplete, err := thread.downloadBlockComplete(download.conn, fork, headers[index].Height)
+				if err == nil && !bytes.Equal(blkComplete.Bloom.Hash, headers[index].Bloom.Hash) {
+					err = errors.New("Block is not matching the header")
+				}
+				if err == nil {
+					if _, err = blockchain.VerifyBlockStaking(blkComplete); err != nil {
+						invalidLock.Lock()
+						invalid = err
+						invalidLock.Unlock()
+						download.failed = true
+						return
+					}
+				}
+				if err != nil {
+					errs += 1
+					jobs <- index
+					continue
+				}
+
+				download.duration += time.Since(start)
+				download.size += blkComplete.BloomBlkComplete.Size
+				blocks[index] = blkComplete
+				atomic.AddInt32(&remaining, -1)
+			}
+		})
+	}
+	wg.Wait()
+
+	thread.scoreDownloads(fork, downloads)
+
+	return blocks, invalid
+}
+
+// scoreDownloads rewards the connections proportionally to their throughput
+func (thread *ConsensusProcessForksThread) scoreDownloads(fork *Fork, downloads []*forkConnDownload) {
+
+	best := float64(0)
+	for _, download := range downloads {
+		if download.failed {
+			fork.errors += 1
+		}
+		if download.size > 0 {
+			best = generics.Max(best, float64(download.size)/download.duration.Seconds())
+		}
+	}
+
+	for _, download := range downloads {
+		if download.size > 0 {
+			throughput := float64(download.size) / download.duration.Seconds()
+			download.conn.IncreaseKnownNodeScoreBy(1 + int32(9*throughput/best))
+		}
+	}
+}
+
+// downloadRemainingBlocksHeadersFirst downloads and validates the next headers of the fork and then their blocks in parallel
+func (thread *ConsensusProcessForksThread) downloadRemainingBlocksHeadersFirst(fork *Fork) bool {
+
+	fork.Lock()
+	defer fork.Unlock()
+
+	if fork.Current == fork.End {
+		return fork.Blocks.Length > 0
+	}
+
+	count := generics.Min(fork.End-fork.Current, config.FORK_MAX_HEADERS)
+
+	var headers []*block.Block
+	for headers == nil {
+
+		if fork.errors > 2 {
+			return false
+		}
+		if fork.errors < -10 {
+			fork.errors = -10
+		}
+
+		conn := fork.getRandomConn()
+		if conn == nil {
+			return false
+		}
+
+		var err error
+		if headers, err = thread.downloadBlockHeaders(conn, fork.Current, count); err == nil {
+			err = thread.validateBlockHeaders(fork, headers)
+		}
+		if err != nil {
+			headers = nil
+			fork.errors += 1
+		}
+	}
+
+	blocks, err := thread.downloadBlocksParallel(fork, headers)
+	if err != nil { //the fork is dropped before its blocks are added
+		return false
+	}
+
+	for _, blkComplete := range blocks {
+		if blkComplete == nil {
+			break
+		}
+		fork.Blocks.Push(blkComplete)
+		fork.Current += 1
+	}
+
+	return fork.Blocks.Length > 0
+}
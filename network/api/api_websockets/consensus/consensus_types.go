@@ -5,6 +5,7 @@ import (
 )
 
 type ChainUpdateNotification struct {
+	Start              uint64   `json:"start" msgpack:"start"` //first block that can be downloaded. Pruned nodes don't store older blocks
 	End                uint64   `json:"end" msgpack:"end"`
 	Hash               []byte   `json:"hash" msgpack:"hash"`
 	PrevHash           []byte   `json:"prevHash" msgpack:"prevHash"`
@@ -3,6 +3,7 @@ package banned_nodes
 import (
 	"net/url"
 	"pandora-pay/helpers/generics"
+	"sync"
 	"time"
 )
 
@@ -11,20 +12,25 @@ type BannedNode struct {
 	Timestamp  time.Time
 	Expiration time.Time
 	Message    string
+	Stored     bool //stored bans are kept after restarts
 }
 
 type BannedNodes struct {
 	bannedMap *generics.Map[string, *BannedNode]
+	saveLock  sync.Mutex
 }
 
 func (self *BannedNodes) IsBanned(urlStr string) bool {
-	if _, found := self.bannedMap.Load(urlStr); found {
-		return true
+	if bannedNode, found := self.bannedMap.Load(urlStr); found {
+		if time.Now().Before(bannedNode.Expiration) {
+			return true
+		}
+		self.removeBan(urlStr)
 	}
 	return false
 }
 
-func (self *BannedNodes) Ban(url *url.URL, urlStr, message string, duration time.Duration) {
+func (self *BannedNodes) Ban(url *url.URL, urlStr, message string, duration time.Duration, stored bool) {
 	if urlStr == "" {
 		urlStr = url.String()
 	}
@@ -34,7 +40,47 @@ func (self *BannedNodes) Ban(url *url.URL, urlStr, message string, duration time
 		Message:    message,
 		Timestamp:  time,
 		Expiration: time.Add(duration),
+		Stored:     stored,
 	})
+	if stored {
+		self.saveBannedNodes()
+	}
+}
+
+func (self *BannedNodes) Unban(urlStr string) bool {
+	return self.removeBan(urlStr)
+}
+
+func (self *BannedNodes) removeBan(urlStr string) bool {
+	bannedNode, found := self.bannedMap.LoadAndDelete(urlStr)
+	if found && bannedNode.Stored {
+		self.saveBannedNodes()
+	}
+	return found
+}
+
+// GetList returns the bans that did not expire
+func (self *BannedNodes) GetList() []*BannedNode {
+
+	now := time.Now()
+
+	list := []*BannedNode{}
+	expired := []string{}
+
+	self.bannedMap.Range(func(urlStr string, bannedNode *BannedNode) bool {
+		if now.Before(bannedNode.Expiration) {
+			list = append(list, bannedNode)
+		} else {
+			expired = append(expired, urlStr)
+		}
+		return true
+	})
+
+	for _, urlStr := range expired {
+		self.removeBan(urlStr)
+	}
+
+	return list
 }
 
 func NewBannedNodes() *BannedNodes {
@@ -0,0 +1,80 @@
+package banned_nodes
+
+import (
+	"github.com/vmihailenco/msgpack/v5"
+	"net/url"
+	"pandora-pay/gui"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"time"
+)
+
+type bannedNodeStored struct {
+	URL        string `msgpack:"url"`
+	Timestamp  int64  `msgpack:"timestamp"`
+	Expiration int64  `msgpack:"expiration"`
+	Message    string `msgpack:"message"`
+}
+
+// saveBannedNodes stores the bans which did not expire into the settings store
+func (self *BannedNodes) saveBannedNodes() {
+
+	self.saveLock.Lock()
+	defer self.saveLock.Unlock()
+
+	now := time.Now()
+
+	stored := []*bannedNodeStored{}
+	self.bannedMap.Range(func(urlStr string, bannedNode *BannedNode) bool {
+		if bannedNode.Stored && now.Before(bannedNode.Expiration) {
+			stored = append(stored, &bannedNodeStored{urlStr, bannedNode.Timestamp.Unix(), bannedNode.Expiration.Unix(), bannedNode.Message})
+		}
+		return true
+	})
+
+	data, err := msgpack.Marshal(stored)
+	if err == nil {
+		err = store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			writer.Put("bannedNodes", data)
+			return nil
+		})
+	}
+
+	if err != nil {
+		gui.Network().Error("Error storing banned nodes", err)
+	}
+}
+
+// LoadBannedNodes restores the stored bans which did not expire
+func (self *BannedNodes) LoadBannedNodes() error {
+
+	var stored []*bannedNodeStored
+
+	if err := store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		data := reader.Get("bannedNodes")
+		if data == nil {
+			return nil
+		}
+		return msgpack.Unmarshal(data, &stored)
+	}); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, data := range stored {
+
+		expiration := time.Unix(data.Expiration, 0)
+		if !now.Before(expiration) {
+			continue
+		}
+
+		u, err := url.Parse(data.URL)
+		if err != nil {
+			continue
+		}
+
+		self.bannedMap.Store(data.URL, &BannedNode{u, time.Unix(data.Timestamp, 0), expiration, data.Message, true})
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package banned_nodes
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"net/url"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+	"time"
+)
+
+func initTestSettingsStore(t *testing.T) {
+	db, err := store_db_memory.CreateStoreDBMemory("settings")
+	assert.NoError(t, err)
+	store.StoreSettings = &store.Store{Name: "settings", Opened: true, DB: db}
+}
+
+func TestBannedNodesExpiry(t *testing.T) {
+
+	initTestSettingsStore(t)
+
+	banned, expired, expired2 := &url.URL{Scheme: "ws", Host: "1.1.1.1:16000", Path: "/ws"}, &url.URL{Scheme: "ws", Host: "2.2.2.2:16000", Path: "/ws"}, &url.URL{Scheme: "ws", Host: "3.3.3.3:16000", Path: "/ws"}
+
+	bannedNodes := NewBannedNodes()
+	bannedNodes.Ban(banned, "", "Invalid block", time.Hour, false)
+	bannedNodes.Ban(expired, "", "Invalid block", -time.Minute, false)
+	bannedNodes.Ban(expired2, "", "Invalid block", -time.Minute, true)
+
+	assert.True(t, bannedNodes.IsBanned(banned.String()))
+	assert.False(t, bannedNodes.IsBanned(expired.String()))
+	assert.False(t, bannedNodes.IsBanned("ws://4.4.4.4:16000/ws"))
+
+	_, found := bannedNodes.bannedMap.Load(expired.String())
+	assert.False(t, found, "the expired ban is removed when checked")
+
+	list := bannedNodes.GetList()
+	assert.Equal(t, 1, len(list))
+	assert.Equal(t, banned, list[0].URL)
+	assert.Equal(t, "Invalid block", list[0].Message)
+
+	_, found = bannedNodes.bannedMap.Load(expired2.String())
+	assert.False(t, found, "the expired bans are removed when listed")
+
+	assert.True(t, bannedNodes.Unban(banned.String()))
+	assert.False(t, bannedNodes.Unban(banned.String()))
+	assert.False(t, bannedNodes.IsBanned(banned.String()))
+}
+
+func TestBannedNodesStore(t *testing.T) {
+
+	initTestSettingsStore(t)
+
+	stored, notStored := &url.URL{Scheme: "ws", Host: "1.1.1.1:16000", Path: "/ws"}, &url.URL{Scheme: "ws", Host: "2.2.2.2:16000", Path: "/ws"}
+
+	bannedNodes := NewBannedNodes()
+	bannedNodes.Ban(stored, "", "Invalid block", time.Hour, true)
+	bannedNodes.Ban(notStored, "", "You can't connect to yourself", time.Hour, false)
+
+	original, _ := bannedNodes.bannedMap.Load(stored.String())
+
+	loaded := NewBannedNodes()
+	assert.NoError(t, loaded.LoadBannedNodes())
+
+	assert.True(t, loaded.IsBanned(stored.String()))
+	assert.False(t, loaded.IsBanned(notStored.String()), "the bans with stored=false are not persisted")
+
+	list := loaded.GetList()
+	assert.Equal(t, 1, len(list))
+	assert.Equal(t, stored, list[0].URL)
+	assert.Equal(t, "Invalid block", list[0].Message)
+	assert.Equal(t, original.Timestamp.Unix(), list[0].Timestamp.Unix())
+	assert.Equal(t, original.Expiration.Unix(), list[0].Expiration.Unix())
+	assert.True(t, list[0].Stored)
+
+	//a ban which expired while the node was stopped is not restored
+	assert.NoError(t, store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		now := time.Now()
+		data, err := msgpack.Marshal([]*bannedNodeStored{
+			{stored.String(), now.Unix(), now.Add(time.Hour).Unix(), "Invalid block"},
+			{notStored.String(), now.Add(-time.Hour).Unix(), now.Add(-time.Minute).Unix(), "Invalid block"},
+		})
+		if err != nil {
+			return err
+		}
+		writer.Put("bannedNodes", data)
+		return nil
+	}))
+
+	loaded = NewBannedNodes()
+	assert.NoError(t, loaded.LoadBannedNodes())
+	assert.True(t, loaded.IsBanned(stored.String()))
+	assert.False(t, loaded.IsBanned(notStored.String()))
+
+	//unbanning a stored ban removes it from the store
+	assert.True(t, bannedNodes.Unban(stored.String()))
+
+	loaded = NewBannedNodes()
+	assert.NoError(t, loaded.LoadBannedNodes())
+	assert.Empty(t, loaded.GetList())
+}
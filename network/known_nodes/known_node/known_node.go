@@ -11,7 +11,10 @@ type KnownNode struct {
 
 type KnownNodeScored struct {
 	KnownNode
-	Score int32 //use atomic
+	Score     int32 //use atomic
+	LastSeen  int64 //use atomic, unix time of the last connection
+	Successes int32 //use atomic, successful connections
+	Failures  int32 //use atomic, failed connections
 }
 
 var KNOWN_KNODE_SCORE_MINIMUM = int32(-1000)
@@ -3,6 +3,7 @@ package known_nodes
 import (
 	"errors"
 	"math/rand"
+	"net/url"
 	"pandora-pay/config"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/network/banned_nodes"
@@ -11,6 +12,7 @@ import (
 	"pandora-pay/store/min_max_heap"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type KnownNodes struct {
@@ -70,6 +72,7 @@ func (self *KnownNodes) IncreaseKnownNodeScore(knownNode *known_node.KnownNodeSc
 }
 
 func (self *KnownNodes) DecreaseKnownNodeScore(knownNode *known_node.KnownNodeScored, delta int32, isServer bool) (bool, bool) {
+	atomic.AddInt32(&knownNode.Failures, 1)
 	update, removed, score := knownNode.DecreaseScore(delta, isServer)
 	if removed {
 		self.RemoveKnownNode(knownNode)
@@ -86,12 +89,17 @@ func (self *KnownNodes) DecreaseKnownNodeScore(knownNode *known_node.KnownNodeSc
 }
 
 func (self *KnownNodes) MarkKnownNodeConnected(knownNode *known_node.KnownNodeScored) {
+	atomic.AddInt32(&knownNode.Successes, 1)
+	atomic.StoreInt64(&knownNode.LastSeen, time.Now().Unix())
+
 	self.knownNotConnectedMaxHeapMutex.Lock()
 	defer self.knownNotConnectedMaxHeapMutex.Unlock()
 	self.knownNotConnectedMaxHeap.DeleteByKey([]byte(knownNode.URL))
 }
 
 func (self *KnownNodes) MarkKnownNodeDisconnected(knownNode *known_node.KnownNodeScored) {
+	atomic.StoreInt64(&knownNode.LastSeen, time.Now().Unix())
+
 	self.knownNotConnectedMaxHeapMutex.Lock()
 	defer self.knownNotConnectedMaxHeapMutex.Unlock()
 	self.knownNotConnectedMaxHeap.Update(float64(atomic.LoadInt32(&knownNode.Score)), []byte(knownNode.URL))
@@ -138,6 +146,25 @@ func (self *KnownNodes) AddKnownNode(url string, isSeed bool) (*known_node.Known
 	return knownNode, nil
 }
 
+func (self *KnownNodes) GetKnownNode(url string) *known_node.KnownNodeScored {
+	knownNode, _ := self.knownMap.Load(url)
+	return knownNode
+}
+
+// BanNode bans the url, removes it from the known nodes and closes its connection
+func (self *KnownNodes) BanNode(url *url.URL, message string, duration time.Duration) {
+
+	urlStr := url.String()
+	self.bannedNodes.Ban(url, urlStr, message, duration, true)
+
+	if knownNode := self.GetKnownNode(urlStr); knownNode != nil {
+		self.RemoveKnownNode(knownNode)
+	}
+	if conn, ok := self.connectedNodes.AllAddresses.Load(urlStr); ok {
+		conn.Close()
+	}
+}
+
 func (self *KnownNodes) RemoveKnownNode(knownNode *known_node.KnownNodeScored) {
 
 	if _, exists := self.knownMap.LoadAndDelete(knownNode.URL); exists {
@@ -0,0 +1,83 @@
+package known_nodes
+
+import (
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"sync/atomic"
+)
+
+type knownNodeStored struct {
+	URL       string `msgpack:"url"`
+	Score     int32  `msgpack:"score"`
+	LastSeen  int64  `msgpack:"lastSeen"`
+	Successes int32  `msgpack:"successes"`
+	Failures  int32  `msgpack:"failures"`
+}
+
+// SaveKnownNodes stores the address book into the settings store
+func (self *KnownNodes) SaveKnownNodes() error {
+
+	list := self.GetList()
+
+	stored := make([]*knownNodeStored, len(list))
+	for i, knownNode := range list {
+		stored[i] = &knownNodeStored{
+			knownNode.URL,
+			atomic.LoadInt32(&knownNode.Score),
+			atomic.LoadInt64(&knownNode.LastSeen),
+			atomic.LoadInt32(&knownNode.Successes),
+			atomic.LoadInt32(&knownNode.Failures),
+		}
+	}
+
+	data, err := msgpack.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	return store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("knownNodes", data)
+		return nil
+	})
+}
+
+// LoadKnownNodes adds the stored known nodes restoring their score. The seeds should be added before
+func (self *KnownNodes) LoadKnownNodes() error {
+
+	var stored []*knownNodeStored
+
+	if err := store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		data := reader.Get("knownNodes")
+		if data == nil {
+			return nil
+		}
+		return msgpack.Unmarshal(data, &stored)
+	}); err != nil {
+		return err
+	}
+
+	for _, data := range stored {
+
+		knownNode := self.GetKnownNode(data.URL)
+		if knownNode == nil {
+			var err error
+			if knownNode, err = self.AddKnownNode(data.URL, false); err != nil {
+				continue
+			}
+		}
+
+		atomic.StoreInt32(&knownNode.Score, data.Score)
+		atomic.StoreInt64(&knownNode.LastSeen, data.LastSeen)
+		atomic.StoreInt32(&knownNode.Successes, data.Successes)
+		atomic.StoreInt32(&knownNode.Failures, data.Failures)
+
+		if _, ok := self.connectedNodes.AllAddresses.Load(data.URL); !ok {
+			self.knownNotConnectedMaxHeapMutex.Lock()
+			self.knownNotConnectedMaxHeap.Update(float64(data.Score), []byte(data.URL))
+			self.knownNotConnectedMaxHeapMutex.Unlock()
+		}
+	}
+
+	return nil
+}
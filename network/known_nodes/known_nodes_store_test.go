@@ -0,0 +1,66 @@
+package known_nodes
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"pandora-pay/network/banned_nodes"
+	"pandora-pay/network/connected_nodes"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_memory"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKnownNodesStore(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("settings")
+	assert.NoError(t, err)
+	store.StoreSettings = &store.Store{Name: "settings", Opened: true, DB: db}
+
+	seedURL, nodeURL, bannedURL := "ws://1.1.1.1:16000/ws", "ws://2.2.2.2:16000/ws", "ws://3.3.3.3:16000/ws"
+
+	knownNodes := NewKnownNodes(connected_nodes.NewConnectedNodes(), banned_nodes.NewBannedNodes())
+
+	seed, err := knownNodes.AddKnownNode(seedURL, true)
+	assert.NoError(t, err)
+	knownNodes.IncreaseKnownNodeScore(seed, 10, false)
+
+	node, err := knownNodes.AddKnownNode(nodeURL, false)
+	assert.NoError(t, err)
+	knownNodes.IncreaseKnownNodeScore(node, 50, false)
+	knownNodes.MarkKnownNodeConnected(node)
+	knownNodes.MarkKnownNodeDisconnected(node)
+	knownNodes.DecreaseKnownNodeScore(node, -5, false)
+
+	_, err = knownNodes.AddKnownNode(bannedURL, false)
+	assert.NoError(t, err)
+
+	assert.NoError(t, knownNodes.SaveKnownNodes())
+
+	bannedNodes := banned_nodes.NewBannedNodes()
+	bannedNodes.Ban(&url.URL{Scheme: "ws", Host: "3.3.3.3:16000", Path: "/ws"}, bannedURL, "Invalid block", time.Hour, false)
+
+	//the seeds are added before loading
+	loaded := NewKnownNodes(connected_nodes.NewConnectedNodes(), bannedNodes)
+	_, err = loaded.AddKnownNode(seedURL, true)
+	assert.NoError(t, err)
+
+	assert.NoError(t, loaded.LoadKnownNodes())
+
+	assert.Equal(t, 2, len(loaded.GetList()))
+	assert.Nil(t, loaded.GetKnownNode(bannedURL), "the banned nodes are not restored")
+
+	loadedSeed := loaded.GetKnownNode(seedURL)
+	assert.True(t, loadedSeed.IsSeed)
+	assert.Equal(t, int32(10), atomic.LoadInt32(&loadedSeed.Score))
+
+	loadedNode := loaded.GetKnownNode(nodeURL)
+	assert.False(t, loadedNode.IsSeed)
+	assert.Equal(t, int32(45), atomic.LoadInt32(&loadedNode.Score))
+	assert.Equal(t, atomic.LoadInt64(&node.LastSeen), atomic.LoadInt64(&loadedNode.LastSeen))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loadedNode.Successes))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loadedNode.Failures))
+
+	assert.Equal(t, loadedNode, loaded.GetBestNotConnectedKnownNode(), "the restored scores order the nodes to connect to")
+}
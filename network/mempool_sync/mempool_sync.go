@@ -13,8 +13,6 @@ type MempoolSync struct {
 
 func (self *MempoolSync) DownloadMempool(conn *connection.AdvancedConnection) (err error) {
 
-	cb := self.websockets.ApiWebsockets.GetMap["mempool/new-tx-id"]
-
 	index, page := 0, 0
 	count := config.API_MEMPOOL_MAX_TRANSACTIONS
 
@@ -37,7 +35,7 @@ func (self *MempoolSync) DownloadMempool(conn *connection.AdvancedConnection) (e
 		}
 
 		for _, tx := range data.Hashes {
-			cb(conn, tx)
+			self.websockets.ApiWebsockets.DownloadMempoolTxId(conn, tx)
 		}
 
 		index += len(data.Hashes)
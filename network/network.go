@@ -31,11 +31,17 @@ func NewNetwork(settings *settings.Settings, chain *blockchain.Blockchain, mempo
 
 	connectedNodes := connected_nodes.NewConnectedNodes()
 	bannedNodes := banned_nodes.NewBannedNodes()
+	if err := bannedNodes.LoadBannedNodes(); err != nil {
+		return nil, err
+	}
 
 	knownNodes := known_nodes.NewKnownNodes(connectedNodes, bannedNodes)
 	for _, seed := range config.NETWORK_SELECTED_SEEDS {
 		knownNodes.AddKnownNode(seed.Url, true)
 	}
+	if err := knownNodes.LoadKnownNodes(); err != nil {
+		return nil, err
+	}
 
 	tcpServer, err := node_tcp.NewTcpServer(connectedNodes, bannedNodes, knownNodes, settings, chain, mempool, wallet, txsValidator, txsBuilder)
 	if err != nil {
@@ -63,5 +69,7 @@ func NewNetwork(settings *settings.Settings, chain *blockchain.Blockchain, mempo
 
 	network.syncBlockchainNewConnections()
 
+	network.continuouslySaveKnownNodes()
+
 	return network, nil
 }
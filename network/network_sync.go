@@ -47,7 +47,7 @@ func (network *Network) continuouslyConnectingNewPeers() {
 							}
 
 						} else {
-							gui.GUI.Log("connected to: " + knownNode.URL)
+							gui.Network().Log("connected to: " + knownNode.URL)
 						}
 					}
 				}
@@ -118,6 +118,17 @@ func (network *Network) continuouslyDownloadNetworkNodes() {
 
 }
 
+func (network *Network) continuouslySaveKnownNodes() {
+	recovery.SafeGo(func() {
+		for {
+			time.Sleep(config.NETWORK_KNOWN_NODES_SAVE_INTERVAL)
+			if err := network.KnownNodes.SaveKnownNodes(); err != nil {
+				gui.Network().Error("Error saving known nodes", err)
+			}
+		}
+	})
+}
+
 func (network *Network) syncBlockchainNewConnections() {
 	recovery.SafeGo(func() {
 
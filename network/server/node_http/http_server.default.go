@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"pandora-pay/config"
+	"pandora-pay/metrics"
 )
 
 func (server *HttpServer) get(w http.ResponseWriter, req *http.Request) {
@@ -23,6 +24,13 @@ func (server *HttpServer) get(w http.ResponseWriter, req *http.Request) {
 	var err error
 	var output interface{}
 
+	if limiter := server.rateLimiters[req.URL.Path]; limiter != nil {
+		if !limiter.AllowRemoteAddr(req.RemoteAddr) {
+			http.Error(w, "Too many requests", http.StatusTooManyRequests)
+			return
+		}
+	}
+
 	callback := server.GetMap[req.URL.Path]
 	if callback != nil {
 
@@ -98,11 +106,19 @@ func (server *HttpServer) post(w http.ResponseWriter, req *http.Request) {
 	w.Write(final)
 }
 
+func (server *HttpServer) metrics(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	metrics.Write(w)
+}
+
 func (server *HttpServer) GetHttpHandler() *http.Handler {
 
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/ws", server.websocketServer.HandleUpgradeConnection)
+	mux.HandleFunc("/metrics", server.metrics)
+	mux.HandleFunc("/health", server.health.handleHealth)
+	mux.HandleFunc("/ready", server.health.handleReady)
 
 	if config.FAUCET_TESTNET_ENABLED {
 		fs := http.FileServer(http.Dir("../../../static/challenge"))
@@ -114,6 +130,10 @@ func (server *HttpServer) GetHttpHandler() *http.Handler {
 		server.GetMap["/"+key] = callback
 	}
 
+	for key, limiter := range server.Api.RateLimiters {
+		server.rateLimiters["/"+key] = limiter
+	}
+
 	for key, callback := range server.Api.PostMap {
 		mux.HandleFunc("/"+key, server.post)
 		server.PostMap["/"+key] = callback
@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/url"
 	"pandora-pay/blockchain"
+	"pandora-pay/helpers/rate_limiter"
 	"pandora-pay/mempool"
 	"pandora-pay/network/api/api_common"
 	"pandora-pay/network/api/api_http"
@@ -25,14 +26,16 @@ type HttpServer struct {
 	Api             *api_http.API
 	ApiWebsockets   *api_websockets.APIWebsockets
 	ApiStore        *api_common.APIStore
+	health          *health
 	GetMap          map[string]func(values url.Values) (any, error)
 	PostMap         map[string]func(values io.ReadCloser) (any, error)
+	rateLimiters    map[string]*rate_limiter.RateLimiters
 }
 
 func NewHttpServer(chain *blockchain.Blockchain, settings *settings.Settings, connectedNodes *connected_nodes.ConnectedNodes, bannedNodes *banned_nodes.BannedNodes, knownNodes *known_nodes.KnownNodes, mempool *mempool.Mempool, wallet *wallet.Wallet, txsValidator *txs_validator.TxsValidator, txsBuilder *txs_builder.TxsBuilder) (*HttpServer, error) {
 
 	apiStore := api_common.NewAPIStore(chain)
-	apiCommon, err := api_common.NewAPICommon(knownNodes, mempool, chain, wallet, txsValidator, txsBuilder, apiStore)
+	apiCommon, err := api_common.NewAPICommon(knownNodes, bannedNodes, mempool, chain, wallet, txsValidator, txsBuilder, apiStore)
 	if err != nil {
 		return nil, err
 	}
@@ -47,11 +50,15 @@ func NewHttpServer(chain *blockchain.Blockchain, settings *settings.Settings, co
 		Websockets:      websockets,
 		GetMap:          make(map[string]func(values url.Values) (any, error)),
 		PostMap:         make(map[string]func(values io.ReadCloser) (any, error)),
+		rateLimiters:    make(map[string]*rate_limiter.RateLimiters),
 		Api:             api,
 		ApiWebsockets:   apiWebsockets,
 		ApiStore:        apiStore,
+		health:          newHealth(chain, mempool, connectedNodes, wallet),
 	}
 
+	registerMetrics(chain, mempool, connectedNodes)
+
 	if err = node_http_rpc.InitializeRPC(apiCommon); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,143 @@
+package node_http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"pandora-pay/blockchain"
+	"pandora-pay/config"
+	"pandora-pay/mempool"
+	"pandora-pay/network/connected_nodes"
+	"pandora-pay/recovery"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/wallet"
+	"sync/atomic"
+	"time"
+)
+
+type healthCheck struct {
+	Ok    bool   `json:"ok"`
+	Value any    `json:"value,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type healthReply struct {
+	Ok      bool                    `json:"ok"`
+	Checks  map[string]*healthCheck `json:"checks"`
+	Forging *bool                   `json:"forging,omitempty"` //informative, it doesn't change the readiness
+}
+
+type health struct {
+	chain          *blockchain.Blockchain
+	mempool        *mempool.Mempool
+	connectedNodes *connected_nodes.ConnectedNodes
+	wallet         *wallet.Wallet
+}
+
+func (reply *healthReply) add(name string, value any, err error) {
+	check := &healthCheck{Ok: err == nil, Value: value}
+	if err != nil {
+		check.Error = err.Error()
+		reply.Ok = false
+	}
+	reply.Checks[name] = check
+}
+
+func (h *health) checkDB(ctx context.Context) error {
+
+	answerCn := make(chan error, 1)
+	recovery.SafeGo(func() {
+		answerCn <- store.StoreBlockchain.DB.View(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+			dbTx.Get("chainHash")
+			return nil
+		})
+	})
+
+	select {
+	case err := <-answerCn:
+		return err
+	case <-ctx.Done():
+		return errors.New("Database is not responding")
+	}
+}
+
+// healthReply returns if the process is alive and the database is reachable
+func (h *health) healthReply(ctx context.Context) *healthReply {
+	reply := &healthReply{true, make(map[string]*healthCheck), nil}
+	reply.add("process", nil, nil)
+	reply.add("db", nil, h.checkDB(ctx))
+	return reply
+}
+
+// readyReply returns if the node is synchronized, connected and can process transactions
+func (h *health) readyReply(ctx context.Context) *healthReply {
+
+	reply := h.healthReply(ctx)
+
+	var err error
+
+	syncData := h.chain.Sync.GetSyncData()
+	if syncData == nil || !syncData.Sync {
+		err = errors.New("Node is not synchronized")
+	}
+	reply.add("sync", nil, err)
+
+	err = nil
+	peers := atomic.LoadInt64(&h.connectedNodes.TotalSockets)
+	if peers < config.READY_MIN_PEERS {
+		err = errors.New("Not enough connected nodes")
+	}
+	reply.add("peers", peers, err)
+
+	err = nil
+	age := int64(0)
+	if now, timestamp := time.Now().Unix(), int64(h.chain.GetChainData().Timestamp); now > timestamp {
+		age = now - timestamp
+	}
+	if uint64(age) > config.READY_MAX_BLOCK_AGE {
+		err = errors.New("Last block is too old")
+	}
+	reply.add("lastBlockAge", age, err)
+
+	reply.add("mempool", h.mempool.Txs.GetCount(), h.mempool.Ping(ctx))
+
+	forging := h.wallet.IsForging()
+	reply.Forging = &forging
+
+	return reply
+}
+
+func (h *health) write(w http.ResponseWriter, reply *healthReply) {
+
+	final, err := json.Marshal(reply)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if reply.Ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(final)
+}
+
+func (h *health) handleHealth(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), config.HEALTH_CHECK_TIMEOUT)
+	defer cancel()
+	h.write(w, h.healthReply(ctx))
+}
+
+func (h *health) handleReady(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), config.HEALTH_CHECK_TIMEOUT)
+	defer cancel()
+	h.write(w, h.readyReply(ctx))
+}
+
+func newHealth(chain *blockchain.Blockchain, mempool *mempool.Mempool, connectedNodes *connected_nodes.ConnectedNodes, wallet *wallet.Wallet) *health {
+	return &health{chain, mempool, connectedNodes, wallet}
+}
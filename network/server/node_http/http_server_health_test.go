@@ -0,0 +1,88 @@
+package node_http
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/blockchain_sync"
+	"pandora-pay/config"
+	"pandora-pay/config/globals"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/mempool"
+	"pandora-pay/network/connected_nodes"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/wallet"
+	"testing"
+	"time"
+)
+
+func createTestChain(sync *blockchain_sync.BlockchainSync, timestamp uint64) *blockchain.Blockchain {
+	chain := &blockchain.Blockchain{ChainData: &generics.Value[*blockchain.BlockchainData]{}, Sync: sync}
+	chain.ChainData.Store(&blockchain.BlockchainData{Timestamp: timestamp})
+	return chain
+}
+
+func TestHealthReady(t *testing.T) {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(false)
+	assert.NoError(t, err)
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{Name: "blockchain", Opened: true, DB: db}
+
+	defer func(timeout time.Duration) { config.HEALTH_CHECK_TIMEOUT = timeout }(config.HEALTH_CHECK_TIMEOUT)
+	config.HEALTH_CHECK_TIMEOUT = 200 * time.Millisecond
+
+	globals.Arguments = map[string]any{"--skip-init-sync": false}
+	notSynced := blockchain_sync.CreateBlockchainSync()
+
+	globals.Arguments["--skip-init-sync"] = true
+	synced := blockchain_sync.CreateBlockchainSync()
+	assert.Eventually(t, func() bool { return synced.GetSyncData().Sync }, 5*time.Second, 50*time.Millisecond)
+
+	workingMempool, err := mempool.CreateMempool(nil)
+	assert.NoError(t, err)
+
+	now := uint64(time.Now().Unix())
+
+	for _, test := range []struct {
+		name   string
+		health *health
+		failed string
+	}{
+		{"healthy", newHealth(createTestChain(synced, now), workingMempool, &connected_nodes.ConnectedNodes{TotalSockets: 1}, &wallet.Wallet{}), ""},
+		{"not synchronized", newHealth(createTestChain(notSynced, now), workingMempool, &connected_nodes.ConnectedNodes{TotalSockets: 1}, &wallet.Wallet{}), "sync"},
+		{"too few peers", newHealth(createTestChain(synced, now), workingMempool, &connected_nodes.ConnectedNodes{TotalSockets: 0}, &wallet.Wallet{}), "peers"},
+		{"stale last block", newHealth(createTestChain(synced, now-config.READY_MAX_BLOCK_AGE-10), workingMempool, &connected_nodes.ConnectedNodes{TotalSockets: 1}, &wallet.Wallet{}), "lastBlockAge"},
+		{"mempool not responding", newHealth(createTestChain(synced, now), &mempool.Mempool{Txs: workingMempool.Txs}, &connected_nodes.ConnectedNodes{TotalSockets: 1}, &wallet.Wallet{}), "mempool"}, //no worker answers the ping
+	} {
+
+		w := httptest.NewRecorder()
+		test.health.handleReady(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+		reply := &healthReply{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), reply), test.name)
+		assert.Equal(t, test.failed == "", reply.Ok, test.name)
+		if test.failed == "" {
+			assert.Equal(t, http.StatusOK, w.Code, test.name)
+		} else {
+			assert.Equal(t, http.StatusServiceUnavailable, w.Code, test.name)
+		}
+
+		assert.Equal(t, 6, len(reply.Checks), test.name)
+		for name, check := range reply.Checks {
+			assert.Equal(t, name != test.failed, check.Ok, test.name+" "+name)
+			assert.Equal(t, name != test.failed, check.Error == "", test.name+" "+name)
+		}
+		assert.NotNil(t, reply.Forging, test.name)
+		assert.False(t, *reply.Forging, test.name)
+	}
+
+}
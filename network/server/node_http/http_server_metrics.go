@@ -0,0 +1,47 @@
+package node_http
+
+import (
+	"pandora-pay/blockchain"
+	"pandora-pay/mempool"
+	"pandora-pay/metrics"
+	"pandora-pay/network/connected_nodes"
+	"sync/atomic"
+)
+
+// registerMetrics exports the state of the node which is read when the metrics are requested
+func registerMetrics(chain *blockchain.Blockchain, mempool *mempool.Mempool, connectedNodes *connected_nodes.ConnectedNodes) {
+
+	metrics.NewGaugeFunc("pandora_chain_height", "Height of the blockchain", "", func() float64 {
+		return float64(chain.GetChainData().Height)
+	})
+	metrics.NewGaugeFunc("pandora_chain_transactions", "Transactions included in the blockchain", "", func() float64 {
+		return float64(chain.GetChainData().TransactionsCount)
+	})
+
+	metrics.NewGaugeFunc("pandora_sync", "1 when the node is synchronized with the network", "", func() float64 {
+		if syncData := chain.Sync.GetSyncData(); syncData != nil && syncData.Sync {
+			return 1
+		}
+		return 0
+	})
+	metrics.NewGaugeFunc("pandora_sync_blocks_changed", "Blocks changed in the last sync interval", "", func() float64 {
+		if syncData := chain.Sync.GetSyncData(); syncData != nil {
+			return float64(syncData.BlocksChangedLastInterval)
+		}
+		return 0
+	})
+
+	metrics.NewGaugeFunc("pandora_mempool_txs", "Transactions in mempool", "", func() float64 {
+		return float64(mempool.Txs.GetCount())
+	})
+	metrics.NewGaugeFunc("pandora_mempool_bytes", "Size in bytes of the transactions in mempool", "", func() float64 {
+		return float64(mempool.Txs.GetSize())
+	})
+
+	metrics.NewGaugeFunc("pandora_connected_nodes", "Connected nodes", `type="client"`, func() float64 {
+		return float64(atomic.LoadInt64(&connectedNodes.Clients))
+	})
+	metrics.NewGaugeFunc("pandora_connected_nodes", "Connected nodes", `type="server"`, func() float64 {
+		return float64(atomic.LoadInt64(&connectedNodes.ServerSockets))
+	})
+}
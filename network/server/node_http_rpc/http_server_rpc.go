@@ -10,12 +10,22 @@ type HTTPServerRPCRoutes struct {
 	apiCommon *api_common.APICommon
 }
 
-func InitializeRPC(apiCommon *api_common.APICommon) (err error) {
+func newRPCServer(apiCommon *api_common.APICommon) (*rpc.Server, error) {
 
 	s := rpc.NewServer()
 
 	s.RegisterCodec(NewUpCodec(), "application/json")
-	if err = s.RegisterService(apiCommon, "api"); err != nil {
+	if err := s.RegisterService(apiCommon, "api"); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func InitializeRPC(apiCommon *api_common.APICommon) (err error) {
+
+	s, err := newRPCServer(apiCommon)
+	if err != nil {
 		return
 	}
 
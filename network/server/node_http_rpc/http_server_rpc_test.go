@@ -0,0 +1,36 @@
+package node_http_rpc
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"pandora-pay/helpers/rate_limiter"
+	"pandora-pay/network/api/api_common"
+	"strings"
+	"testing"
+)
+
+func TestRPCMempoolNewTxRateLimit(t *testing.T) {
+
+	limiters := rate_limiter.NewRateLimiters(0.001, 1)
+
+	s, err := newRPCServer(&api_common.APICommon{NewTxsRateLimiters: limiters})
+	assert.NoError(t, err)
+
+	//the HTTP GET mempool/new-tx consumed the burst of the address
+	assert.True(t, limiters.Allow("1.2.3.4"))
+
+	req := httptest.NewRequest(http.MethodPost, "/rpc/api/v1", strings.NewReader(`{"method":"mempool/new-tx","params":[{"tx":""}],"id":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "1.2.3.4:5000"
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+
+	reply := struct {
+		Error any `json:"error"`
+	}{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &reply))
+	assert.Equal(t, "Too many requests", reply.Error)
+}
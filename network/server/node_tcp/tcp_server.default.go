@@ -85,8 +85,8 @@ func NewTcpServer(connectedNodes *connected_nodes.ConnectedNodes, bannedNodes *b
 		server.Address = address
 	}
 
-	bannedNodes.Ban(&url.URL{Scheme: "ws", Host: "127.0.0.1:" + port, Path: "/ws"}, "", "You can't connect to yourself", 10*365*24*time.Hour)
-	bannedNodes.Ban(&url.URL{Scheme: "ws", Host: address + ":" + port, Path: "/ws"}, "", "You can't connect to yourself", 10*365*24*time.Hour)
+	bannedNodes.Ban(&url.URL{Scheme: "ws", Host: "127.0.0.1:" + port, Path: "/ws"}, "", "You can't connect to yourself", 10*365*24*time.Hour, false)
+	bannedNodes.Ban(&url.URL{Scheme: "ws", Host: address + ":" + port, Path: "/ws"}, "", "You can't connect to yourself", 10*365*24*time.Hour, false)
 
 	var certPath, keyPath string
 	if globals.Arguments["--tcp-server-tls-cert-file"] != nil {
@@ -143,7 +143,7 @@ func NewTcpServer(connectedNodes *connected_nodes.ConnectedNodes, bannedNodes *b
 		config.NETWORK_ADDRESS_URL_STRING = url.String()
 		config.NETWORK_WEBSOCKET_ADDRESS_URL_STRING = websocketUrl.String()
 
-		bannedNodes.Ban(websocketUrl, "", "You can't connect to yourself", 10*365*24*time.Hour)
+		bannedNodes.Ban(websocketUrl, "", "You can't connect to yourself", 10*365*24*time.Hour, false)
 		server.URL = url
 	}
 
@@ -151,13 +151,13 @@ func NewTcpServer(connectedNodes *connected_nodes.ConnectedNodes, bannedNodes *b
 		if server.tcpListener, err = tls.Listen("tcp", ":"+port, tlsConfig); err != nil {
 			return nil, err
 		}
-		gui.GUI.Info("TLS Certificate loaded for ", address, port)
+		gui.Network().Info("TLS Certificate loaded for ", address, port)
 	} else {
 		// no ssl at all
 		if server.tcpListener, err = net.Listen("tcp", ":"+port); err != nil {
 			return nil, errors.New("Error creating TcpServer" + err.Error())
 		}
-		gui.GUI.Warning("No TLS Certificate")
+		gui.Network().Warning("No TLS Certificate")
 	}
 
 	gui.GUI.InfoUpdate("TCP", address+":"+port)
@@ -168,9 +168,9 @@ func NewTcpServer(connectedNodes *connected_nodes.ConnectedNodes, bannedNodes *b
 
 	recovery.SafeGo(func() {
 		if err := http.Serve(server.tcpListener, *server.HttpServer.GetHttpHandler()); err != nil {
-			gui.GUI.Error("Error opening HTTP server", err)
+			gui.Network().Error("Error opening HTTP server", err)
 		}
-		gui.GUI.Info("HTTP server")
+		gui.Network().Info("HTTP server")
 	})
 
 	return server, nil
@@ -9,6 +9,7 @@ import (
 	"pandora-pay/config"
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/rate_limiter"
 	"pandora-pay/network/known_nodes/known_node"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/network/websocks/websock"
@@ -49,6 +50,7 @@ type AdvancedConnection struct {
 	ConnectionType           bool
 	onClosedConnection       func(c *AdvancedConnection)
 	onIncreaseKnownNodeScore func(knownNode *known_node.KnownNodeScored, delta int32, isServer bool) bool
+	NewTxsRateLimiter        *rate_limiter.RateLimiter //limits the transactions pushed by the other side
 }
 
 func (c *AdvancedConnection) GetTimeout() time.Duration {
@@ -323,6 +325,14 @@ func (c *AdvancedConnection) IncreaseKnownNodeScore() {
 
 }
 
+// IncreaseKnownNodeScoreBy rewards the known node of the connection. Connections opened by other nodes have no known node
+func (c *AdvancedConnection) IncreaseKnownNodeScoreBy(delta int32) bool {
+	if c.KnownNode == nil {
+		return false
+	}
+	return c.onIncreaseKnownNodeScore(c.KnownNode, delta, c.ConnectionType)
+}
+
 func NewAdvancedConnection(conn *websock.Conn, remoteAddr string, knownNode *known_node.KnownNodeScored, getMap map[string]func(conn *AdvancedConnection, values []byte) (interface{}, error), connectionType bool, newSubscriptionCn, removeSubscriptionCn chan<- *SubscriptionNotification, onClosedConnection func(*AdvancedConnection), onIncreaseKnownNodeScore func(*known_node.KnownNodeScored, int32, bool) bool) (*AdvancedConnection, error) {
 
 	//making sure u is not collided with UUID_ALL and UUID_SKIP_ALL
@@ -352,6 +362,7 @@ func NewAdvancedConnection(conn *websock.Conn, remoteAddr string, knownNode *kno
 		connectionType,
 		onClosedConnection,
 		onIncreaseKnownNodeScore,
+		rate_limiter.NewRateLimiter(config.MEMPOOL_NEW_TXS_RATE_LIMIT, config.MEMPOOL_NEW_TXS_RATE_BURST),
 	}
 	advancedConnection.Subscriptions = NewSubscriptions(advancedConnection, newSubscriptionCn, removeSubscriptionCn)
 	return advancedConnection, nil
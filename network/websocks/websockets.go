@@ -103,7 +103,7 @@ func (websockets *Websockets) BroadcastAwaitAnswer(name, data []byte, consensusT
 
 	t := time.Now().Unix()
 	index := rand.Int()
-	gui.GUI.Log("Propagating", index, len(all), string(name), t)
+	gui.Network().Log("Propagating", index, len(all), string(name), t)
 
 	chans := make(chan *advanced_connection_types.AdvancedConnectionReply, len(all)+1)
 	for i, conn := range all {
@@ -121,7 +121,7 @@ func (websockets *Websockets) BroadcastAwaitAnswer(name, data []byte, consensusT
 	for i := range all {
 		out[i] = <-chans
 		if out[i] != nil && out[i].Err != nil {
-			gui.GUI.Error("Error propagating", index, out[i].Err, len(all), string(name), all[i].RemoteAddr, all[i].UUID, time.Now().Unix()-t)
+			gui.Network().Error("Error propagating", index, out[i].Err, len(all), string(name), all[i].RemoteAddr, all[i].UUID, time.Now().Unix()-t)
 		}
 	}
 
@@ -3,13 +3,25 @@ package websocks
 import (
 	"github.com/vmihailenco/msgpack/v5"
 	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_extra"
 	"pandora-pay/config"
+	"pandora-pay/cryptography"
 	"pandora-pay/helpers"
 	"pandora-pay/mempool"
+	"pandora-pay/metrics"
 	"pandora-pay/network/api/api_common/api_types"
 	"pandora-pay/network/websocks/connection"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/recovery"
+	"pandora-pay/store"
+	"pandora-pay/store/hash_map"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
 )
 
 type WebsocketSubscriptions struct {
@@ -23,6 +35,7 @@ type WebsocketSubscriptions struct {
 	accountsTransactionsSubscriptions map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 	assetsSubscriptions               map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 	transactionsSubscriptions         map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
+	conditionalPaymentsSubscriptions  map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 }
 
 func newWebsocketSubscriptions(websockets *Websockets, chain *blockchain.Blockchain, mempool *mempool.Mempool) (subs *WebsocketSubscriptions) {
@@ -35,6 +48,7 @@ func newWebsocketSubscriptions(websockets *Websockets, chain *blockchain.Blockch
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
+		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 	}
 
 	if config.SEED_WALLET_NODES_INFO {
@@ -103,6 +117,8 @@ func (this *WebsocketSubscriptions) getSubsMap(subscriptionType api_types.Subscr
 		subsMap = this.assetsSubscriptions
 	case api_types.SUBSCRIPTION_TRANSACTION:
 		subsMap = this.transactionsSubscriptions
+	case api_types.SUBSCRIPTION_CONDITIONAL_PAYMENTS:
+		subsMap = this.conditionalPaymentsSubscriptions
 	}
 	return
 }
@@ -115,6 +131,7 @@ func (this *WebsocketSubscriptions) removeConnection(conn *connection.AdvancedCo
 	for key, value := range subsMap {
 		if value[conn.UUID] != nil {
 			delete(value, conn.UUID)
+			metrics.WebsocketSubscriptions.Add(-1)
 		}
 		if len(value) == 0 {
 			deleted = append(deleted, key)
@@ -125,6 +142,107 @@ func (this *WebsocketSubscriptions) removeConnection(conn *connection.AdvancedCo
 	}
 }
 
+type conditionalPaymentNotification struct {
+	multisigPublicKeys [][]byte
+	element            helpers.SerializableInterface
+	extra              *api_types.APISubscriptionNotificationConditionalPaymentExtra
+}
+
+// the multisig keys of deleted conditional payments are read from the info stored for the transaction
+func (this *WebsocketSubscriptions) loadConditionalPaymentMultisigKeys(txId []byte, payloadIndex byte) (multisigPublicKeys [][]byte, err error) {
+	err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		data := reader.Get("txCondPayments:" + string(txId))
+		if data == nil {
+			return
+		}
+
+		list := make([]*info.ConditionalPaymentInfo, 0)
+		if err = msgpack.Unmarshal(data, &list); err != nil {
+			return
+		}
+
+		for _, condPaymentInfo := range list {
+			if condPaymentInfo.PayloadIndex == payloadIndex {
+				multisigPublicKeys = condPaymentInfo.MultisigPublicKeys
+			}
+		}
+		return
+	})
+	return
+}
+
+// expired is set for the deleted payments which were settled with their default resolution
+func (this *WebsocketSubscriptions) getConditionalPaymentNotification(expiryHeight, chainHeight uint64, key string, expired bool, committed *hash_map.CommittedMapElement[*conditional_payment.ConditionalPayment]) *conditionalPaymentNotification {
+
+	if len(key) <= cryptography.HashSize+1 {
+		return nil
+	}
+
+	txId := []byte(key[:cryptography.HashSize])
+	payloadIndex, err := strconv.Atoi(key[cryptography.HashSize+1:])
+	if err != nil {
+		return nil
+	}
+
+	notification := &conditionalPaymentNotification{
+		extra: &api_types.APISubscriptionNotificationConditionalPaymentExtra{txId, byte(payloadIndex), expiryHeight, api_types.CONDITIONAL_PAYMENT_CREATED},
+	}
+
+	if committed.Element != nil {
+		notification.element = committed.Element
+		notification.multisigPublicKeys = committed.Element.MultisigPublicKeys
+		if committed.Element.Processed {
+			notification.extra.Status = api_types.CONDITIONAL_PAYMENT_RESOLVED
+		}
+		return notification
+	}
+
+	//the info of the transactions removed by a reorg is already deleted, they are notified using the removed transactions
+	if notification.multisigPublicKeys, err = this.loadConditionalPaymentMultisigKeys(txId, byte(payloadIndex)); err != nil || notification.multisigPublicKeys == nil {
+		return nil
+	}
+
+	if expiryHeight < chainHeight {
+		//the payments resolved before their expiry were already notified when they were resolved
+		if !expired {
+			return nil
+		}
+		notification.extra.Status = api_types.CONDITIONAL_PAYMENT_EXPIRED
+	} else {
+		notification.extra.Status = api_types.CONDITIONAL_PAYMENT_REMOVED
+	}
+	return notification
+}
+
+// the conditional payments of a transaction removed by a reorg are created from the transaction itself
+func getRemovedTxConditionalPaymentsNotifications(tx *transaction.Transaction, blockHeight uint64) (list []*conditionalPaymentNotification) {
+
+	if tx.Version != transaction_type.TX_ZETHER {
+		return
+	}
+
+	for i, payload := range tx.TransactionBaseInterface.(*transaction_zether.TransactionZether).Payloads {
+		if extra, ok := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPayment); ok {
+			list = append(list, &conditionalPaymentNotification{
+				extra.MultisigPublicKeys,
+				nil,
+				&api_types.APISubscriptionNotificationConditionalPaymentExtra{tx.Bloom.Hash, byte(i), blockHeight + extra.Deadline, api_types.CONDITIONAL_PAYMENT_REMOVED},
+			})
+		}
+	}
+
+	return
+}
+
+func (this *WebsocketSubscriptions) sendConditionalPaymentNotification(notification *conditionalPaymentNotification) {
+	for _, multisigPublicKey := range notification.multisigPublicKeys {
+		if list := this.conditionalPaymentsSubscriptions[string(multisigPublicKey)]; list != nil {
+			this.send(api_types.SUBSCRIPTION_CONDITIONAL_PAYMENTS, []byte("sub/notify"), multisigPublicKey, list, notification.element, nil, notification.extra)
+		}
+	}
+}
+
 func (this *WebsocketSubscriptions) processSubscriptions() {
 
 	updateNotificationsCn := this.chain.UpdateSocketsSubscriptionsNotifications.AddListener()
@@ -151,6 +269,9 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 			if subsMap[keyStr] == nil {
 				subsMap[keyStr] = make(map[advanced_connection_types.UUID]*connection.SubscriptionNotification)
 			}
+			if subsMap[keyStr][subscription.Conn.UUID] == nil {
+				metrics.WebsocketSubscriptions.Add(1)
+			}
 			subsMap[keyStr][subscription.Conn.UUID] = subscription
 
 		case subscription := <-this.removeSubscriptionCn:
@@ -161,7 +282,10 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 
 			keyStr := string(subscription.Subscription.Key)
 			if subsMap[keyStr] != nil {
-				delete(subsMap[keyStr], subscription.Conn.UUID)
+				if subsMap[keyStr][subscription.Conn.UUID] != nil {
+					delete(subsMap[keyStr], subscription.Conn.UUID)
+					metrics.WebsocketSubscriptions.Add(-1)
+				}
 				if len(subsMap[keyStr]) == 0 {
 					delete(subsMap, keyStr)
 				}
@@ -230,6 +354,17 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 				}
 			}
 
+			chainHeight := this.chain.GetChainData().Height
+			for _, conditionalPaymentsMap := range dataStorage.ConditionalPaymentsCollection.GetAllMaps() {
+				for k, v := range conditionalPaymentsMap.HashMap.Committed {
+					if v.Stored == "update" || v.Stored == "del" {
+						if notification := this.getConditionalPaymentNotification(conditionalPaymentsMap.BlockHeight, chainHeight, k, conditionalPaymentsMap.Expired[k], v); notification != nil {
+							this.sendConditionalPaymentNotification(notification)
+						}
+					}
+				}
+			}
+
 		case txsUpdates, ok := <-updateTransactionsCn:
 			if !ok {
 				return
@@ -253,6 +388,13 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 						},
 					})
 				}
+
+				//only the transactions removed without being included again are set
+				if !v.Inserted && v.Tx != nil {
+					for _, notification := range getRemovedTxConditionalPaymentsNotifications(v.Tx, v.BlockHeight) {
+						this.sendConditionalPaymentNotification(notification)
+					}
+				}
 			}
 
 		case txUpdate, ok := <-updateMempoolTransactionsCn:
@@ -263,14 +405,14 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 			for key := range txUpdate.Keys {
 				if list := this.accountsTransactionsSubscriptions[key]; list != nil {
 					this.send(api_types.SUBSCRIPTION_ACCOUNT_TRANSACTIONS, []byte("sub/notify"), []byte(key), list, nil, txUpdate.Tx.Bloom.Hash, &api_types.APISubscriptionNotificationAccountTxExtra{
-						Mempool: &api_types.APISubscriptionNotificationAccountTxExtraMempool{txUpdate.Inserted, txUpdate.IncludedInBlockchainNotification},
+						Mempool: &api_types.APISubscriptionNotificationAccountTxExtraMempool{txUpdate.Inserted, txUpdate.IncludedInBlockchainNotification, txUpdate.ReplacedBy},
 					})
 				}
 			}
 
 			if list := this.transactionsSubscriptions[txUpdate.Tx.Bloom.HashStr]; list != nil {
 				this.send(api_types.SUBSCRIPTION_TRANSACTION, []byte("sub/notify"), txUpdate.Tx.Bloom.Hash, list, nil, nil, &api_types.APISubscriptionNotificationTxExtra{
-					Mempool: &api_types.APISubscriptionNotificationTxExtraMempool{txUpdate.Inserted, txUpdate.IncludedInBlockchainNotification},
+					Mempool: &api_types.APISubscriptionNotificationTxExtraMempool{txUpdate.Inserted, txUpdate.IncludedInBlockchainNotification, txUpdate.ReplacedBy},
 				})
 			}
 
@@ -283,6 +425,7 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 			this.removeConnection(conn, api_types.SUBSCRIPTION_ACCOUNT_TRANSACTIONS)
 			this.removeConnection(conn, api_types.SUBSCRIPTION_ASSET)
 			this.removeConnection(conn, api_types.SUBSCRIPTION_TRANSACTION)
+			this.removeConnection(conn, api_types.SUBSCRIPTION_CONDITIONAL_PAYMENTS)
 
 		}
 
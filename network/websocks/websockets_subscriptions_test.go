@@ -0,0 +1,110 @@
+package websocks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_extra"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_script"
+	"pandora-pay/cryptography"
+	"pandora-pay/network/api/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/hash_map"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestGetConditionalPaymentNotification(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.NoError(t, err)
+	store.StoreBlockchain = &store.Store{Name: "blockchain", Opened: true, DB: db}
+
+	multisigPublicKeys := [][]byte{addresses.GenerateNewPrivateKey().GeneratePublicKey()}
+	txId, removedTxId := cryptography.RandomHash(), cryptography.RandomHash()
+
+	//the info of the removed tx was deleted by the reorg
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		var data []byte
+		if data, err = msgpack.Marshal([]*info.ConditionalPaymentInfo{{1, multisigPublicKeys}}); err != nil {
+			return
+		}
+		writer.Put("txCondPayments:"+string(txId), data)
+		return
+	}))
+
+	subs := &WebsocketSubscriptions{}
+
+	condPayment := conditional_payment.NewConditionalPayment([]byte(string(txId)+"_1"), 0, 100)
+	condPayment.MultisigPublicKeys = multisigPublicKeys
+
+	processedCondPayment := conditional_payment.NewConditionalPayment([]byte(string(txId)+"_1"), 0, 100)
+	processedCondPayment.MultisigPublicKeys = multisigPublicKeys
+	processedCondPayment.Processed = true
+
+	for _, test := range []struct {
+		name        string
+		key         string
+		chainHeight uint64
+		element     *conditional_payment.ConditionalPayment
+		expired     bool
+		status      api_types.ConditionalPaymentNotificationStatus
+		notified    bool
+	}{
+		{"created", string(txId) + "_1", 50, condPayment, false, api_types.CONDITIONAL_PAYMENT_CREATED, true},
+		{"resolved", string(txId) + "_1", 50, processedCondPayment, false, api_types.CONDITIONAL_PAYMENT_RESOLVED, true},
+		{"expired", string(txId) + "_1", 101, nil, true, api_types.CONDITIONAL_PAYMENT_EXPIRED, true},
+		{"deleted after it was resolved", string(txId) + "_1", 101, nil, false, 0, false},
+		{"removed", string(txId) + "_1", 50, nil, false, api_types.CONDITIONAL_PAYMENT_REMOVED, true},
+		{"removed by a reorg", string(removedTxId) + "_1", 50, nil, false, 0, false},
+		{"other payload", string(txId) + "_0", 101, nil, true, 0, false},
+		{"invalid key", string(txId), 50, condPayment, false, 0, false},
+	} {
+
+		notification := subs.getConditionalPaymentNotification(100, test.chainHeight, test.key, test.expired, &hash_map.CommittedMapElement[*conditional_payment.ConditionalPayment]{Element: test.element})
+		if !test.notified {
+			assert.Nil(t, notification, test.name)
+			continue
+		}
+
+		assert.NotNil(t, notification, test.name)
+		assert.Equal(t, multisigPublicKeys, notification.multisigPublicKeys, test.name)
+		assert.Equal(t, &api_types.APISubscriptionNotificationConditionalPaymentExtra{txId, 1, 100, test.status}, notification.extra, test.name)
+		if test.element != nil {
+			assert.Equal(t, test.element, notification.element, test.name)
+		} else {
+			assert.Nil(t, notification.element, test.name)
+		}
+	}
+
+}
+
+func TestGetRemovedTxConditionalPaymentsNotifications(t *testing.T) {
+
+	multisigPublicKeys := [][]byte{addresses.GenerateNewPrivateKey().GeneratePublicKey(), addresses.GenerateNewPrivateKey().GeneratePublicKey()}
+
+	tx := &transaction.Transaction{
+		TransactionBaseInterface: &transaction_zether.TransactionZether{
+			Payloads: []*transaction_zether_payload.TransactionZetherPayload{
+				{PayloadScript: transaction_zether_payload_script.SCRIPT_TRANSFER},
+				{PayloadScript: transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT, Extra: &transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPayment{Deadline: 20, MultisigThreshold: 1, MultisigPublicKeys: multisigPublicKeys}},
+			},
+		},
+		Version: transaction_type.TX_ZETHER,
+		Bloom:   &transaction.TransactionBloom{Hash: cryptography.RandomHash()},
+	}
+
+	list := getRemovedTxConditionalPaymentsNotifications(tx, 50)
+	assert.Equal(t, 1, len(list))
+	assert.Equal(t, multisigPublicKeys, list[0].multisigPublicKeys)
+	assert.Equal(t, &api_types.APISubscriptionNotificationConditionalPaymentExtra{tx.Bloom.Hash, 1, 70, api_types.CONDITIONAL_PAYMENT_REMOVED}, list[0].extra, "the payment expired at the deadline after the block of the removed tx")
+
+	assert.Empty(t, getRemovedTxConditionalPaymentsNotifications(&transaction.Transaction{Version: transaction_type.TX_SIMPLE}, 50))
+}
@@ -2,6 +2,8 @@ package start
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"os"
@@ -10,6 +12,8 @@ import (
 	"pandora-pay/app"
 	"pandora-pay/blockchain"
 	"pandora-pay/blockchain/forging"
+	"pandora-pay/blockchain/forging/forging_signer"
+	"pandora-pay/blockchain/forging/forging_signer/forging_signer_server"
 	"pandora-pay/blockchain/genesis"
 	"pandora-pay/config"
 	"pandora-pay/config/arguments"
@@ -20,6 +24,7 @@ import (
 	"pandora-pay/helpers/debugging_pprof"
 	"pandora-pay/mempool"
 	"pandora-pay/network"
+	"pandora-pay/recovery"
 	"pandora-pay/settings"
 	"pandora-pay/store"
 	"pandora-pay/testnet"
@@ -90,9 +95,29 @@ func StartMainNow() (err error) {
 	if err = genesis.GenesisInit(app.Wallet.GetFirstAddressForDevnetGenesisAirdrop); err != nil {
 		return
 	}
+	if globals.Arguments["--import-snapshot"] != nil {
+		if globals.Arguments["--import-snapshot-hash"] == nil {
+			return errors.New("--import-snapshot requires the trusted block hash --import-snapshot-hash")
+		}
+		var trustedHash []byte
+		if trustedHash, err = hex.DecodeString(globals.Arguments["--import-snapshot-hash"].(string)); err != nil {
+			return
+		}
+		if err = app.Chain.ImportSnapshot(globals.Arguments["--import-snapshot"].(string), trustedHash); err != nil {
+			return
+		}
+	}
 	if err = app.Chain.InitializeChain(); err != nil {
 		return
 	}
+	if globals.Arguments["--export-snapshot"] != nil {
+		if err = app.Chain.ExportSnapshot(globals.Arguments["--export-snapshot"].(string)); err != nil {
+			return
+		}
+		//the snapshot is the state of the current height, the node exits before the chain changes
+		app.Close()
+		os.Exit(0)
+	}
 
 	if runtime.GOARCH != "wasm" && globals.Arguments["--balance-decryptor-disable-init"] == false {
 		tableSize := 0
@@ -124,6 +149,12 @@ func StartMainNow() (err error) {
 	app.TxsBuilder = txs_builder.TxsBuilderInit(app.Wallet, app.Mempool, app.TxsValidator)
 	globals.MainEvents.BroadcastEvent("main", "transactions builder initialized")
 
+	if config_forging.FORGING_SIGNER != "" {
+		signer := forging_signer.NewForgingSignerClient(config_forging.FORGING_SIGNER, config_forging.FORGING_SIGNER_AUTH)
+		app.TxsBuilder.SetForgingSigner(signer)
+		app.Forging.SetSigner(signer)
+	}
+
 	app.Forging.InitializeForging(app.TxsBuilder.CreateForgingTransactions, app.Chain.NextBlockCreatedCn, app.Chain.UpdateNewChainUpdate, app.Chain.ForgingSolutionCn)
 
 	if config_forging.FORGING_ENABLED {
@@ -132,6 +163,12 @@ func StartMainNow() (err error) {
 
 	app.Chain.InitForging()
 
+	if config_forging.FORGING_SIGNER_SERVER != "" {
+		if err = forging_signer_server.CreateForgingSignerServer(app.Wallet, config_forging.FORGING_SIGNER_SERVER, config_forging.FORGING_SIGNER_REWARD_ADDRESS); err != nil {
+			return
+		}
+	}
+
 	if globals.Arguments["--exit"] == true {
 		os.Exit(1)
 		return
@@ -147,6 +184,15 @@ func StartMainNow() (err error) {
 	}
 	globals.MainEvents.BroadcastEvent("main", "network initialized")
 
+	//the stored txs are verified at the current height right away, but the mempool worker inserts them only after it receives its first work from the chain
+	if config.CONSENSUS == config.CONSENSUS_TYPE_FULL {
+		recovery.SafeGo(func() {
+			if err := app.Mempool.ReloadStoredTxs(app.Chain.GetChainData().Height, context.Background()); err != nil {
+				gui.GUI.Error("Error reloading mempool txs", err)
+			}
+		})
+	}
+
 	gui.GUI.Log("Main Loop")
 	globals.MainEvents.BroadcastEvent("main", "initialized")
 
@@ -7,6 +7,7 @@ import (
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/advanced_buffers"
 	"pandora-pay/helpers/generics"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
 )
@@ -26,6 +27,7 @@ type HashMap[T HashMapElementSerializableInterface] struct {
 	DeletedEvent   func(key []byte) error
 	StoredEvent    func(key []byte, committed *CommittedMapElement[T], index uint64) error
 	Indexable      bool
+	StateTree      bool //changes are committed into the state tree
 }
 
 func (hashMap *HashMap[T]) deserialize(key, data []byte, index uint64) (T, error) {
@@ -364,6 +366,10 @@ func (hashMap *HashMap[T]) CommitChanges() (err error) {
 						hashMap.Tx.Delete(hashMap.name + ":listKeys:" + k)
 					}
 
+					if hashMap.StateTree {
+						state_tree.Update(hashMap.Tx, hashMap.name, []byte(k), nil)
+					}
+
 				}
 
 				if hashMap.DeletedEvent != nil {
@@ -423,6 +429,9 @@ func (hashMap *HashMap[T]) CommitChanges() (err error) {
 			if hashMap.Tx.IsWritable() {
 				//clone required because the element could change later on
 				hashMap.Tx.Put(hashMap.name+":map:"+k, committed.serialized)
+				if hashMap.StateTree {
+					state_tree.Update(hashMap.Tx, hashMap.name, []byte(k), committed.serialized)
+				}
 			}
 
 			committed.Status = "view"
@@ -489,6 +498,7 @@ func CreateNewHashMap[T HashMapElementSerializableInterface](tx store_db_interfa
 		nil,
 		nil,
 		indexable,
+		false,
 	}
 
 	//safe to Get because data will be converted into an integer
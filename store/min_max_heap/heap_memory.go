@@ -9,23 +9,35 @@ type HeapMemory struct {
 }
 
 func (self *HeapMemory) DeleteByKey(key []byte) error {
-	value, ok := self.dict[string(key)]
+	index, ok := self.dict[string(key)]
 	if !ok {
 		return errors.New("Key is not found")
 	}
 
-	if err := self.Delete(value); err != nil {
+	last, err := self.removeElement()
+	if err != nil {
 		return err
 	}
 
+	//the removed element was the last one
+	if index == self.GetSize() {
+		return nil
+	}
+
 	delete(self.dict, string(key))
-	return nil
+	if err = self.updateElement(index, last); err != nil {
+		return err
+	}
+
+	if index > 0 && self.compare(last.Score, self.array[self.parent(index)].Score) {
+		return self.upHeapify(index)
+	}
+	return self.downHeapify(index)
 }
 
 func (self *HeapMemory) Update(score float64, key []byte) error {
-	value, ok := self.dict[string(key)]
-	if ok {
-		if err := self.Delete(value); err != nil {
+	if _, ok := self.dict[string(key)]; ok {
+		if err := self.DeleteByKey(key); err != nil {
 			return err
 		}
 	}
@@ -159,3 +159,36 @@ func TestCreateMinHeapMemory(t *testing.T) {
 	assert.Nil(t, top)
 	assert.Nil(t, err)
 }
+
+func TestDeleteByKeyHeapMemory(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+
+	minHeap := NewMinMemoryHeap("")
+
+	scores := map[string]float64{}
+	for i := 0; i < 1000; i++ {
+		key := helpers.RandomBytes(cryptography.PublicKeySize)
+		scores[string(key)] = float64(rand.Uint32())
+		assert.Nil(t, minHeap.Insert(scores[string(key)], key))
+	}
+
+	//deleting the elements in random order, including the last ones, must keep the heap valid
+	for key := range scores {
+		if rand.Intn(2) == 0 {
+			assert.Nil(t, minHeap.DeleteByKey([]byte(key)))
+			delete(scores, key)
+		}
+	}
+	assert.Equal(t, minHeap.GetSize(), uint64(len(scores)))
+
+	prev := float64(0)
+	for range scores {
+		el, err := minHeap.RemoveTop()
+		assert.Nil(t, err)
+		assert.Equal(t, true, el.Score >= prev)
+		_, ok := scores[string(el.Key)]
+		assert.Equal(t, true, ok)
+		prev = el.Score
+	}
+	assert.Equal(t, uint64(0), minHeap.GetSize())
+}
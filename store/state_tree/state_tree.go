@@ -0,0 +1,104 @@
+package state_tree
+
+import (
+	"pandora-pay/cryptography"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+/**
+Sparse Merkle Tree of depth 256 committing all the hashmaps of the DataStorage
+Only the nodes different than the empty subtrees are stored
+*/
+
+const DEPTH = 256
+
+var defaultHashes [DEPTH + 1][]byte
+
+// LeafKey returns the position of an element of a hashmap in the tree
+func LeafKey(name string, key []byte) []byte {
+	return cryptography.SHA3(append([]byte(name+":"), key...))
+}
+
+// LeafHash returns the leaf of a serialized element. Missing elements are represented by the empty leaf
+func LeafHash(serialized []byte) []byte {
+	if serialized == nil {
+		return defaultHashes[0]
+	}
+	return cryptography.SHA3(serialized)
+}
+
+func hashNode(left, right []byte) []byte {
+	return cryptography.SHA3(append(append(make([]byte, 0, len(left)+len(right)), left...), right...))
+}
+
+func getBit(path []byte, index int) byte {
+	return (path[index/8] >> (7 - uint(index%8))) & 1
+}
+
+// nodePath keeps only the first bits of the path, the other bits are cleared
+func nodePath(path []byte, bits int) []byte {
+	out := make([]byte, len(path))
+	copy(out, path[:bits/8])
+	if bits%8 != 0 {
+		out[bits/8] = path[bits/8] & (0xFF << (8 - uint(bits%8)))
+	}
+	return out
+}
+
+func nodeKey(level int, path []byte) string {
+	return "stateTree:" + strconv.Itoa(level) + ":" + string(path)
+}
+
+func getNode(tx store_db_interface.StoreDBTransactionInterface, level int, path []byte) []byte {
+	if data := tx.Get(nodeKey(level, path)); data != nil {
+		return data
+	}
+	return defaultHashes[level]
+}
+
+func setNode(tx store_db_interface.StoreDBTransactionInterface, level int, path, hash []byte) {
+	if string(hash) == string(defaultHashes[level]) {
+		tx.Delete(nodeKey(level, path))
+	} else {
+		tx.Put(nodeKey(level, path), hash)
+	}
+}
+
+// Update sets the leaf of an element. Use a nil serialized to remove the element
+func Update(tx store_db_interface.StoreDBTransactionInterface, name string, key, serialized []byte) {
+
+	path := LeafKey(name, key)
+
+	current := LeafHash(serialized)
+	setNode(tx, 0, path, current)
+
+	for level := 0; level < DEPTH; level++ {
+
+		bits := DEPTH - level
+
+		siblingPath := nodePath(path, bits)
+		siblingPath[(bits-1)/8] ^= 1 << (7 - uint((bits-1)%8))
+		sibling := getNode(tx, level, siblingPath)
+
+		if getBit(path, bits-1) == 0 {
+			current = hashNode(current, sibling)
+		} else {
+			current = hashNode(sibling, current)
+		}
+
+		setNode(tx, level+1, nodePath(path, bits-1), current)
+	}
+}
+
+// GetRoot returns the root of the committed state
+func GetRoot(tx store_db_interface.StoreDBTransactionInterface) []byte {
+	return getNode(tx, DEPTH, make([]byte, cryptography.HashSize))
+}
+
+func init() {
+	defaultHashes[0] = make([]byte, cryptography.HashSize)
+	for i := 1; i <= DEPTH; i++ {
+		defaultHashes[i] = hashNode(defaultHashes[i-1], defaultHashes[i-1])
+	}
+}
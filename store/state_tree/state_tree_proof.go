@@ -0,0 +1,81 @@
+package state_tree
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+// StateProof proves the inclusion (or the exclusion) of an element in the state root
+type StateProof struct {
+	Bitmap   helpers.Base64   `json:"bitmap" msgpack:"bitmap"`     //bit i is set when the sibling of level i is not empty
+	Siblings []helpers.Base64 `json:"siblings" msgpack:"siblings"` //only the non empty siblings, starting from the leaf
+}
+
+func GetProof(tx store_db_interface.StoreDBTransactionInterface, name string, key []byte) *StateProof {
+
+	path := LeafKey(name, key)
+
+	proof := &StateProof{
+		make([]byte, DEPTH/8),
+		[]helpers.Base64{},
+	}
+
+	for level := 0; level < DEPTH; level++ {
+
+		bits := DEPTH - level
+
+		siblingPath := nodePath(path, bits)
+		siblingPath[(bits-1)/8] ^= 1 << (7 - uint((bits-1)%8))
+
+		if sibling := tx.Get(nodeKey(level, siblingPath)); sibling != nil {
+			proof.Bitmap[level/8] |= 1 << (7 - uint(level%8))
+			proof.Siblings = append(proof.Siblings, sibling)
+		}
+	}
+
+	return proof
+}
+
+// ComputeRoot returns the root obtained by the proof for the serialized element. Use a nil serialized to prove a missing element
+func (proof *StateProof) ComputeRoot(name string, key, serialized []byte) ([]byte, error) {
+
+	if len(proof.Bitmap) != DEPTH/8 {
+		return nil, errors.New("Invalid proof bitmap")
+	}
+
+	path := LeafKey(name, key)
+	current := LeafHash(serialized)
+
+	c := 0
+	for level := 0; level < DEPTH; level++ {
+
+		sibling := defaultHashes[level]
+		if (proof.Bitmap[level/8]>>(7-uint(level%8)))&1 == 1 {
+			if c >= len(proof.Siblings) || len(proof.Siblings[c]) != cryptography.HashSize {
+				return nil, errors.New("Invalid proof siblings")
+			}
+			sibling = proof.Siblings[c]
+			c += 1
+		}
+
+		if getBit(path, DEPTH-level-1) == 0 {
+			current = hashNode(current, sibling)
+		} else {
+			current = hashNode(sibling, current)
+		}
+	}
+
+	if c != len(proof.Siblings) {
+		return nil, errors.New("Invalid proof siblings")
+	}
+
+	return current, nil
+}
+
+func (proof *StateProof) Verify(root []byte, name string, key, serialized []byte) bool {
+	computed, err := proof.ComputeRoot(name, key, serialized)
+	return err == nil && bytes.Equal(computed, root)
+}
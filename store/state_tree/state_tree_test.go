@@ -0,0 +1,107 @@
+package state_tree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestStateProof(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.Nil(t, err)
+
+	keys := make([][]byte, 10)
+	values := make([][]byte, 10)
+
+	err = db.Update(func(tx store_db_interface.StoreDBTransactionInterface) error {
+
+		assert.Equal(t, GetRoot(tx), defaultHashes[DEPTH], "Empty root is invalid")
+
+		for i := range keys {
+			keys[i] = cryptography.RandomHash()
+			values[i] = helpers.RandomBytes(40)
+			Update(tx, "test", keys[i], values[i])
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = db.View(func(tx store_db_interface.StoreDBTransactionInterface) error {
+
+		root := GetRoot(tx)
+		for i := range keys {
+			proof := GetProof(tx, "test", keys[i])
+			assert.True(t, proof.Verify(root, "test", keys[i], values[i]), "Inclusion proof is invalid")
+			assert.False(t, proof.Verify(root, "test", keys[i], values[(i+1)%len(values)]), "Inclusion proof verified a different element")
+			assert.False(t, proof.Verify(root, "test2", keys[i], values[i]), "Inclusion proof verified a different hashmap")
+		}
+
+		missing := cryptography.RandomHash()
+		proof := GetProof(tx, "test", missing)
+		assert.True(t, proof.Verify(root, "test", missing, nil), "Exclusion proof is invalid")
+		return nil
+	})
+	assert.Nil(t, err)
+
+	//removing all the elements restores the empty root
+	err = db.Update(func(tx store_db_interface.StoreDBTransactionInterface) error {
+		for i := range keys {
+			Update(tx, "test", keys[i], nil)
+		}
+		assert.Equal(t, GetRoot(tx), defaultHashes[DEPTH], "Root is not empty")
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+// countingTx counts the store operations done by the state tree
+type countingTx struct {
+	store_db_interface.StoreDBTransactionInterface
+	gets, puts, deletes int
+}
+
+func (tx *countingTx) Get(key string) []byte {
+	tx.gets += 1
+	return tx.StoreDBTransactionInterface.Get(key)
+}
+
+func (tx *countingTx) Put(key string, value []byte) {
+	tx.puts += 1
+	tx.StoreDBTransactionInterface.Put(key, value)
+}
+
+func (tx *countingTx) Delete(key string) {
+	tx.deletes += 1
+	tx.StoreDBTransactionInterface.Delete(key)
+}
+
+// BenchmarkUpdate measures the cost of committing a leaf into a tree of 1000 elements, the store operations are reported per leaf
+func BenchmarkUpdate(b *testing.B) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.Nil(b, err)
+
+	err = db.Update(func(tx store_db_interface.StoreDBTransactionInterface) error {
+
+		for i := 0; i < 1000; i++ {
+			Update(tx, "test", cryptography.RandomHash(), helpers.RandomBytes(40))
+		}
+
+		counting := &countingTx{StoreDBTransactionInterface: tx}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			Update(counting, "test", cryptography.RandomHash(), helpers.RandomBytes(40))
+		}
+		b.StopTimer()
+
+		b.ReportMetric(float64(counting.gets)/float64(b.N), "gets/leaf")
+		b.ReportMetric(float64(counting.puts+counting.deletes)/float64(b.N), "writes/leaf")
+		return nil
+	})
+	assert.Nil(b, err)
+}
@@ -21,7 +21,7 @@ func createStore(name string, db store_db_interface.StoreDBInterface) (*Store, e
 	store := &Store{
 		Name:   name,
 		Opened: false,
-		DB:     db,
+		DB:     newStoreDBMetrics(name, db),
 	}
 
 	store.Opened = true
@@ -0,0 +1,51 @@
+package store_db_bolt
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"pandora-pay/store/store_db/store_db_interface"
+	"testing"
+)
+
+func TestIterateByPrefix(t *testing.T) {
+
+	//the store is created in the working directory
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(t.TempDir()))
+	defer os.Chdir(cwd)
+
+	db, err := CreateStoreDBBolt("test")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("a:2", []byte{2})
+		writer.Put("a:1", []byte{1})
+		writer.Put("ab:3", []byte{3})
+		writer.Put("b:1", []byte{4})
+		return nil
+	}))
+
+	assert.NoError(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+
+		keys, values := []string{}, [][]byte{}
+		assert.NoError(t, reader.(store_db_interface.StoreDBTransactionIterableInterface).IterateByPrefix("a:", func(key string, value []byte) error {
+			keys = append(keys, key)
+			values = append(values, value)
+			return nil
+		}))
+		assert.Equal(t, []string{"a:1", "a:2"}, keys)
+		assert.Equal(t, [][]byte{{1}, {2}}, values)
+
+		count := 0
+		assert.EqualError(t, reader.(store_db_interface.StoreDBTransactionIterableInterface).IterateByPrefix("a", func(key string, value []byte) error {
+			count++
+			return errors.New("stop")
+		}), "stop")
+		assert.Equal(t, 1, count, "the iteration stops at the first error")
+
+		return nil
+	}))
+}
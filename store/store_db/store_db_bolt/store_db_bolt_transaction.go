@@ -1,6 +1,7 @@
 package store_db_bolt
 
 import (
+	"bytes"
 	bolt "go.etcd.io/bbolt"
 	"pandora-pay/helpers"
 	"pandora-pay/store/store_db/store_db_interface"
@@ -23,8 +24,8 @@ func (tx *StoreDBBoltTransaction) Put(key string, value []byte) {
 	}
 }
 
-//bolt requires the data to be cloned in order to become persistent
-//github issue see here https://github.com/etcd-io/bbolt/issues/298
+// bolt requires the data to be cloned in order to become persistent
+// github issue see here https://github.com/etcd-io/bbolt/issues/298
 func (tx *StoreDBBoltTransaction) Get(key string) []byte {
 	return helpers.CloneBytes(tx.bucket.Get([]byte(key)))
 }
@@ -36,3 +37,13 @@ func (tx *StoreDBBoltTransaction) Exists(key string) bool {
 func (tx *StoreDBBoltTransaction) Delete(key string) {
 	tx.bucket.Delete([]byte(key))
 }
+
+func (tx *StoreDBBoltTransaction) IterateByPrefix(prefix string, callback func(key string, value []byte) error) error {
+	c := tx.bucket.Cursor()
+	for k, v := c.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, v = c.Next() {
+		if err := callback(string(k), helpers.CloneBytes(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
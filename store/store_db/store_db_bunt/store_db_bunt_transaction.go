@@ -3,6 +3,7 @@ package store_db_bunt
 import (
 	buntdb "github.com/tidwall/buntdb"
 	"pandora-pay/store/store_db/store_db_interface"
+	"strings"
 )
 
 type StoreDBBuntTransaction struct {
@@ -45,3 +46,18 @@ func (tx *StoreDBBuntTransaction) Delete(key string) {
 		panic(err)
 	}
 }
+
+func (tx *StoreDBBuntTransaction) IterateByPrefix(prefix string, callback func(key string, value []byte) error) (err error) {
+	if err2 := tx.buntTx.AscendGreaterOrEqual("", prefix, func(key, value string) bool {
+		if !strings.HasPrefix(key, prefix) {
+			return false
+		}
+		if err = callback(key, []byte(value)); err != nil {
+			return false
+		}
+		return true
+	}); err2 != nil {
+		return err2
+	}
+	return
+}
@@ -7,3 +7,9 @@ type StoreDBTransactionInterface interface {
 	Delete(key string)
 	IsWritable() bool
 }
+
+// StoreDBTransactionIterableInterface is implemented only by the stores which can iterate their keys
+type StoreDBTransactionIterableInterface interface {
+	StoreDBTransactionInterface
+	IterateByPrefix(prefix string, callback func(key string, value []byte) error) error
+}
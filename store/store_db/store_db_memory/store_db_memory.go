@@ -38,15 +38,11 @@ func (store *StoreDBMemory) Update(callback func(dbTx store_db_interface.StoreDB
 		write: true,
 	}
 
-	err := callback(tx)
-
-	if err == nil {
-		if err = tx.writeTx(); err != nil {
-			return err
-		}
+	if err := callback(tx); err != nil {
+		return err
 	}
 
-	return nil
+	return tx.writeTx()
 }
 
 func CreateStoreDBMemory(name string) (*StoreDBMemory, error) {
@@ -0,0 +1,47 @@
+package store_db_memory
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/store/store_db/store_db_interface"
+	"testing"
+)
+
+func TestIterateByPrefix(t *testing.T) {
+
+	db, err := CreateStoreDBMemory("test")
+	assert.NoError(t, err)
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("a:2", []byte{2})
+		writer.Put("a:1", []byte{1})
+		writer.Put("ab:3", []byte{3})
+		writer.Put("b:1", []byte{4})
+		return nil
+	}))
+
+	assert.NoError(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+
+		//the changes of the transaction are visible
+		writer.Put("a:3", []byte{5})
+		writer.Delete("a:2")
+
+		keys, values := []string{}, [][]byte{}
+		assert.NoError(t, writer.(store_db_interface.StoreDBTransactionIterableInterface).IterateByPrefix("a:", func(key string, value []byte) error {
+			keys = append(keys, key)
+			values = append(values, value)
+			return nil
+		}))
+		assert.Equal(t, []string{"a:1", "a:3"}, keys)
+		assert.Equal(t, [][]byte{{1}, {5}}, values)
+
+		count := 0
+		assert.EqualError(t, writer.(store_db_interface.StoreDBTransactionIterableInterface).IterateByPrefix("a", func(key string, value []byte) error {
+			count++
+			return errors.New("stop")
+		}), "stop")
+		assert.Equal(t, 1, count, "the iteration stops at the first error")
+
+		return nil
+	}))
+}
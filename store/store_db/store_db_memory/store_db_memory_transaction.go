@@ -5,6 +5,8 @@ import (
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/store/store_db/store_db_interface"
+	"sort"
+	"strings"
 )
 
 type StoreDBMemoryTransactionData struct {
@@ -78,3 +80,29 @@ func (tx *StoreDBMemoryTransaction) writeTx() error {
 
 	return nil
 }
+
+func (tx *StoreDBMemoryTransaction) IterateByPrefix(prefix string, callback func(key string, value []byte) error) error {
+
+	keys := make([]string, 0)
+	for key := range tx.store {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	tx.local.Range(func(key string, data *StoreDBMemoryTransactionData) bool {
+		if data.operation == "put" && tx.store[key] == nil && strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if value := tx.Get(key); value != nil {
+			if err := callback(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
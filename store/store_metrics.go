@@ -0,0 +1,29 @@
+package store
+
+import (
+	"pandora-pay/metrics"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strings"
+	"time"
+)
+
+// storeDBMetrics measures the duration of the commits of a store
+type storeDBMetrics struct {
+	store_db_interface.StoreDBInterface
+	commitDuration *metrics.Histogram
+}
+
+func (db *storeDBMetrics) Update(callback func(dbTx store_db_interface.StoreDBTransactionInterface) error) error {
+	start := time.Now()
+	defer func() {
+		db.commitDuration.Observe(time.Since(start).Seconds())
+	}()
+	return db.StoreDBInterface.Update(callback)
+}
+
+func newStoreDBMetrics(name string, db store_db_interface.StoreDBInterface) *storeDBMetrics {
+	return &storeDBMetrics{
+		db,
+		metrics.NewHistogram("pandora_store_commit_seconds", "Duration of the store commits", `store="`+strings.TrimPrefix(name, "/")+`"`, metrics.DefaultBuckets),
+	}
+}
@@ -7,7 +7,11 @@ import (
 	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/blockchain/data_storage/plain_accounts"
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account/asset_fee_liquidity"
+	"pandora-pay/blockchain/forging/forging_signer"
 	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/config/config_fees"
+	"pandora-pay/helpers/generics"
 	"pandora-pay/mempool"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/store"
@@ -20,10 +24,13 @@ import (
 )
 
 type TxsBuilder struct {
-	wallet       *wallet.Wallet
-	txsValidator *txs_validator.TxsValidator
-	mempool      *mempool.Mempool
-	lock         *sync.Mutex
+	wallet           *wallet.Wallet
+	txsValidator     *txs_validator.TxsValidator
+	mempool          *mempool.Mempool
+	createdZetherTxs map[string]*TxBuilderCreateZetherTxData //data of the propagated txs, required to replace them
+	forgingReward    ForgingRewardHandler                    //nil when the staking rewards are sent to the forger
+	forgingSigner    *forging_signer.ForgingSignerClient     //nil when the staking keys are kept by the wallet
+	lock             *sync.Mutex
 }
 
 func (builder *TxsBuilder) getNonce(nonce uint64, publicKey []byte, accNonce uint64) uint64 {
@@ -33,6 +40,31 @@ func (builder *TxsBuilder) getNonce(nonce uint64, publicKey []byte, accNonce uin
 	return builder.mempool.GetNonce(publicKey, accNonce)
 }
 
+// autoFee replaces the floor fee per byte of an automatic fee with the estimated one. Fees paid in assets are converted using the asset fee liquidity
+func (builder *TxsBuilder) autoFee(fee *wizard.WizardTransactionFee, minFeePerByte uint64, liquidity *asset_fee_liquidity.AssetFeeLiquidity) (*wizard.WizardTransactionFee, error) {
+
+	if fee.Fixed > 0 || fee.PerByte > 0 || !fee.PerByteAuto {
+		return fee, nil
+	}
+
+	feePerByte := generics.Max(builder.mempool.EstimateFeePerByte(1), minFeePerByte)
+
+	if liquidity != nil {
+		if liquidity.Rate == 0 {
+			return fee, nil
+		}
+		var err error
+		if feePerByte, err = liquidity.ConvertNativeFee(feePerByte); err != nil {
+			return nil, err
+		}
+	}
+
+	fee = fee.Clone()
+	fee.PerByte = feePerByte
+	fee.PerByteExtraSpace = config_fees.FEE_PER_BYTE_EXTRA_SPACE
+	return fee, nil
+}
+
 func (builder *TxsBuilder) convertFloatAmounts(amounts []float64, ast *asset.Asset) ([]uint64, error) {
 
 	var err error
@@ -89,10 +121,15 @@ func (builder *TxsBuilder) CreateSimpleTx(txData *TxBuilderCreateSimpleTx, propa
 
 	statusCallback("Wallet Addresses Found")
 
+	fee, err := builder.autoFee(txData.Fee, config_fees.FEE_PER_BYTE, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	transfer := &wizard.WizardTxSimpleTransfer{
 		txData.Extra,
 		txData.Data,
-		txData.Fee,
+		fee,
 		txData.Nonce,
 		nil,
 	}
@@ -144,6 +181,9 @@ func TxsBuilderInit(wallet *wallet.Wallet, mempool *mempool.Mempool, txsValidato
 		wallet,
 		txsValidator,
 		mempool,
+		make(map[string]*TxBuilderCreateZetherTxData),
+		nil,
+		nil,
 		&sync.Mutex{},
 	}
 
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"pandora-pay/addresses"
 	"pandora-pay/blockchain/data_storage/assets"
 	"pandora-pay/blockchain/data_storage/assets/asset"
@@ -24,6 +25,7 @@ import (
 	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
 	"pandora-pay/txs_builder/wizard"
+	"pandora-pay/wallet/wallet_address"
 )
 
 func (builder *TxsBuilder) showWarningIfNotSyncCLI() {
@@ -276,14 +278,14 @@ func (builder *TxsBuilder) initCLI() {
 		gui.GUI.OutputWrite(fmt.Sprintf("Tx created: %s %s", base64.StdEncoding.EncodeToString(tx.Bloom.Hash), cmd))
 
 		assetId := tx.TransactionBaseInterface.(*transaction_zether.TransactionZether).Payloads[0].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetCreate).GetAssetId(tx.Bloom.Hash, 0)
-		gui.GUI.OutputWrite(fmt.Sprintf("Asset Id: %s", base64.StdEncoding.EncodeToString(assetId), cmd))
+		gui.GUI.OutputWrite(fmt.Sprintf("Asset Id: %s", base64.StdEncoding.EncodeToString(assetId)))
 
 		if updatePrivKey != nil || supplyPrivKey != nil {
 
 			if filename := gui.GUI.OutputReadFilename("Path to export Asset Private Keys", "keys", true); len(filename) > 0 {
 				if err = files.WriteFile(filename,
 					fmt.Sprintf("Asset ID: %s", base64.StdEncoding.EncodeToString(assetId)),
-					fmt.Sprintf("Asset name: %s", extra.Asset.Name, extra.Asset.Ticker),
+					fmt.Sprintf("Asset name: %s %s", extra.Asset.Name, extra.Asset.Ticker),
 					fmt.Sprintf("Supply Private Key: %s", base64.StdEncoding.EncodeToString(supplyPrivKey.Key)),
 					fmt.Sprintf("Update Private Key: %s", base64.StdEncoding.EncodeToString(updatePrivKey.Key)),
 				); err != nil {
@@ -345,6 +347,178 @@ func (builder *TxsBuilder) initCLI() {
 		return
 	}
 
+	cliPrivateAssetSupplyDecrease := func(cmd string, ctx context.Context) (err error) {
+		builder.showWarningIfNotSyncCLI()
+
+		extra := &wizard.WizardZetherPayloadExtraAssetSupplyDecrease{}
+		txData := &TxBuilderCreateZetherTxData{
+			Payloads: []*TxBuilderCreateZetherTxPayload{{
+				Extra: extra,
+			}},
+		}
+
+		if _, txData.Payloads[0].Sender, _, err = builder.wallet.CliSelectAddress("Select Address which will decrease the supply of asset", ctx); err != nil {
+			return
+		}
+
+		txData.Payloads[0].Asset = builder.readAsset("Asset", false)
+
+		extra.AssetSupplyPrivateKey = gui.GUI.OutputReadBytes("Asset Supply Decrease Private Key", func(value []byte) bool {
+			return len(value) == cryptography.PrivateKeySize
+		})
+
+		if txData.Payloads[0].Burn, err = builder.readAmount(txData.Payloads[0].Asset, "Amount to burn"); err != nil {
+			return
+		}
+
+		if _, txData.Payloads[0].Recipient, txData.Payloads[0].Amount, err = builder.readAddressOptional("Transfer Address", txData.Payloads[0].Asset, true); err != nil {
+			return
+		}
+
+		txData.Payloads[0].RingConfiguration = builder.readZetherRingConfiguration()
+		txData.Payloads[0].Data = builder.readData()
+		txData.Payloads[0].Fee = builder.readZetherFee(txData.Payloads[0].Asset)
+		propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+
+		tx, err := builder.CreateZetherTx(txData, nil, propagate, true, true, false, ctx, func(status string) {
+			gui.GUI.OutputWrite(status)
+		})
+		if err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Tx created: %s %s", base64.StdEncoding.EncodeToString(tx.Bloom.Hash), cmd))
+
+		return
+	}
+
+	cliPrivateAssetUpdate := func(cmd string, ctx context.Context) (err error) {
+		builder.showWarningIfNotSyncCLI()
+
+		extra := &wizard.WizardZetherPayloadExtraAssetUpdate{}
+		txData := &TxBuilderCreateZetherTxData{
+			Payloads: []*TxBuilderCreateZetherTxPayload{{
+				Extra: extra,
+				Asset: config_coins.NATIVE_ASSET_FULL,
+			}},
+		}
+
+		if _, txData.Payloads[0].Sender, _, err = builder.wallet.CliSelectAddress("Select Address which will update the asset", ctx); err != nil {
+			return
+		}
+
+		extra.AssetId = builder.readAsset("Asset", false)
+
+		extra.AssetUpdatePrivateKey = gui.GUI.OutputReadBytes("Asset Update Private Key", func(value []byte) bool {
+			return len(value) == cryptography.PrivateKeySize
+		})
+
+		extra.UpdateType = transaction_zether_payload_extra.AssetUpdateType(gui.GUI.OutputReadUint64("Update Type. 0 - Pause, 1 - Unpause, 2 - Freeze Supply, 3 - Change Update Public Key, 4 - Change Supply Public Key, 5 - Update Info", false, 0, func(value uint64) bool {
+			return value <= uint64(transaction_zether_payload_extra.ASSET_UPDATE_INFO)
+		}))
+
+		switch extra.UpdateType {
+		case transaction_zether_payload_extra.ASSET_UPDATE_UPDATE_PUBLIC_KEY, transaction_zether_payload_extra.ASSET_UPDATE_SUPPLY_PUBLIC_KEY:
+			extra.NewPublicKey = gui.GUI.OutputReadBytes("New Public Key", func(value []byte) bool {
+				return len(value) == cryptography.PublicKeySize
+			})
+		case transaction_zether_payload_extra.ASSET_UPDATE_INFO:
+			extra.Name = gui.GUI.OutputReadString("Asset Name")
+			extra.Description = gui.GUI.OutputReadString("Asset Description. Leave empty for none")
+			extra.Data = []byte(gui.GUI.OutputReadString("Asset Data. Leave empty for none"))
+		}
+
+		if _, txData.Payloads[0].Recipient, txData.Payloads[0].Amount, err = builder.readAddressOptional("Transfer Address", config_coins.NATIVE_ASSET_FULL, true); err != nil {
+			return
+		}
+
+		txData.Payloads[0].RingConfiguration = builder.readZetherRingConfiguration()
+		txData.Payloads[0].Data = builder.readData()
+		txData.Payloads[0].Fee = builder.readZetherFee(config_coins.NATIVE_ASSET_FULL)
+		propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+
+		tx, err := builder.CreateZetherTx(txData, nil, propagate, true, true, false, ctx, func(status string) {
+			gui.GUI.OutputWrite(status)
+		})
+		if err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Tx created: %s %s", base64.StdEncoding.EncodeToString(tx.Bloom.Hash), cmd))
+
+		return
+	}
+
+	cliPrivateRegistrationUpdate := func(cmd string, ctx context.Context) (err error) {
+		builder.showWarningIfNotSyncCLI()
+
+		extra := &wizard.WizardZetherPayloadExtraRegistrationUpdate{}
+		txData := &TxBuilderCreateZetherTxData{
+			Payloads: []*TxBuilderCreateZetherTxPayload{{
+				Extra: extra,
+				Asset: config_coins.NATIVE_ASSET_FULL,
+			}},
+		}
+
+		var addr *wallet_address.WalletAddress
+		if addr, txData.Payloads[0].Sender, _, err = builder.wallet.CliSelectAddress("Select Address which will update its registration", ctx); err != nil {
+			return
+		}
+		if addr.PrivateKey == nil {
+			return errors.New("Private Key is missing")
+		}
+
+		extra.UpdateType = transaction_zether_payload_extra.RegistrationUpdateType(gui.GUI.OutputReadUint64("Update Type. 0 - Stake, 1 - Unstake, 2 - Change Spend Public Key, 3 - Remove Spend Public Key", false, 0, func(value uint64) bool {
+			return value <= uint64(transaction_zether_payload_extra.REGISTRATION_UPDATE_REMOVE_SPEND_PUBLIC_KEY)
+		}))
+
+		extra.PrivateKey = addr.PrivateKey.Key
+		if addr.SpendPrivateKey != nil {
+			extra.SpendPrivateKey = addr.SpendPrivateKey.Key
+		}
+
+		staked, newSpendPrivateKey := addr.Staked, addr.SpendPrivateKey
+		switch extra.UpdateType {
+		case transaction_zether_payload_extra.REGISTRATION_UPDATE_STAKE:
+			staked = true
+		case transaction_zether_payload_extra.REGISTRATION_UPDATE_UNSTAKE:
+			staked = false
+		case transaction_zether_payload_extra.REGISTRATION_UPDATE_SPEND_PUBLIC_KEY:
+			newSpendPrivateKey = addresses.GenerateNewPrivateKey()
+			extra.NewSpendPublicKey = newSpendPrivateKey.GeneratePublicKey()
+		case transaction_zether_payload_extra.REGISTRATION_UPDATE_REMOVE_SPEND_PUBLIC_KEY:
+			newSpendPrivateKey = nil
+		}
+
+		if _, txData.Payloads[0].Recipient, txData.Payloads[0].Amount, err = builder.readAddressOptional("Transfer Address", config_coins.NATIVE_ASSET_FULL, true); err != nil {
+			return
+		}
+
+		txData.Payloads[0].RingConfiguration = builder.readZetherRingConfiguration()
+		txData.Payloads[0].Data = builder.readData()
+		txData.Payloads[0].Fee = builder.readZetherFee(config_coins.NATIVE_ASSET_FULL)
+		propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+
+		tx, err := builder.CreateZetherTx(txData, nil, propagate, true, true, false, ctx, func(status string) {
+			gui.GUI.OutputWrite(status)
+		})
+		if err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Tx created: %s %s", base64.StdEncoding.EncodeToString(tx.Bloom.Hash), cmd))
+
+		if newSpendPrivateKey != nil && newSpendPrivateKey != addr.SpendPrivateKey {
+			gui.GUI.OutputWrite("New Spend Private Key. Keep it until the transaction is included", hex.EncodeToString(newSpendPrivateKey.Key))
+		}
+
+		if propagate {
+			builder.updateWalletRegistrationOnInclusion(tx.Bloom.Hash, addr.PublicKey, staked, newSpendPrivateKey)
+		}
+
+		return
+	}
+
 	cliPrivatePlainAccountFund := func(cmd string, ctx context.Context) (err error) {
 		builder.showWarningIfNotSyncCLI()
 
@@ -526,6 +700,47 @@ func (builder *TxsBuilder) initCLI() {
 		return
 	}
 
+	cliClaim := func(cmd string, ctx context.Context) (err error) {
+
+		builder.showWarningIfNotSyncCLI()
+
+		txExtra := &wizard.WizardTxSimpleExtraClaim{}
+		txData := &TxBuilderCreateSimpleTx{
+			Extra:      txExtra,
+			FeeVersion: true,
+		}
+
+		if _, txData.Sender, _, err = builder.wallet.CliSelectAddress("Select Plain Account Address to Publicly Claim the Unclaimed funds", ctx); err != nil {
+			return
+		}
+
+		var addr *addresses.Address
+		if addr, err = builder.readAddress("Receiver address", false); err != nil {
+			return
+		}
+		txExtra.ReceiverPublicKey = addr.PublicKey
+
+		if txExtra.Amount, err = builder.readAmount(config_coins.NATIVE_ASSET_FULL, "Amount"); err != nil {
+			return
+		}
+
+		txData.Nonce = gui.GUI.OutputReadUint64("Nonce. Leave empty for automatically detection", true, 0, nil)
+		txData.Data = builder.readData()
+		txData.Fee = builder.readFee(config_coins.NATIVE_ASSET_FULL)
+
+		propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+
+		tx, err := builder.CreateSimpleTx(txData, propagate, true, true, false, ctx, func(status string) {
+			gui.GUI.OutputWrite(status)
+		})
+		if err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Tx created: %s %s", base64.StdEncoding.EncodeToString(tx.Bloom.Hash), cmd))
+		return
+	}
+
 	cliResolutionConditionalPayment := func(cmd string, ctx context.Context) (err error) {
 
 		builder.showWarningIfNotSyncCLI()
@@ -540,46 +755,61 @@ func (builder *TxsBuilder) initCLI() {
 			FeeVersion: true,
 		}
 
-		txExtra.TxId = gui.GUI.OutputReadBytes("Provide TxId", func(val []byte) bool {
-			return len(val) == cryptography.HashSize
-		})
+		if filename := gui.GUI.OutputReadFilename("Path to partial resolution file. Leave empty to type the signatures", "resolution", true); len(filename) > 0 {
 
-		txExtra.PayloadIndex = byte(gui.GUI.OutputReadInt("Payload index", false, 0, func(val int) bool {
-			return val >= 0 && val < 255
-		}))
+			var data []byte
+			if data, err = os.ReadFile(filename); err != nil {
+				return
+			}
+			if err = json.Unmarshal(data, txExtra); err != nil {
+				return
+			}
 
-		txExtra.Resolution = gui.GUI.OutputReadBool("Resolution.  Use y/n for voting", false, false)
+			gui.GUI.OutputWrite(fmt.Sprintf("Partial resolution loaded with %d signatures", len(txExtra.Signatures)))
 
-		i := 0
-		for {
-			key := gui.GUI.OutputReadBytes(fmt.Sprintf("Public Key %d. Use enter to continue", i), func(key []byte) bool {
-				return len(key) == cryptography.PublicKeySize || len(key) == 0
-			})
-			if len(key) == 0 {
-				break
-			}
+		} else {
 
-			signature := gui.GUI.OutputReadBytes("Signature", func(sign []byte) bool {
-				return len(sign) == cryptography.SignatureSize
+			txExtra.TxId = gui.GUI.OutputReadBytes("Provide TxId", func(val []byte) bool {
+				return len(val) == cryptography.HashSize
 			})
 
-			extra := &transaction_simple_extra.TransactionSimpleExtraResolutionConditionalPayment{nil,
-				txExtra.TxId,
-				txExtra.PayloadIndex,
-				txExtra.Resolution,
-				[][]byte{key},
-				[][]byte{signature},
-			}
+			txExtra.PayloadIndex = byte(gui.GUI.OutputReadInt("Payload index", false, 0, func(val int) bool {
+				return val >= 0 && val < 255
+			}))
 
-			if !extra.VerifySignature() {
-				gui.GUI.Error("provided resolution signature is not valid")
-				break
-			}
+			txExtra.Resolution = gui.GUI.OutputReadBool("Resolution.  Use y/n for voting", false, false)
+
+			i := 0
+			for {
+				key := gui.GUI.OutputReadBytes(fmt.Sprintf("Public Key %d. Use enter to continue", i), func(key []byte) bool {
+					return len(key) == cryptography.PublicKeySize || len(key) == 0
+				})
+				if len(key) == 0 {
+					break
+				}
 
-			txExtra.MultisigPublicKeys = append(txExtra.MultisigPublicKeys, key)
-			txExtra.Signatures = append(txExtra.Signatures, signature)
+				signature := gui.GUI.OutputReadBytes("Signature", func(sign []byte) bool {
+					return len(sign) == cryptography.SignatureSize
+				})
 
-			i++
+				extra := &transaction_simple_extra.TransactionSimpleExtraResolutionConditionalPayment{nil,
+					txExtra.TxId,
+					txExtra.PayloadIndex,
+					txExtra.Resolution,
+					[][]byte{key},
+					[][]byte{signature},
+				}
+
+				if !extra.VerifySignature() {
+					gui.GUI.Error("provided resolution signature is not valid")
+					break
+				}
+
+				txExtra.MultisigPublicKeys = append(txExtra.MultisigPublicKeys, key)
+				txExtra.Signatures = append(txExtra.Signatures, signature)
+
+				i++
+			}
 		}
 
 		txData.Nonce = 0
@@ -598,12 +828,41 @@ func (builder *TxsBuilder) initCLI() {
 		return
 	}
 
+	cliReplaceTx := func(cancel bool) func(cmd string, ctx context.Context) error {
+		return func(cmd string, ctx context.Context) (err error) {
+
+			builder.showWarningIfNotSyncCLI()
+
+			hash := gui.GUI.OutputReadBytes("Pending Transaction Hash", func(val []byte) bool {
+				return len(val) == cryptography.HashSize
+			})
+
+			propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+
+			tx, err := builder.ReplaceTx(hash, cancel, propagate, true, true, ctx, func(status string) {
+				gui.GUI.OutputWrite(status)
+			})
+			if err != nil {
+				return
+			}
+
+			gui.GUI.OutputWrite(fmt.Sprintf("Tx created: %s %s", base64.StdEncoding.EncodeToString(tx.Bloom.Hash), cmd))
+			return
+		}
+	}
+
 	gui.GUI.CommandDefineCallback("Private Transfer", cliPrivateTransfer, true)
 	gui.GUI.CommandDefineCallback("Private Asset Create", cliPrivateAssetCreate, true)
 	gui.GUI.CommandDefineCallback("Private Asset Supply Increase", cliPrivateAssetSupplyIncrease, true)
+	gui.GUI.CommandDefineCallback("Private Asset Supply Decrease", cliPrivateAssetSupplyDecrease, true)
+	gui.GUI.CommandDefineCallback("Private Asset Update", cliPrivateAssetUpdate, true)
+	gui.GUI.CommandDefineCallback("Private Registration Update", cliPrivateRegistrationUpdate, true)
 	gui.GUI.CommandDefineCallback("Private Plain Account Fund", cliPrivatePlainAccountFund, true)
 	gui.GUI.CommandDefineCallback("Private Conditional Payment", cliPrivateConditionalPayment, true)
 	gui.GUI.CommandDefineCallback("Public Update Asset Fee Liquidity", cliUpdateAssetFeeLiquidity, true)
+	gui.GUI.CommandDefineCallback("Public Claim", cliClaim, true)
 	gui.GUI.CommandDefineCallback("Public Resolution Conditional Payment", cliResolutionConditionalPayment, true)
+	gui.GUI.CommandDefineCallback("Bump Fee Pending Transaction", cliReplaceTx(false), true)
+	gui.GUI.CommandDefineCallback("Cancel Pending Transaction", cliReplaceTx(true), true)
 
 }
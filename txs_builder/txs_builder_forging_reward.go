@@ -0,0 +1,17 @@
+package txs_builder
+
+import (
+	"pandora-pay/blockchain/transactions/transaction"
+)
+
+// ForgingRewardHandler allows redirecting the staking reward of a forged block to a different address
+type ForgingRewardHandler interface {
+	GetForgingRewardRecipient(forgerPublicKey []byte) string //empty string to keep the forger as recipient
+	ForgingRewardCreated(blockHeight uint64, forgerPublicKey []byte, stakingAmount, reward uint64, tx *transaction.Transaction)
+}
+
+func (builder *TxsBuilder) SetForgingRewardHandler(handler ForgingRewardHandler) {
+	builder.lock.Lock()
+	defer builder.lock.Unlock()
+	builder.forgingReward = handler
+}
@@ -0,0 +1,21 @@
+package txs_builder
+
+import (
+	"pandora-pay/blockchain/forging/forging_signer"
+)
+
+// SetForgingSigner signs the staking transactions of the forgers missing from the wallet using a remote signer
+func (builder *TxsBuilder) SetForgingSigner(signer *forging_signer.ForgingSignerClient) {
+	builder.lock.Lock()
+	defer builder.lock.Unlock()
+	builder.forgingSigner = signer
+}
+
+// isSignedByForgingSigner returns true when the private key of the forger is kept by the forging signer
+func (builder *TxsBuilder) isSignedByForgingSigner(forgerPublicKey []byte) bool {
+	if builder.forgingSigner == nil {
+		return false
+	}
+	addr := builder.wallet.GetWalletAddressByPublicKey(forgerPublicKey, true)
+	return addr == nil || addr.PrivateKey == nil
+}
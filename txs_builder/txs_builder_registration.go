@@ -0,0 +1,49 @@
+package txs_builder
+
+import (
+	"encoding/base64"
+	"pandora-pay/addresses"
+	"pandora-pay/gui"
+	"pandora-pay/recovery"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"time"
+)
+
+// updateWalletRegistrationOnInclusion updates the staked status and the spend private key of the address only once the registration update tx is included.
+// In case the tx is dropped, the wallet keeps the registration expected by the chain
+func (builder *TxsBuilder) updateWalletRegistrationOnInclusion(txHash, publicKey []byte, staked bool, spendPrivateKey *addresses.PrivateKey) {
+	recovery.SafeGo(func() {
+
+		txHashStr := string(txHash)
+
+		for {
+
+			time.Sleep(10 * time.Second)
+
+			//the mempool is checked first as the tx is removed from it once included
+			pending := builder.mempool.Txs.Exists(txHashStr)
+
+			var included bool
+			if err := store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+				included = reader.Exists("txBlock:" + txHashStr)
+				return nil
+			}); err != nil {
+				gui.GUI.Error("Error checking the registration update tx", err)
+				return
+			}
+
+			if included {
+				if _, err := builder.wallet.UpdateAddressRegistrationByPublicKey(publicKey, staked, spendPrivateKey, true); err != nil {
+					gui.GUI.Error("Error updating the wallet registration", err)
+				}
+				return
+			}
+
+			if !pending {
+				gui.GUI.Warning("Registration update tx was not included. The wallet keeps the previous registration", base64.StdEncoding.EncodeToString(txHash))
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package txs_builder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_data"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_extra"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/config/config_fees"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"pandora-pay/txs_builder/wizard"
+	"pandora-pay/wallet/wallet_address"
+)
+
+const replaceTxAttempts = 3
+
+func (txData *TxBuilderCreateZetherTxData) clone() *TxBuilderCreateZetherTxData {
+	out := &TxBuilderCreateZetherTxData{make([]*TxBuilderCreateZetherTxPayload, len(txData.Payloads))}
+	for t, payload := range txData.Payloads {
+		cloned := *payload
+		out.Payloads[t] = &cloned
+	}
+	return out
+}
+
+// storeCreatedZetherTx keeps the data of a propagated tx and forgets the txs that are no longer in mempool
+func (builder *TxsBuilder) storeCreatedZetherTx(hash string, txData *TxBuilderCreateZetherTxData) {
+	for key := range builder.createdZetherTxs {
+		if !builder.mempool.Txs.Exists(key) {
+			delete(builder.createdZetherTxs, key)
+		}
+	}
+	builder.createdZetherTxs[hash] = txData
+}
+
+// replaceFeePerByte returns a fee per byte higher than the one paid by the replaced tx
+func (builder *TxsBuilder) replaceFeePerByte(feePerByte uint64) uint64 {
+	return generics.Max(builder.mempool.EstimateFeePerByte(1), feePerByte+feePerByte/10+1)
+}
+
+// ReplaceTx creates a new transaction paying a higher fee that replaces a pending transaction of the wallet. In case cancel is true, the new transaction only spends the fee
+func (builder *TxsBuilder) ReplaceTx(hash []byte, cancel, propagateTx, awaitAnswer, awaitBroadcast bool, ctx context.Context, statusCallback func(string)) (*transaction.Transaction, error) {
+
+	replaced := builder.mempool.Txs.Get(string(hash))
+	if replaced == nil {
+		return nil, errors.New("Transaction was not found in mempool")
+	}
+
+	replacedFee, err := replaced.Tx.GetAllFee()
+	if err != nil {
+		return nil, err
+	}
+
+	feePerByte := builder.replaceFeePerByte(replaced.FeePerByte)
+
+	var tx *transaction.Transaction
+	var created *TxBuilderCreateZetherTxData
+	for i := 0; i < replaceTxAttempts; i++ {
+
+		switch replaced.Tx.Version {
+		case transaction_type.TX_SIMPLE:
+			tx, err = builder.replaceSimpleTx(replaced.Tx, cancel, feePerByte, statusCallback)
+		case transaction_type.TX_ZETHER:
+			tx, created, err = builder.replaceZetherTx(replaced.Tx, cancel, feePerByte, ctx, statusCallback)
+		default:
+			return nil, errors.New("Invalid Tx Version")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var fee uint64
+		if fee, err = tx.GetAllFee(); err != nil {
+			return nil, err
+		}
+		if fee >= replacedFee {
+			break
+		}
+
+		//a smaller replacement needs to pay at least the fee of the replaced tx
+		feePerByte = feePerByte*replacedFee/fee + 1
+		tx = nil
+	}
+
+	if tx == nil {
+		return nil, errors.New("Replacement fee is lower than the fee of the replaced transaction")
+	}
+	statusCallback("Replacement Transaction Created")
+
+	if propagateTx {
+		if err = builder.mempool.AddTxToMempool(tx, replaced.ChainHeight, true, awaitAnswer, awaitBroadcast, advanced_connection_types.UUID_ALL, ctx); err != nil {
+			return nil, err
+		}
+		if created != nil {
+			builder.lock.Lock()
+			builder.storeCreatedZetherTx(tx.Bloom.HashStr, created)
+			builder.lock.Unlock()
+		}
+	}
+
+	return tx, nil
+}
+
+func (builder *TxsBuilder) replaceSimpleTx(replaced *transaction.Transaction, cancel bool, feePerByte uint64, statusCallback func(string)) (*transaction.Transaction, error) {
+
+	txBase := replaced.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+	if !txBase.HasVin() {
+		return nil, errors.New("Transaction was not created by the wallet")
+	}
+
+	addr := builder.wallet.GetWalletAddressByPublicKey(txBase.Vin.PublicKey, true)
+	if addr == nil || addr.PrivateKey == nil {
+		return nil, errors.New("Transaction was not created by the wallet")
+	}
+
+	transfer := &wizard.WizardTxSimpleTransfer{
+		nil,
+		&wizard.WizardTransactionData{nil, false},
+		&wizard.WizardTransactionFee{0, feePerByte, config_fees.FEE_PER_BYTE_EXTRA_SPACE, false},
+		txBase.Nonce,
+		addr.PrivateKey.Key,
+	}
+
+	switch txBase.TxScript {
+	case transaction_simple.SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY:
+		if cancel {
+			transfer.Extra = &wizard.WizardTxSimpleExtraUpdateAssetFeeLiquidity{nil, nil, false, nil}
+		} else {
+			extra := txBase.Extra.(*transaction_simple_extra.TransactionSimpleExtraUpdateAssetFeeLiquidity)
+			transfer.Extra = &wizard.WizardTxSimpleExtraUpdateAssetFeeLiquidity{nil, extra.Liquidities, extra.NewCollector, extra.Collector}
+		}
+	case transaction_simple.SCRIPT_CLAIM:
+		if cancel {
+			return nil, errors.New("Claim transactions can only be bumped")
+		}
+		extra := txBase.Extra.(*transaction_simple_extra.TransactionSimpleExtraClaim)
+		transfer.Extra = &wizard.WizardTxSimpleExtraClaim{nil, extra.Amount, extra.ReceiverPublicKey}
+	default:
+		return nil, errors.New("Transaction can not be replaced")
+	}
+
+	if !cancel {
+		if txBase.DataVersion == transaction_data.TX_DATA_ENCRYPTED {
+			return nil, errors.New("Transactions with encrypted data can only be canceled")
+		}
+		transfer.Data.Data = txBase.Data
+	}
+
+	return wizard.CreateSimpleTx(transfer, false, statusCallback)
+}
+
+func (builder *TxsBuilder) replaceZetherTx(replaced *transaction.Transaction, cancel bool, feePerByte uint64, ctx context.Context, statusCallback func(string)) (*transaction.Transaction, *TxBuilderCreateZetherTxData, error) {
+
+	var txData *TxBuilderCreateZetherTxData
+
+	if cancel {
+		var err error
+		if txData, err = builder.cancelZetherTxData(replaced); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		builder.lock.Lock()
+		created := builder.createdZetherTxs[replaced.Bloom.HashStr]
+		builder.lock.Unlock()
+
+		if created == nil {
+			return nil, nil, errors.New("Transaction data is not available. The transaction can only be canceled")
+		}
+
+		txData = created.clone()
+		for _, payload := range txData.Payloads {
+			payload.WitnessIndexes = nil
+		}
+	}
+
+	for _, payload := range txData.Payloads {
+		payload.Fee = &wizard.WizardZetherTransactionFee{&wizard.WizardTransactionFee{0, 0, 0, true}, true, 0, 0}
+	}
+
+	//the new transaction spends the balances used by the replaced transaction
+	pendingTxs := builder.mempool.Txs.GetTxsOnlyList()
+	for i, tx := range pendingTxs {
+		if tx.Bloom.HashStr == replaced.Bloom.HashStr {
+			pendingTxs = append(pendingTxs[:i], pendingTxs[i+1:]...)
+			break
+		}
+	}
+
+	//prebuild completes the payloads
+	created := txData.clone()
+
+	tx, err := builder.createZetherTx(txData, pendingTxs, feePerByte, false, false, false, ctx, statusCallback)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tx, created, nil
+}
+
+// cancelZetherTxData creates empty payloads spending from the wallet senders of the replaced transaction
+func (builder *TxsBuilder) cancelZetherTxData(replaced *transaction.Transaction) (*TxBuilderCreateZetherTxData, error) {
+
+	txBase := replaced.TransactionBaseInterface.(*transaction_zether.TransactionZether)
+
+	txData := &TxBuilderCreateZetherTxData{}
+
+	for t, payload := range txBase.Payloads {
+		for i, publicKey := range txBase.Bloom.PublicKeyLists[t] {
+			if (i%2 == 0) != payload.Parity {
+				continue
+			}
+
+			addr := builder.wallet.GetWalletAddressByPublicKey(publicKey, true)
+			if addr == nil || addr.PrivateKey == nil {
+				continue
+			}
+
+			decrypted, err := builder.wallet.DecryptTx(replaced, publicKey)
+			if err != nil {
+				return nil, err
+			}
+			if decrypted.ZetherTx.Payloads[t] == nil || !decrypted.ZetherTx.Payloads[t].WhisperSenderValid {
+				continue
+			}
+
+			if !builder.cancelZetherSenderExists(txData, addr, payload.Asset) {
+				txData.Payloads = append(txData.Payloads, &TxBuilderCreateZetherTxPayload{
+					Sender:            addr.AddressEncoded,
+					Asset:             payload.Asset,
+					RingConfiguration: &ZetherRingConfiguration{len(txBase.Bloom.PublicKeyLists[t]), &ZetherSenderRingType{false, false, nil, 0}, &ZetherRecipientRingType{false, false, nil, 0}},
+				})
+			}
+			break
+		}
+	}
+
+	if len(txData.Payloads) == 0 {
+		return nil, errors.New("Transaction was not created by the wallet")
+	}
+
+	return txData, nil
+}
+
+func (builder *TxsBuilder) cancelZetherSenderExists(txData *TxBuilderCreateZetherTxData, addr *wallet_address.WalletAddress, asset []byte) bool {
+	for _, payload := range txData.Payloads {
+		if payload.Sender == addr.AddressEncoded && bytes.Equal(payload.Asset, asset) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package txs_builder
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account/asset_fee_liquidity"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_fees"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/mempool"
+	"pandora-pay/txs_builder/wizard"
+	"testing"
+)
+
+func TestTxsBuilder_AutoFee(t *testing.T) {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive(false)
+	assert.NoError(t, err)
+
+	mempool, err := mempool.CreateMempool(nil)
+	assert.NoError(t, err)
+
+	//a congested block paying 10 per byte, the estimate is 11
+	hash := cryptography.RandomHash()
+	mempool.FeeEstimator.AddBlock(1, []*transaction.Transaction{{
+		TransactionBaseInterface: &transaction_zether.TransactionZether{
+			Payloads: []*transaction_zether_payload.TransactionZetherPayload{{Asset: config_coins.NATIVE_ASSET_FULL, Statement: &crypto.Statement{Fee: 10 * config.BLOCK_MAX_SIZE}}},
+		},
+		Version: transaction_type.TX_ZETHER,
+		Bloom:   &transaction.TransactionBloom{Size: config.BLOCK_MAX_SIZE, Hash: hash, HashStr: string(hash)},
+	}})
+	assert.Equal(t, uint64(11), mempool.EstimateFeePerByte(1))
+
+	builder := &TxsBuilder{mempool: mempool}
+
+	for _, test := range []struct {
+		name          string
+		fee           *wizard.WizardTransactionFee
+		minFeePerByte uint64
+		liquidity     *asset_fee_liquidity.AssetFeeLiquidity
+		expected      *wizard.WizardTransactionFee
+	}{
+		{"fixed fee", &wizard.WizardTransactionFee{100, 0, 0, true}, 1, nil, &wizard.WizardTransactionFee{100, 0, 0, true}},
+		{"fee per byte", &wizard.WizardTransactionFee{0, 5, 0, true}, 1, nil, &wizard.WizardTransactionFee{0, 5, 0, true}},
+		{"no automatic fee", &wizard.WizardTransactionFee{0, 0, 0, false}, 1, nil, &wizard.WizardTransactionFee{0, 0, 0, false}},
+		{"estimated fee", &wizard.WizardTransactionFee{0, 0, 0, true}, 1, nil, &wizard.WizardTransactionFee{0, 11, config_fees.FEE_PER_BYTE_EXTRA_SPACE, true}},
+		{"minimum fee higher than the estimate", &wizard.WizardTransactionFee{0, 0, 0, true}, 20, nil, &wizard.WizardTransactionFee{0, 20, config_fees.FEE_PER_BYTE_EXTRA_SPACE, true}},
+		{"asset fee", &wizard.WizardTransactionFee{0, 0, 0, true}, 1, &asset_fee_liquidity.AssetFeeLiquidity{nil, 4, 1}, &wizard.WizardTransactionFee{0, 28, config_fees.FEE_PER_BYTE_EXTRA_SPACE, true}},
+		{"asset fee without rate", &wizard.WizardTransactionFee{0, 0, 0, true}, 1, &asset_fee_liquidity.AssetFeeLiquidity{nil, 0, 1}, &wizard.WizardTransactionFee{0, 0, 0, true}},
+	} {
+		fee, err := builder.autoFee(test.fee, test.minFeePerByte, test.liquidity)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, fee, test.name)
+	}
+
+	fee := &wizard.WizardTransactionFee{0, 0, 0, true}
+	_, err = builder.autoFee(fee, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &wizard.WizardTransactionFee{0, 0, 0, true}, fee, "the fee of the caller is not changed")
+}
@@ -19,6 +19,7 @@ import (
 	"pandora-pay/blockchain/data_storage/registrations/registration"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_fees"
 	"pandora-pay/cryptography/bn256"
 	"pandora-pay/cryptography/crypto"
 	"pandora-pay/gui"
@@ -267,7 +268,7 @@ func (builder *TxsBuilder) createZetherRing(allAlreadyUsed map[string]bool, send
 	return
 }
 
-func (builder *TxsBuilder) prebuild(txData *TxBuilderCreateZetherTxData, pendingTxs []*transaction.Transaction, blockHeight uint64, prevKernelHash []byte, ctx context.Context, statusCallback func(string)) ([]*wizard.WizardZetherTransfer, map[string]map[string][]byte, map[string]bool, [][]*bn256.G1, [][]*bn256.G1, map[string]*wizard.WizardZetherPublicKeyIndex, uint64, []byte, error) {
+func (builder *TxsBuilder) prebuild(txData *TxBuilderCreateZetherTxData, pendingTxs []*transaction.Transaction, blockHeight uint64, prevKernelHash []byte, forgingSigner bool, ctx context.Context, statusCallback func(string)) ([]*wizard.WizardZetherTransfer, map[string]map[string][]byte, map[string]bool, [][]*bn256.G1, [][]*bn256.G1, map[string]*wizard.WizardZetherPublicKeyIndex, uint64, []byte, error) {
 
 	sendersPrivateKeys := make([]*addresses.PrivateKey, len(txData.Payloads))
 	sendersWalletAddresses := make([]*wallet_address.WalletAddress, len(txData.Payloads))
@@ -300,6 +301,8 @@ func (builder *TxsBuilder) prebuild(txData *TxBuilderCreateZetherTxData, pending
 			}
 			payload.Sender = addr.EncodeAddr()
 
+		} else if _, staking := payload.Extra.(*wizard.WizardZetherPayloadExtraStaking); staking && forgingSigner {
+			//the private key is kept by the forging signer
 		} else {
 
 			addr, err := builder.wallet.GetWalletAddressByEncodedAddress(payload.Sender, true)
@@ -480,9 +483,14 @@ func (builder *TxsBuilder) prebuild(txData *TxBuilderCreateZetherTxData, pending
 				payload.Fee.LeadingZeros = assetFeeLiquidity.LeadingZeros
 			}
 
+			var senderPrivateKey []byte
+			if sendersPrivateKeys[t] != nil { //missing when the transfer is signed by the forging signer
+				senderPrivateKey = sendersPrivateKeys[t].Key[:]
+			}
+
 			transfers[t] = &wizard.WizardZetherTransfer{
 				Asset:            payload.Asset,
-				SenderPrivateKey: sendersPrivateKeys[t].Key[:],
+				SenderPrivateKey: senderPrivateKey,
 				Recipient:        payload.Recipient,
 				Amount:           payload.Amount,
 				Burn:             payload.Burn,
@@ -622,7 +630,11 @@ func (builder *TxsBuilder) prebuild(txData *TxBuilderCreateZetherTxData, pending
 		verify := true
 
 		if sendersWalletAddresses[t] == nil {
-			transfers[t].SenderDecryptedBalance = transfers[t].Amount
+			if sendersPrivateKeys[t] == nil { //it was decrypted by the forging signer
+				transfers[t].SenderDecryptedBalance = txData.Payloads[t].DecryptedBalance
+			} else {
+				transfers[t].SenderDecryptedBalance = transfers[t].Amount
+			}
 		} else if sendersEncryptedBalances[t] != nil {
 
 			if txData.Payloads[t].DecryptedBalance > 0 { // in case it was specified to avoid getting stuck
@@ -659,6 +671,10 @@ func (builder *TxsBuilder) prebuild(txData *TxBuilderCreateZetherTxData, pending
 }
 
 func (builder *TxsBuilder) CreateZetherTx(txData *TxBuilderCreateZetherTxData, pendingTxs []*transaction.Transaction, propagateTx, awaitAnswer, awaitBroadcast bool, validateTx bool, ctx context.Context, statusCallback func(string)) (*transaction.Transaction, error) {
+	return builder.createZetherTx(txData, pendingTxs, config_fees.FEE_PER_BYTE_ZETHER, propagateTx, awaitAnswer, awaitBroadcast, ctx, statusCallback)
+}
+
+func (builder *TxsBuilder) createZetherTx(txData *TxBuilderCreateZetherTxData, pendingTxs []*transaction.Transaction, minFeePerByte uint64, propagateTx, awaitAnswer, awaitBroadcast bool, ctx context.Context, statusCallback func(string)) (*transaction.Transaction, error) {
 
 	if pendingTxs == nil {
 		pendingTxs = builder.mempool.Txs.GetTxsOnlyList()
@@ -667,14 +683,23 @@ func (builder *TxsBuilder) CreateZetherTx(txData *TxBuilderCreateZetherTxData, p
 	builder.lock.Lock()
 	defer builder.lock.Unlock()
 
-	transfers, emap, hasRollovers, ringsSenderMembers, ringsRecipientMembers, publicKeyIndexes, chainHeight, chainKernelHash, err := builder.prebuild(txData, pendingTxs, 0, nil, ctx, statusCallback)
+	//the payloads are completed by prebuild
+	created := txData.clone()
+
+	transfers, emap, hasRollovers, ringsSenderMembers, ringsRecipientMembers, publicKeyIndexes, chainHeight, chainKernelHash, err := builder.prebuild(txData, pendingTxs, 0, nil, false, ctx, statusCallback)
 	if err != nil {
 		return nil, err
 	}
 
 	feesFinal := make([]*wizard.WizardTransactionFee, len(txData.Payloads))
 	for t, payload := range txData.Payloads {
-		feesFinal[t] = payload.Fee.WizardTransactionFee
+		var liquidity *asset_fee_liquidity.AssetFeeLiquidity
+		if !bytes.Equal(payload.Asset, config_coins.NATIVE_ASSET_FULL) {
+			liquidity = &asset_fee_liquidity.AssetFeeLiquidity{payload.Asset, payload.Fee.Rate, payload.Fee.LeadingZeros}
+		}
+		if feesFinal[t], err = builder.autoFee(payload.Fee.WizardTransactionFee, minFeePerByte, liquidity); err != nil {
+			return nil, err
+		}
 	}
 
 	var tx *transaction.Transaction
@@ -690,6 +715,7 @@ func (builder *TxsBuilder) CreateZetherTx(txData *TxBuilderCreateZetherTxData, p
 		if err = builder.mempool.AddTxToMempool(tx, chainHeight, true, awaitAnswer, awaitBroadcast, advanced_connection_types.UUID_ALL, ctx); err != nil {
 			return nil, err
 		}
+		builder.storeCreatedZetherTx(tx.Bloom.HashStr, created)
 	}
 
 	return tx, nil
@@ -720,6 +746,13 @@ func (builder *TxsBuilder) CreateForgingTransactions(blkComplete *block_complete
 	builder.lock.Lock()
 	defer builder.lock.Unlock()
 
+	rewardRecipient := forger.EncodeAddr()
+	if builder.forgingReward != nil {
+		if recipient := builder.forgingReward.GetForgingRewardRecipient(forgerPublicKey); recipient != "" {
+			rewardRecipient = recipient
+		}
+	}
+
 	//reward
 	txData := &TxBuilderCreateZetherTxData{
 		Payloads: []*TxBuilderCreateZetherTxPayload{
@@ -741,7 +774,7 @@ func (builder *TxsBuilder) CreateForgingTransactions(blkComplete *block_complete
 				config_coins.NATIVE_ASSET_FULL,
 				finalForgerReward,
 				finalForgerReward, //reward will be the encrypted Balance
-				forger.EncodeAddr(),
+				rewardRecipient,
 				0,
 				&ZetherRingConfiguration{64, &ZetherSenderRingType{true, false, nil, 0}, &ZetherRecipientRingType{true, false, nil, 0}},
 				nil,
@@ -752,7 +785,9 @@ func (builder *TxsBuilder) CreateForgingTransactions(blkComplete *block_complete
 		},
 	}
 
-	transfers, emap, hasRollovers, ringsSenderMembers, ringsRecipientMembers, publicKeyIndexes, _, _, err := builder.prebuild(txData, pendingTxs, blkComplete.Height, blkComplete.PrevKernelHash, context.Background(), func(string) {})
+	forgingSigner := builder.isSignedByForgingSigner(forgerPublicKey)
+
+	transfers, emap, hasRollovers, ringsSenderMembers, ringsRecipientMembers, publicKeyIndexes, _, _, err := builder.prebuild(txData, pendingTxs, blkComplete.Height, blkComplete.PrevKernelHash, forgingSigner, context.Background(), func(string) {})
 	if err != nil {
 		return nil, err
 	}
@@ -765,14 +800,30 @@ func (builder *TxsBuilder) CreateForgingTransactions(blkComplete *block_complete
 	}
 
 	var tx *transaction.Transaction
-	if tx, err = wizard.CreateZetherTx(transfers, emap, hasRollovers, ringsSenderMembers, ringsRecipientMembers, chainHeight, blkComplete.PrevKernelHash, publicKeyIndexes, feesFinal, context.Background(), func(string) {}); err != nil {
-		return nil, err
-	}
+	if forgingSigner {
 
-	gui.GUI.Info("CreateForgingTransactions 3")
+		if tx, err = builder.forgingSigner.SignForgingTransaction(forgerPublicKey, transfers, finalForgerReward, emap, hasRollovers, ringsSenderMembers, ringsRecipientMembers, chainHeight, blkComplete.PrevKernelHash, publicKeyIndexes); err != nil {
+			return nil, err
+		}
 
-	if err = builder.txsValidator.MarkAsValidatedTx(tx); err != nil {
-		return nil, err
+		gui.GUI.Info("CreateForgingTransactions 3")
+
+		//the transaction was created by a different process
+		if err = builder.txsValidator.ValidateTx(tx); err != nil {
+			return nil, err
+		}
+
+	} else {
+
+		if tx, err = wizard.CreateZetherTx(transfers, emap, hasRollovers, ringsSenderMembers, ringsRecipientMembers, chainHeight, blkComplete.PrevKernelHash, publicKeyIndexes, feesFinal, context.Background(), func(string) {}); err != nil {
+			return nil, err
+		}
+
+		gui.GUI.Info("CreateForgingTransactions 3")
+
+		if err = builder.txsValidator.MarkAsValidatedTx(tx); err != nil {
+			return nil, err
+		}
 	}
 
 	//if err = builder.txsValidator.ValidateTx(tx); err != nil {
@@ -781,5 +832,9 @@ func (builder *TxsBuilder) CreateForgingTransactions(blkComplete *block_complete
 
 	gui.GUI.Info("CreateForgingTransactions 4")
 
+	if builder.forgingReward != nil {
+		builder.forgingReward.ForgingRewardCreated(blkComplete.Height, forgerPublicKey, blkComplete.StakingAmount, finalForgerReward, tx)
+	}
+
 	return tx, nil
 }
@@ -57,12 +57,18 @@ func CreateSimpleTx(transfer *WizardTxSimpleTransfer, validateTx bool, statusCal
 		}
 		txBase.TxScript = transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT
 		transfer.Fee = &WizardTransactionFee{0, 0, 0, false}
+	case *WizardTxSimpleExtraClaim:
+		txBase.Extra = &transaction_simple_extra.TransactionSimpleExtraClaim{nil,
+			txExtra.Amount,
+			txExtra.ReceiverPublicKey,
+		}
+		txBase.TxScript = transaction_simple.SCRIPT_CLAIM
 	}
 
 	var privateKey *addresses.PrivateKey
 
 	switch txBase.TxScript {
-	case transaction_simple.SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY:
+	case transaction_simple.SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY, transaction_simple.SCRIPT_CLAIM:
 		if privateKey, err = addresses.NewPrivateKey(transfer.Key); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,90 @@
+package wizard
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_extra"
+	"pandora-pay/cryptography"
+)
+
+func (extra *WizardTxSimpleExtraResolutionConditionalPayment) MessageForSigning() []byte {
+	txExtra := &transaction_simple_extra.TransactionSimpleExtraResolutionConditionalPayment{nil,
+		extra.TxId,
+		extra.PayloadIndex,
+		extra.Resolution,
+		nil, nil,
+	}
+	return txExtra.MessageForSigning()
+}
+
+// AddSignature appends a co-signer signature to a partial resolution
+func (extra *WizardTxSimpleExtraResolutionConditionalPayment) AddSignature(publicKey, signature []byte) error {
+
+	if len(publicKey) != cryptography.PublicKeySize {
+		return errors.New("Invalid Public Key")
+	}
+	if len(signature) != cryptography.SignatureSize {
+		return errors.New("Invalid Signature")
+	}
+
+	for i := range extra.MultisigPublicKeys {
+		if bytes.Equal(extra.MultisigPublicKeys[i], publicKey) {
+			return errors.New("Public Key already signed the resolution")
+		}
+	}
+
+	if len(extra.MultisigPublicKeys) >= conditional_payment.MULTISIG_PUBLIC_KEYS_MAX {
+		return errors.New("Too many signatures")
+	}
+
+	single := &transaction_simple_extra.TransactionSimpleExtraResolutionConditionalPayment{nil,
+		extra.TxId,
+		extra.PayloadIndex,
+		extra.Resolution,
+		[][]byte{publicKey},
+		[][]byte{signature},
+	}
+	if !single.VerifySignature() {
+		return errors.New("Resolution signature is not valid")
+	}
+
+	extra.MultisigPublicKeys = append(extra.MultisigPublicKeys, publicKey)
+	extra.Signatures = append(extra.Signatures, signature)
+
+	return nil
+}
+
+// Merge adds the signatures of another partial resolution of the same conditional payment
+func (extra *WizardTxSimpleExtraResolutionConditionalPayment) Merge(other *WizardTxSimpleExtraResolutionConditionalPayment) error {
+
+	if !bytes.Equal(extra.TxId, other.TxId) || extra.PayloadIndex != other.PayloadIndex {
+		return errors.New("Partial resolutions are for different conditional payments")
+	}
+	if extra.Resolution != other.Resolution {
+		return errors.New("Partial resolutions have different resolutions")
+	}
+	if len(other.MultisigPublicKeys) != len(other.Signatures) {
+		return errors.New("Signatures and Public Keys Mismatch")
+	}
+
+	for i := range other.MultisigPublicKeys {
+
+		found := false
+		for j := range extra.MultisigPublicKeys {
+			if bytes.Equal(extra.MultisigPublicKeys[j], other.MultisigPublicKeys[i]) {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		if err := extra.AddSignature(other.MultisigPublicKeys[i], other.Signatures[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
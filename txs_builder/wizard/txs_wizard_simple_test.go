@@ -1 +1,85 @@
 package wizard
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_extra"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"testing"
+)
+
+func TestMergeResolutionConditionalPayment(t *testing.T) {
+
+	txId := helpers.RandomBytes(cryptography.HashSize)
+
+	partials := make([]*WizardTxSimpleExtraResolutionConditionalPayment, 3)
+	for i := range partials {
+
+		partials[i] = &WizardTxSimpleExtraResolutionConditionalPayment{nil, txId, 1, true, nil, nil}
+
+		privateKey := addresses.GenerateNewPrivateKey()
+		signature, err := privateKey.Sign(partials[i].MessageForSigning())
+		assert.NoError(t, err)
+
+		assert.NoError(t, partials[i].AddSignature(privateKey.GeneratePublicKey(), signature))
+		assert.Error(t, partials[i].AddSignature(privateKey.GeneratePublicKey(), signature), "duplicate signer")
+	}
+
+	merged := &WizardTxSimpleExtraResolutionConditionalPayment{nil, txId, 1, true, nil, nil}
+	for _, partial := range partials {
+		assert.NoError(t, merged.Merge(partial))
+		assert.NoError(t, merged.Merge(partial), "merging the same partial twice should be ignored")
+	}
+	assert.Equal(t, len(partials), len(merged.MultisigPublicKeys))
+	assert.Equal(t, len(partials), len(merged.Signatures))
+
+	other := &WizardTxSimpleExtraResolutionConditionalPayment{nil, txId, 1, false, nil, nil}
+	assert.Error(t, other.Merge(partials[0]), "different resolutions can not be merged")
+
+	invalid := &WizardTxSimpleExtraResolutionConditionalPayment{nil, txId, 1, true, nil, nil}
+	assert.Error(t, invalid.AddSignature(partials[0].MultisigPublicKeys[0], helpers.RandomBytes(cryptography.SignatureSize)))
+
+}
+
+func TestCreateSimpleTxClaim(t *testing.T) {
+
+	privateKey := addresses.GenerateNewPrivateKey()
+	receiver := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+
+	transfer := &WizardTxSimpleTransfer{
+		&WizardTxSimpleExtraClaim{nil, 1000, receiver},
+		&WizardTransactionData{nil, false},
+		&WizardTransactionFee{0, 0, 0, false},
+		5,
+		privateKey.Key,
+	}
+
+	tx, err := CreateSimpleTx(transfer, true, func(string) {})
+	assert.NoError(t, err)
+
+	tx2 := &transaction.Transaction{}
+	assert.NoError(t, tx2.Deserialize(advanced_buffers.NewBufferReader(tx.Bloom.Serialized)))
+	assert.NoError(t, tx2.BloomAll())
+	assert.True(t, tx2.VerifySignatureManually())
+
+	base := tx2.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+	assert.Equal(t, transaction_simple.SCRIPT_CLAIM, base.TxScript)
+	assert.Equal(t, uint64(5), base.Nonce)
+	assert.Equal(t, privateKey.GeneratePublicKey(), base.Vin.PublicKey)
+
+	extra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraClaim)
+	assert.Equal(t, uint64(1000), extra.Amount)
+	assert.Equal(t, receiver, extra.ReceiverPublicKey)
+
+	keys := make(map[string]bool)
+	base.ComputeAllKeys(keys)
+	assert.True(t, keys[string(receiver)])
+
+	transfer.Extra = &WizardTxSimpleExtraClaim{nil, 0, receiver}
+	_, err = CreateSimpleTx(transfer, true, func(string) {})
+	assert.Error(t, err, "claim amount must be greater than zero")
+}
@@ -23,6 +23,12 @@ type WizardTxSimpleExtraResolutionConditionalPayment struct {
 	Signatures          [][]byte `json:"signatures" msgpack:"signatures"`
 }
 
+type WizardTxSimpleExtraClaim struct {
+	WizardTxSimpleExtra `json:"-"  msgpack:"-"`
+	Amount              uint64 `json:"amount" msgpack:"amount"`
+	ReceiverPublicKey   []byte `json:"receiverPublicKey" msgpack:"receiverPublicKey"`
+}
+
 type WizardTxSimpleTransfer struct {
 	Extra WizardTxSimpleExtra    `json:"extra" msgpack:"extra"`
 	Data  *WizardTransactionData `json:"data" msgpack:"data"`
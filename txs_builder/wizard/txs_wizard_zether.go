@@ -236,6 +236,7 @@ func signZetherTx(tx *transaction.Transaction, txBase *transaction_zether.Transa
 
 	payloads := make([]*transaction_zether_payload.TransactionZetherPayload, len(transfers))
 	privateKeysForSign := make([]*addresses.PrivateKey, len(transfers))
+	spendPrivateKeysForSign := make([]*addresses.PrivateKey, len(transfers)) //only for registration update
 
 	spaceExtra := 0
 
@@ -304,6 +305,56 @@ func signZetherTx(tx *transaction.Transaction, txBase *transaction_zether.Transa
 
 				spaceExtra += 1 + len(payloadExtra.ReceiverPublicKey) + 66
 
+			case *WizardZetherPayloadExtraAssetSupplyDecrease:
+				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE
+				if privateKeysForSign[t], err = addresses.NewPrivateKey(payloadExtra.AssetSupplyPrivateKey); err != nil {
+					return
+				}
+				payloads[t].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyDecrease{nil,
+					privateKeysForSign[t].GeneratePublicKey(),
+					helpers.EmptyBytes(cryptography.SignatureSize),
+				}
+
+			case *WizardZetherPayloadExtraAssetUpdate:
+				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_ASSET_UPDATE
+				if privateKeysForSign[t], err = addresses.NewPrivateKey(payloadExtra.AssetUpdatePrivateKey); err != nil {
+					return
+				}
+				payloads[t].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetUpdate{nil,
+					payloadExtra.AssetId,
+					payloadExtra.UpdateType,
+					payloadExtra.NewPublicKey,
+					payloadExtra.Name,
+					payloadExtra.Description,
+					payloadExtra.Data,
+					privateKeysForSign[t].GeneratePublicKey(),
+					helpers.EmptyBytes(cryptography.SignatureSize),
+				}
+
+			case *WizardZetherPayloadExtraRegistrationUpdate:
+				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_REGISTRATION_UPDATE
+				if privateKeysForSign[t], err = addresses.NewPrivateKey(payloadExtra.PrivateKey); err != nil {
+					return
+				}
+				extra := &transaction_zether_payload_extra.TransactionZetherPayloadExtraRegistrationUpdate{nil,
+					privateKeysForSign[t].GeneratePublicKey(),
+					payloadExtra.UpdateType,
+					payloadExtra.NewSpendPublicKey,
+					nil,
+					helpers.EmptyBytes(cryptography.SignatureSize),
+					nil,
+				}
+				if len(payloadExtra.SpendPrivateKey) > 0 {
+					if spendPrivateKeysForSign[t], err = addresses.NewPrivateKey(payloadExtra.SpendPrivateKey); err != nil {
+						return
+					}
+					extra.SpendPublicKey = spendPrivateKeysForSign[t].GeneratePublicKey()
+					extra.SpendSignature = helpers.EmptyBytes(cryptography.SignatureSize)
+				} else if payloadExtra.UpdateType == transaction_zether_payload_extra.REGISTRATION_UPDATE_SPEND_PUBLIC_KEY {
+					spaceExtra += cryptography.PublicKeySize //registration will store a spend public key
+				}
+				payloads[t].Extra = extra
+
 			case *WizardZetherPayloadExtraPlainAccountFund:
 				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_PLAIN_ACCOUNT_FUND
 				payloads[t].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraPlainAccountFund{
@@ -646,11 +697,24 @@ func signZetherTx(tx *transaction.Transaction, txBase *transaction_zether.Transa
 			switch txBase.Payloads[t].PayloadScript {
 			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE:
 				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyIncrease).AssetSignature = signature
+			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE:
+				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetSupplyDecrease).AssetSignature = signature
+			case transaction_zether_payload_script.SCRIPT_ASSET_UPDATE:
+				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraAssetUpdate).AssetSignature = signature
 			case transaction_zether_payload_script.SCRIPT_SPEND:
 				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraSpend).SenderSpendSignature = signature
+			case transaction_zether_payload_script.SCRIPT_REGISTRATION_UPDATE:
+				txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraRegistrationUpdate).RegistrationSignature = signature
 			}
 
 		}
+		if spendPrivateKeysForSign[t] != nil {
+			var signature []byte
+			if signature, err = spendPrivateKeysForSign[t].Sign(tx.SerializeForSigning()); err != nil {
+				return
+			}
+			txBase.Payloads[t].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraRegistrationUpdate).SpendSignature = signature
+		}
 	}
 
 	statusCallback("Transaction Zether Proofs generated")
@@ -2,6 +2,7 @@ package wizard
 
 import (
 	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_extra"
 )
 
 type WizardZetherPayloadExtraStaking struct {
@@ -30,6 +31,30 @@ type WizardZetherPayloadExtraAssetSupplyIncrease struct {
 	AssetSupplyPrivateKey    []byte `json:"assetSupplyPublicKey" msgpack:"assetSupplyPublicKey"`
 }
 
+type WizardZetherPayloadExtraAssetSupplyDecrease struct {
+	WizardZetherPayloadExtra `json:"-" msgpack:""`
+	AssetSupplyPrivateKey    []byte `json:"assetSupplyPrivateKey" msgpack:"assetSupplyPrivateKey"`
+}
+
+type WizardZetherPayloadExtraAssetUpdate struct {
+	WizardZetherPayloadExtra `json:"-" msgpack:""`
+	AssetId                  []byte                                           `json:"assetId" msgpack:"assetId"`
+	UpdateType               transaction_zether_payload_extra.AssetUpdateType `json:"updateType" msgpack:"updateType"`
+	NewPublicKey             []byte                                           `json:"newPublicKey" msgpack:"newPublicKey"`
+	Name                     string                                           `json:"name" msgpack:"name"`
+	Description              string                                           `json:"description" msgpack:"description"`
+	Data                     []byte                                           `json:"data" msgpack:"data"`
+	AssetUpdatePrivateKey    []byte                                           `json:"assetUpdatePrivateKey" msgpack:"assetUpdatePrivateKey"`
+}
+
+type WizardZetherPayloadExtraRegistrationUpdate struct {
+	WizardZetherPayloadExtra `json:"-" msgpack:""`
+	UpdateType               transaction_zether_payload_extra.RegistrationUpdateType `json:"updateType" msgpack:"updateType"`
+	NewSpendPublicKey        []byte                                                  `json:"newSpendPublicKey" msgpack:"newSpendPublicKey"`
+	PrivateKey               []byte                                                  `json:"privateKey" msgpack:"privateKey"`           //account private key
+	SpendPrivateKey          []byte                                                  `json:"spendPrivateKey" msgpack:"spendPrivateKey"` //current spend private key. Empty if the registration has none
+}
+
 type WizardZetherPayloadExtraPlainAccountFund struct {
 	WizardZetherPayloadExtra `json:"-" msgpack:""`
 	PlainAccountPublicKey    []byte `json:"plainAccountPublicKey" msgpack:"plainAccountPublicKey"`
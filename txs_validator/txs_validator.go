@@ -2,9 +2,12 @@ package txs_validator
 
 import (
 	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
 	"pandora-pay/config"
+	"pandora-pay/cryptography/crypto"
 	"pandora-pay/gui"
 	"pandora-pay/helpers/generics"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -17,7 +20,7 @@ type TxsValidator struct {
 
 func (validator *TxsValidator) MarkAsValidatedTx(tx *transaction.Transaction) error {
 
-	foundWork, loaded := validator.all.LoadOrStore(tx.Bloom.HashStr, &txValidatedWork{make(chan struct{}), TX_VALIDATED_INIT, tx, 0, nil, nil})
+	foundWork, loaded := validator.all.LoadOrStore(tx.Bloom.HashStr, &txValidatedWork{make(chan struct{}), TX_VALIDATED_INIT, tx, 0, nil, nil, nil})
 
 	if !loaded {
 		if err := foundWork.tx.BloomAll(); err != nil {
@@ -45,10 +48,10 @@ func (validator *TxsValidator) MarkAsValidatedTx(tx *transaction.Transaction) er
 
 }
 
-//blocking
+// blocking
 func (validator *TxsValidator) ValidateTx(tx *transaction.Transaction) error {
 
-	foundWork, loaded := validator.all.LoadOrStore(tx.Bloom.HashStr, &txValidatedWork{make(chan struct{}), TX_VALIDATED_INIT, tx, 0, nil, nil})
+	foundWork, loaded := validator.all.LoadOrStore(tx.Bloom.HashStr, &txValidatedWork{make(chan struct{}), TX_VALIDATED_INIT, tx, 0, nil, nil, nil})
 	if !loaded {
 		validator.newValidationWorkCn <- foundWork
 	}
@@ -63,17 +66,28 @@ func (validator *TxsValidator) ValidateTx(tx *transaction.Transaction) error {
 	return nil
 }
 
+// ValidateTxs validates the txs verifying all the zether proofs in a single batch
 func (validator *TxsValidator) ValidateTxs(txs []*transaction.Transaction) error {
 
+	batch := &txsValidatedBatch{crypto.NewProofsBatch(), &sync.WaitGroup{}}
+	var batched []*txValidatedWork
+
 	outputs := make([]*txValidatedWork, len(txs))
 	for i, tx := range txs {
-		foundWork, loaded := validator.all.LoadOrStore(tx.Bloom.HashStr, &txValidatedWork{make(chan struct{}), TX_VALIDATED_INIT, tx, 0, nil, nil})
+		foundWork, loaded := validator.all.LoadOrStore(tx.Bloom.HashStr, &txValidatedWork{make(chan struct{}), TX_VALIDATED_INIT, tx, 0, nil, nil, batch})
 		if !loaded {
+			batch.wg.Add(1)
+			batched = append(batched, foundWork)
 			validator.newValidationWorkCn <- foundWork
 		}
 		outputs[i] = foundWork
 	}
 
+	if len(batched) > 0 {
+		batch.wg.Wait()
+		validator.finalizeBatch(batch, batched)
+	}
+
 	for _, foundWork := range outputs {
 		<-foundWork.wait
 		if foundWork.result != nil {
@@ -88,6 +102,22 @@ func (validator *TxsValidator) ValidateTxs(txs []*transaction.Transaction) error
 	return nil
 }
 
+// finalizeBatch verifies the proofs of the batch. In case the batch fails, the proofs are verified again one by one to find the invalid txs
+func (validator *TxsValidator) finalizeBatch(batch *txsValidatedBatch, works []*txValidatedWork) {
+
+	if !batch.proofs.Verify() {
+		for _, foundWork := range works {
+			if foundWork.result == nil && foundWork.tx.Version == transaction_type.TX_ZETHER {
+				foundWork.result = verifyTx(foundWork.tx, nil)
+			}
+		}
+	}
+
+	for _, foundWork := range works {
+		foundWork.finalize()
+	}
+}
+
 func (validator *TxsValidator) runRemoveExpiredTransactions() {
 
 	c := 0
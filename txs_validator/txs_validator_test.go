@@ -0,0 +1,123 @@
+package txs_validator
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography/bn256"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/txs_builder/wizard"
+	"testing"
+)
+
+func createTestZetherTx(t *testing.T) *transaction.Transaction {
+
+	const ringSize = 4
+	amount := uint64(1000)
+
+	emap := map[string]map[string][]byte{config_coins.NATIVE_ASSET_FULL_STRING: {}}
+	publicKeyIndexes := make(map[string]*wizard.WizardZetherPublicKeyIndex)
+
+	addMember := func(balance uint64) (*addresses.PrivateKey, *addresses.Address, *bn256.G1) {
+		privateKey := addresses.GenerateNewPrivateKey()
+		address, err := privateKey.GenerateAddress(false, nil, true, nil, 0, nil)
+		assert.NoError(t, err)
+
+		point, err := address.GetPoint()
+		assert.NoError(t, err)
+
+		elgamal := crypto.ConstructElGamal(point.G1(), crypto.ElGamal_BASE_G)
+		if balance > 0 {
+			elgamal = elgamal.Plus(new(big.Int).SetUint64(balance))
+		}
+		emap[config_coins.NATIVE_ASSET_FULL_STRING][point.G1().String()] = elgamal.Serialize()
+		publicKeyIndexes[string(address.PublicKey)] = &wizard.WizardZetherPublicKeyIndex{false, 0, false, nil, address.Registration}
+		return privateKey, address, point.G1()
+	}
+
+	senderPrivateKey, _, senderPoint := addMember(amount)
+	_, recipientAddress, recipientPoint := addMember(0)
+
+	ringSenders, ringRecipients := []*bn256.G1{senderPoint}, []*bn256.G1{recipientPoint}
+	for i := 1; i < ringSize/2; i++ {
+		_, _, point := addMember(0)
+		ringSenders = append(ringSenders, point)
+		_, _, point = addMember(0)
+		ringRecipients = append(ringRecipients, point)
+	}
+
+	transfers := []*wizard.WizardZetherTransfer{{
+		Asset:                  config_coins.NATIVE_ASSET_FULL,
+		SenderPrivateKey:       senderPrivateKey.Key,
+		SenderDecryptedBalance: amount,
+		Recipient:              recipientAddress.EncodeAddr(),
+		Amount:                 amount / 2,
+		Data:                   &wizard.WizardTransactionData{[]byte{}, false},
+		WitnessIndexes:         helpers.ShuffleArray_for_Zether(ringSize),
+	}}
+
+	tx, err := wizard.CreateZetherTx(transfers, emap, map[string]bool{}, [][]*bn256.G1{ringSenders}, [][]*bn256.G1{ringRecipients}, 0, helpers.RandomBytes(32), publicKeyIndexes, []*wizard.WizardTransactionFee{{0, 0, 0, false}}, context.Background(), func(string) {})
+	assert.NoError(t, err)
+
+	return tx
+}
+
+// tamperProof changes z_A of the proof, which is verified only by the final checks added to the batch
+func tamperProof(t *testing.T, tx *transaction.Transaction) {
+
+	payload := tx.TransactionBaseInterface.(*transaction_zether.TransactionZether).Payloads[0]
+
+	w := advanced_buffers.NewBufferWriter()
+	payload.Proof.Serialize(w)
+	data := w.Bytes()
+
+	//z_A is followed by T_1, T_2, 7 field elements and the inner product of 7 rounds
+	offset := len(data) - (2*32 + 7*2*33) - 7*32 - 2*33 - 32
+	data[offset+31] ^= 1
+
+	tampered := &crypto.Proof{}
+	assert.NoError(t, tampered.Deserialize(advanced_buffers.NewBufferReader(data), payload.Statement.RingSize/2))
+	payload.Proof = tampered
+
+	assert.NoError(t, tx.BloomAll())
+}
+
+func TestTxsValidator_ValidateTxsTamperedProof(t *testing.T) {
+
+	validator := &TxsValidator{
+		&generics.Map[string, *txValidatedWork]{},
+		nil,
+		make(chan *txValidatedWork, 1),
+	}
+	newTxsValidatorWorker(validator.newValidationWorkCn).start()
+
+	txs := []*transaction.Transaction{createTestZetherTx(t), createTestZetherTx(t), createTestZetherTx(t)}
+	tamperProof(t, txs[1])
+
+	hashForSignature := txs[1].GetHashSigningManually()
+	base := txs[1].TransactionBaseInterface.(*transaction_zether.TransactionZether)
+	payload := base.Payloads[0]
+	assert.True(t, payload.Proof.VerifyBatch(payload.Asset, 0, base.ChainKernelHash, payload.Statement, hashForSignature, payload.BurnValue, crypto.NewProofsBatch()), "the tampered proof must fail only the batch")
+	assert.False(t, payload.Proof.Verify(payload.Asset, 0, base.ChainKernelHash, payload.Statement, hashForSignature, payload.BurnValue))
+
+	assert.EqualError(t, validator.ValidateTxs(txs), "Proof payload 0 failed")
+
+	for i, tx := range txs {
+		work, ok := validator.all.Load(tx.Bloom.HashStr)
+		assert.True(t, ok)
+		if i == 1 {
+			assert.Error(t, work.result)
+		} else {
+			assert.NoError(t, work.result, "the valid txs of a failed batch are accepted")
+		}
+	}
+
+	assert.NoError(t, validator.ValidateTxs([]*transaction.Transaction{createTestZetherTx(t), createTestZetherTx(t)}))
+}
@@ -2,6 +2,8 @@ package txs_validator
 
 import (
 	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/cryptography/crypto"
+	"sync"
 	"time"
 )
 
@@ -12,6 +14,13 @@ type txValidatedWork struct {
 	time       int64
 	result     error
 	bloomExtra any
+	batch      *txsValidatedBatch //nil when the proofs are verified individually
+}
+
+// txsValidatedBatch verifies the zether proofs of many transactions at once
+type txsValidatedBatch struct {
+	proofs *crypto.ProofsBatch
+	wg     *sync.WaitGroup
 }
 
 const (
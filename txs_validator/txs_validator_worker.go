@@ -3,10 +3,13 @@ package txs_validator
 import (
 	"errors"
 	"fmt"
+	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/blockchain/transactions/transaction/transaction_type"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload/transaction_zether_payload_script"
 	"pandora-pay/config"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/metrics"
 	"sync/atomic"
 	"time"
 )
@@ -15,25 +18,32 @@ type TxsValidatorWorker struct {
 	newValidationWorkCn chan *txValidatedWork
 }
 
-func (worker *TxsValidatorWorker) verifyTx(foundWork *txValidatedWork) error {
+// verifyTx verifies the tx. In case proofs is not nil, the final checks of the zether proofs are only added to it
+func verifyTx(tx *transaction.Transaction, proofs *crypto.ProofsBatch) error {
 
-	if err := foundWork.tx.VerifyBloomAll(); err != nil {
+	if err := tx.VerifyBloomAll(); err != nil {
 		return err
 	}
 
-	switch foundWork.tx.Version {
+	switch tx.Version {
 	case transaction_type.TX_SIMPLE:
-		if !foundWork.tx.VerifySignatureManually() {
+		if !tx.VerifySignatureManually() {
 			return errors.New("Signature Verification failed")
 		}
 	case transaction_type.TX_ZETHER:
-		hashForSignature := foundWork.tx.GetHashSigningManually()
+		hashForSignature := tx.GetHashSigningManually()
 
-		base := foundWork.tx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
+		base := tx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
 		//verify signature
 		assetMap := map[string]int{}
 		for payloadIndex, payload := range base.Payloads {
-			if !payload.Proof.Verify(payload.Asset, assetMap[string(payload.Asset)], base.ChainKernelHash, payload.Statement, hashForSignature, payload.BurnValue) {
+			var verified bool
+			if proofs != nil {
+				verified = payload.Proof.VerifyBatch(payload.Asset, assetMap[string(payload.Asset)], base.ChainKernelHash, payload.Statement, hashForSignature, payload.BurnValue, proofs)
+			} else {
+				verified = payload.Proof.Verify(payload.Asset, assetMap[string(payload.Asset)], base.ChainKernelHash, payload.Statement, hashForSignature, payload.BurnValue)
+			}
+			if !verified {
 				return fmt.Errorf("Proof payload %d failed", payloadIndex)
 			}
 			assetMap[string(payload.Asset)] = assetMap[string(payload.Asset)] + 1
@@ -41,7 +51,7 @@ func (worker *TxsValidatorWorker) verifyTx(foundWork *txValidatedWork) error {
 
 		for _, payload := range base.Payloads {
 			switch payload.PayloadScript {
-			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE, transaction_zether_payload_script.SCRIPT_SPEND:
+			case transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE, transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_DECREASE, transaction_zether_payload_script.SCRIPT_ASSET_UPDATE, transaction_zether_payload_script.SCRIPT_REGISTRATION_UPDATE, transaction_zether_payload_script.SCRIPT_SPEND:
 				if payload.Extra.VerifyExtraSignature(hashForSignature, payload.Statement) == false {
 					return errors.New("Extra signature failed")
 				}
@@ -54,25 +64,42 @@ func (worker *TxsValidatorWorker) verifyTx(foundWork *txValidatedWork) error {
 	return nil
 }
 
+func (foundWork *txValidatedWork) finalize() {
+	foundWork.tx = nil
+	foundWork.batch = nil
+	foundWork.time = time.Now().Add(EXPIRE_TIME_MS).Unix()
+	atomic.StoreInt32(&foundWork.status, TX_VALIDATED_PROCCESSED)
+
+	close(foundWork.wait)
+}
+
 func (worker *TxsValidatorWorker) run() {
 
 	for {
 		foundWork, _ := <-worker.newValidationWorkCn
 
+		var proofs *crypto.ProofsBatch
+		if foundWork.batch != nil {
+			proofs = foundWork.batch.proofs
+		}
+
+		start := time.Now()
 		if err := foundWork.tx.BloomAll(); err != nil {
 			foundWork.result = err
 		} else {
 			foundWork.bloomExtra = foundWork.tx.TransactionBaseInterface.GetBloomExtra()
-			if err = worker.verifyTx(foundWork); err != nil {
+			if err = verifyTx(foundWork.tx, proofs); err != nil {
 				foundWork.result = err
 			}
 		}
+		metrics.TxsValidationDuration.Observe(time.Since(start).Seconds())
 
-		foundWork.tx = nil
-		foundWork.time = time.Now().Add(EXPIRE_TIME_MS).Unix()
-		atomic.StoreInt32(&foundWork.status, TX_VALIDATED_PROCCESSED)
-
-		close(foundWork.wait)
+		//the batch is finalized once all its works were processed
+		if foundWork.batch != nil {
+			foundWork.batch.wg.Done()
+		} else {
+			foundWork.finalize()
+		}
 
 		if config.LIGHT_COMPUTATIONS {
 			time.Sleep(50 * time.Millisecond)
@@ -63,6 +63,10 @@ func (wallet *Wallet) setLoaded(newValue bool) {
 	wallet.initWalletCLI()
 }
 
+func (wallet *Wallet) IsForging() bool {
+	return wallet.forging != nil && wallet.forging.IsForging()
+}
+
 func CreateWallet(forging *forging.Forging, mempool *mempool.Mempool, addressBalanceDecryptor *address_balance_decryptor.AddressBalanceDecryptor) (*Wallet, error) {
 
 	wallet := createWallet(forging, mempool, addressBalanceDecryptor, nil)
@@ -16,7 +16,6 @@ import (
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
 	"pandora-pay/blockchain/data_storage/registrations"
 	"pandora-pay/blockchain/data_storage/registrations/registration"
-	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_extra"
 	"pandora-pay/config/config_coins"
 	"pandora-pay/cryptography"
 	"pandora-pay/cryptography/crypto"
@@ -25,6 +24,7 @@ import (
 	"pandora-pay/helpers/files"
 	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/txs_builder/wizard"
 	"pandora-pay/wallet/wallet_address"
 	"pandora-pay/wallet/wallet_address/shared_staked"
 	"strconv"
@@ -616,9 +616,75 @@ func (wallet *Wallet) initWalletCLI() {
 		return
 	}
 
+	readPartialResolution := func(filename string) (*wizard.WizardTxSimpleExtraResolutionConditionalPayment, error) {
+
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		extra := &wizard.WizardTxSimpleExtraResolutionConditionalPayment{}
+		if err = json.Unmarshal(data, extra); err != nil {
+			return nil, err
+		}
+		if len(extra.TxId) != cryptography.HashSize {
+			return nil, errors.New("Invalid TxId in the partial resolution")
+		}
+		if len(extra.MultisigPublicKeys) != len(extra.Signatures) {
+			return nil, errors.New("Signatures and Public Keys Mismatch")
+		}
+
+		return extra, nil
+	}
+
+	writePartialResolution := func(extra *wizard.WizardTxSimpleExtraResolutionConditionalPayment, filename string) (err error) {
+
+		var marshal []byte
+		if marshal, err = json.Marshal(extra); err != nil {
+			return
+		}
+
+		if err = files.WriteFile(filename, string(marshal)); err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite("Partial resolution exported successfully to: ", filename)
+		return
+	}
+
 	cliSignResolutionConditionalPayment := func(cmd string, ctx context.Context) (err error) {
 
-		extra := &transaction_simple_extra.TransactionSimpleExtraResolutionConditionalPayment{}
+		var extra *wizard.WizardTxSimpleExtraResolutionConditionalPayment
+
+		filename := gui.GUI.OutputReadFilename("Path to partial resolution file. Leave empty to create a new one", "resolution", true)
+		if len(filename) > 0 {
+			if _, err = os.Stat(filename); err == nil {
+				if extra, err = readPartialResolution(filename); err != nil {
+					return
+				}
+				gui.GUI.OutputWrite(fmt.Sprintf("Partial resolution for TxId %s payload %d resolution %t already has %d signatures", base64.StdEncoding.EncodeToString(extra.TxId), extra.PayloadIndex, extra.Resolution, len(extra.Signatures)))
+			} else if !os.IsNotExist(err) {
+				return
+			}
+		}
+
+		if extra == nil {
+
+			extra = &wizard.WizardTxSimpleExtraResolutionConditionalPayment{
+				MultisigPublicKeys: make([][]byte, 0),
+				Signatures:         make([][]byte, 0),
+			}
+
+			extra.TxId = gui.GUI.OutputReadBytes("Provide TxId", func(val []byte) bool {
+				return len(val) == cryptography.HashSize
+			})
+
+			extra.PayloadIndex = byte(gui.GUI.OutputReadInt("Payload index", false, 0, func(val int) bool {
+				return val >= 0 && val < 255
+			}))
+
+			extra.Resolution = gui.GUI.OutputReadBool("Resolution.  Use y/n for voting", false, false)
+		}
 
 		privateKey := gui.GUI.OutputReadBytes("Private Key", func(value []byte) bool {
 			return len(value) == cryptography.PrivateKeySize
@@ -628,35 +694,66 @@ func (wallet *Wallet) initWalletCLI() {
 			return
 		}
 
-		extra.TxId = gui.GUI.OutputReadBytes("Provide TxId", func(val []byte) bool {
-			return len(val) == cryptography.HashSize
-		})
-
-		extra.PayloadIndex = byte(gui.GUI.OutputReadInt("Payload index", false, 0, func(val int) bool {
-			return val >= 0 && val < 255
-		}))
-
-		extra.Resolution = gui.GUI.OutputReadBool("Resolution.  Use y/n for voting", false, false)
-
 		signature, err := crypto.SignMessage(extra.MessageForSigning(), privateKey)
 		if err != nil {
 			return
 		}
 
+		if err = extra.AddSignature(pk.GeneratePublicKey(), signature); err != nil {
+			return
+		}
+
 		gui.GUI.OutputWrite(fmt.Sprintf("Public Key: %s", base64.StdEncoding.EncodeToString(pk.GeneratePublicKey())))
 		gui.GUI.OutputWrite(fmt.Sprintf("Signature: %s", base64.StdEncoding.EncodeToString(signature)))
 
-		if filename := gui.GUI.OutputReadFilename("Path to export", "txt", true); len(filename) > 0 {
+		if len(filename) == 0 {
+			filename = gui.GUI.OutputReadFilename("Path to export", "resolution", true)
+		}
 
-			if err = files.WriteFile(filename, fmt.Sprintf("Public Key: %s\n", base64.StdEncoding.EncodeToString(pk.GeneratePublicKey())),
-				fmt.Sprintf("Signature: %s\n", base64.StdEncoding.EncodeToString(signature))); err != nil {
+		if len(filename) > 0 {
+			return writePartialResolution(extra, filename)
+		}
+
+		return
+	}
+
+	cliMergeResolutionConditionalPayments := func(cmd string, ctx context.Context) (err error) {
+
+		var extra, partial *wizard.WizardTxSimpleExtraResolutionConditionalPayment
+
+		for i := 0; ; i++ {
+
+			filename := gui.GUI.OutputReadFilename(fmt.Sprintf("Path to partial resolution file %d. Leave empty to continue", i), "resolution", true)
+			if len(filename) == 0 {
+				break
+			}
+
+			if partial, err = readPartialResolution(filename); err != nil {
 				return
 			}
 
-			gui.GUI.OutputWrite("Exported successfully to: ", filename)
+			if extra == nil {
+				extra = &wizard.WizardTxSimpleExtraResolutionConditionalPayment{
+					TxId:               partial.TxId,
+					PayloadIndex:       partial.PayloadIndex,
+					Resolution:         partial.Resolution,
+					MultisigPublicKeys: make([][]byte, 0),
+					Signatures:         make([][]byte, 0),
+				}
+			}
+
+			if err = extra.Merge(partial); err != nil {
+				return
+			}
 		}
 
-		return
+		if extra == nil {
+			return errors.New("No partial resolution was provided")
+		}
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Merged resolution has %d signatures", len(extra.Signatures)))
+
+		return writePartialResolution(extra, gui.GUI.OutputReadFilename("Path to export", "resolution", false))
 	}
 
 	gui.GUI.CommandDefineCallback("List Addresses", wallet.CliListAddresses, wallet.Loaded)
@@ -682,5 +779,6 @@ func (wallet *Wallet) initWalletCLI() {
 	gui.GUI.CommandDefineCallback("Create (PublicKey, PrivateKey) pair", cliCreatePair, true)
 	gui.GUI.CommandDefineCallback("Sign message using PrivateKey", cliSignMessage, true)
 	gui.GUI.CommandDefineCallback("Sign Resolution Conditional Payment", cliSignResolutionConditionalPayment, true)
+	gui.GUI.CommandDefineCallback("Merge Resolution Conditional Payments", cliMergeResolutionConditionalPayments, true)
 
 }
@@ -66,7 +66,7 @@ func (wallet *Wallet) processRefreshWallets() {
 
 					return
 				}); err != nil {
-					gui.GUI.Error("Error processRefreshWallets", err)
+					gui.Wallet().Error("Error processRefreshWallets", err)
 				}
 
 				for i, acc := range accsList {
@@ -163,7 +163,7 @@ func (wallet *Wallet) AddSharedStakedAddress(addr *wallet_address.WalletAddress,
 		return errors.New("Wallet was not loaded!")
 	}
 
-	if wallet.Count > config_nodes.DELEGATES_MAXIMUM {
+	if wallet.DelegatesCount >= config_nodes.DELEGATES_MAXIMUM {
 		return errors.New("DELEGATES_MAXIMUM exceeded")
 	}
 
@@ -184,6 +184,7 @@ func (wallet *Wallet) AddSharedStakedAddress(addr *wallet_address.WalletAddress,
 	wallet.forging.Wallet.AddWallet(addr.PublicKey, addr.SharedStaked, false, nil, nil, 0)
 
 	wallet.Count += 1
+	wallet.DelegatesCount += 1
 
 	wallet.updateWallet()
 
@@ -400,6 +401,9 @@ func (wallet *Wallet) RemoveAddressByIndex(index int, lock bool) (bool, error) {
 	delete(wallet.addressesMap, string(adr.PublicKey))
 
 	wallet.Count -= 1
+	if removing.IsSharedStaked {
+		wallet.DelegatesCount -= 1
+	}
 
 	wallet.forging.Wallet.RemoveWallet(removing.PublicKey, false, nil, nil, 0)
 
@@ -463,6 +467,48 @@ func (wallet *Wallet) RenameAddressByPublicKey(publicKey []byte, newName string,
 	return true, wallet.saveWalletAddress(addr, false)
 }
 
+// UpdateAddressRegistrationByPublicKey updates the staked status and the spend private key of an address once a registration update is included
+func (wallet *Wallet) UpdateAddressRegistrationByPublicKey(publicKey []byte, staked bool, spendPrivateKey *addresses.PrivateKey, lock bool) (bool, error) {
+
+	if lock {
+		wallet.Lock.Lock()
+		defer wallet.Lock.Unlock()
+	}
+
+	if !wallet.Loaded {
+		return false, errors.New("Wallet was not loaded!")
+	}
+
+	addr := wallet.GetWalletAddressByPublicKey(publicKey, false)
+	if addr == nil {
+		return false, nil
+	}
+
+	var spendPublicKey []byte
+	if spendPrivateKey != nil {
+		spendPublicKey = spendPrivateKey.GeneratePublicKey()
+	}
+
+	addr1, err := addr.PrivateKey.GenerateAddress(staked, spendPublicKey, false, nil, 0, nil)
+	if err != nil {
+		return false, err
+	}
+	addr2, err := addr.PrivateKey.GenerateAddress(staked, spendPublicKey, true, nil, 0, nil)
+	if err != nil {
+		return false, err
+	}
+
+	addr.Staked = staked
+	addr.SpendPrivateKey = spendPrivateKey
+	addr.SpendPublicKey = spendPublicKey
+	addr.SpendRequired = spendPrivateKey != nil
+	addr.AddressEncoded = addr1.EncodeAddr()
+	addr.AddressRegistrationEncoded = addr2.EncodeAddr()
+	addr.Registration = addr2.Registration
+
+	return true, wallet.saveWalletAddress(addr, false)
+}
+
 func (wallet *Wallet) GetWalletAddress(index int, lock bool) (*wallet_address.WalletAddress, error) {
 
 	if lock {
@@ -590,6 +636,34 @@ func (wallet *Wallet) ImportWalletJSON(data []byte) (err error) {
 	return wallet.saveWalletEntire(false)
 }
 
+// GetSharedStakedAddresses returns the addresses delegated to the node
+func (wallet *Wallet) GetSharedStakedAddresses() []*wallet_address.WalletAddress {
+	wallet.Lock.RLock()
+	defer wallet.Lock.RUnlock()
+
+	out := make([]*wallet_address.WalletAddress, 0, wallet.DelegatesCount)
+	for _, addr := range wallet.Addresses {
+		if addr.IsSharedStaked {
+			out = append(out, addr.Clone())
+		}
+	}
+	return out
+}
+
+// GetForgingPublicKeys returns the public keys of the addresses which can forge
+func (wallet *Wallet) GetForgingPublicKeys() [][]byte {
+	wallet.Lock.RLock()
+	defer wallet.Lock.RUnlock()
+
+	out := make([][]byte, 0)
+	for _, addr := range wallet.Addresses {
+		if addr.SharedStaked != nil && addr.SharedStaked.PrivateKey != nil {
+			out = append(out, addr.PublicKey)
+		}
+	}
+	return out
+}
+
 func (wallet *Wallet) GetDelegatesCount() int {
 	wallet.Lock.RLock()
 	defer wallet.Lock.RUnlock()
@@ -9,6 +9,7 @@ import (
 	"pandora-pay/blockchain/data_storage/accounts/account"
 	"pandora-pay/blockchain/data_storage/registrations/registration"
 	"pandora-pay/config/config_coins"
+	"pandora-pay/config/config_nodes"
 	"pandora-pay/config/config_stake"
 	"pandora-pay/gui"
 	"pandora-pay/wallet/wallet_address"
@@ -173,7 +174,8 @@ func (wallet *Wallet) refreshWalletAccount(acc *account.Account, reg *registrati
 
 		wallet.forging.Wallet.RemoveWallet(addr.PublicKey, true, acc, reg, chainHeight)
 
-		if addr.IsSharedStaked {
+		//the delegator node evicts the delegated addresses itself
+		if addr.IsSharedStaked && !config_nodes.DELEGATOR_ENABLED {
 			_, err = wallet.RemoveAddressByPublicKey(addr.PublicKey, true)
 			return
 		}
@@ -116,7 +116,7 @@ func (wallet *Wallet) loadWallet(password string, firstTime bool) error {
 
 		if bytes.Equal(saved, []byte{1}) {
 
-			gui.GUI.Log("Wallet Loading... ")
+			gui.Wallet().Log("Wallet Loading... ")
 
 			var unmarshal []byte
 
@@ -147,6 +147,7 @@ func (wallet *Wallet) loadWallet(password string, firstTime bool) error {
 
 			wallet.Addresses = make([]*wallet_address.WalletAddress, 0)
 			wallet.addressesMap = make(map[string]*wallet_address.WalletAddress)
+			wallet.DelegatesCount = 0
 
 			for i := 0; i < wallet.Count; i++ {
 
@@ -167,6 +168,9 @@ func (wallet *Wallet) loadWallet(password string, firstTime bool) error {
 
 				wallet.Addresses = append(wallet.Addresses, newWalletAddress)
 				wallet.addressesMap[string(newWalletAddress.PublicKey)] = newWalletAddress
+				if newWalletAddress.IsSharedStaked {
+					wallet.DelegatesCount += 1
+				}
 
 			}
 
@@ -194,7 +198,7 @@ func (wallet *Wallet) walletLoaded(firstTime bool) error {
 
 	wallet.updateWallet()
 	globals.MainEvents.BroadcastEvent("wallet/loaded", wallet.Count)
-	gui.GUI.Log("Wallet Loaded! " + strconv.Itoa(wallet.Count))
+	gui.Wallet().Log("Wallet Loaded! " + strconv.Itoa(wallet.Count))
 
 	return nil
 }